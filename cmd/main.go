@@ -34,8 +34,15 @@ func main() {
 		return
 	}
 
+	// turn the free-text asset types into categories
+	err = config.MigrateAssetCategories(db)
+	if err != nil {
+		log.Fatal("Error migrate asset categories :", err)
+		return
+	}
+
 	// serve API
-	api := server.NewRestApi(db)
+	api := server.NewRestApi(db, env)
 	if err = api.Serve(":" + env.AppPort); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	jwksTimeout = 10 * time.Second
+	// jwksMaxAge is how long fetched keys are used before they are fetched again
+	jwksMaxAge = time.Hour
+	// jwksMinInterval limits refetches triggered by unknown key IDs, so tokens with made up
+	// key IDs cannot make every request call the identity provider
+	jwksMinInterval = time.Minute
+	jwksMaxSize     = 1 << 20
+)
+
+// KeySet returns the keys a token can be verified with
+type KeySet interface {
+	Keys(ctx context.Context, kid string) ([]*Key, error)
+}
+
+type staticKeySet struct {
+	keys []*Key
+}
+
+// NewStaticKeySet verifies tokens with a fixed list of keys
+func NewStaticKeySet(keys []*Key) KeySet {
+	return &staticKeySet{keys: keys}
+}
+
+func (s *staticKeySet) Keys(ctx context.Context, kid string) ([]*Key, error) {
+	return matchKeys(s.keys, kid), nil
+}
+
+type remoteKeySet struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	keys      []*Key
+	fetchedAt time.Time
+}
+
+// NewRemoteKeySet verifies tokens with the keys published at a JWKS URL, such as the
+// jwks_uri of an OpenID Connect provider. Keys are cached and fetched again after an hour,
+// or sooner when a token names a key ID that is not cached, to follow key rotation.
+func NewRemoteKeySet(url string) KeySet {
+	return &remoteKeySet{
+		url:    url,
+		client: &http.Client{Timeout: jwksTimeout},
+		now:    time.Now,
+	}
+}
+
+func (s *remoteKeySet) Keys(ctx context.Context, kid string) ([]*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	keys := matchKeys(s.keys, kid)
+	stale := now.Sub(s.fetchedAt) > jwksMaxAge
+	if !stale && (len(keys) > 0 || now.Sub(s.fetchedAt) < jwksMinInterval) {
+		return keys, nil
+	}
+
+	fetched, err := s.fetch(ctx)
+	if err != nil {
+		// keep using the cached keys while the provider is unreachable
+		if s.keys != nil {
+			return keys, nil
+		}
+		return nil, err
+	}
+	s.keys, s.fetchedAt = fetched, now
+	return matchKeys(s.keys, kid), nil
+}
+
+func (s *remoteKeySet) fetch(ctx context.Context) ([]*Key, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: status %d", res.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, jwksMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	return parseJwks(data)
+}
+
+// matchKeys returns the keys with the key ID, or without an ID
+func matchKeys(keys []*Key, kid string) []*Key {
+	matched := []*Key{}
+	for _, v := range keys {
+		if v.Id == "" || kid == "" || v.Id == kid {
+			matched = append(matched, v)
+		}
+	}
+	return matched
+}
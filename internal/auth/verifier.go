@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"assets-api-go/internal/common"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpiredToken     = errors.New("token is expired")
+)
+
+// Config configures authentication. Tokens are verified with the keys at JwksUrl, or with
+// StaticKeys when no URL is set.
+type Config struct {
+	Enabled    bool
+	JwksUrl    string
+	StaticKeys []*Key
+	Issuer     string
+	// Audience lists the accepted audiences, a token must name at least one of them
+	Audience  []string
+	ClockSkew time.Duration
+	// PublicPaths are served without a token, a trailing * matches any path with that prefix
+	PublicPaths []string
+	// TenantClaim names the claim holding the tenant of the caller
+	TenantClaim string
+}
+
+// Verifier checks bearer tokens, which must be signed JWTs (RS, PS, ES or HS with SHA-256,
+// SHA-384 or SHA-512)
+type Verifier struct {
+	keys        KeySet
+	issuer      string
+	audience    []string
+	clockSkew   time.Duration
+	tenantClaim string
+	now         func() time.Time
+}
+
+func NewVerifier(cfg Config) *Verifier {
+	keys := NewStaticKeySet(cfg.StaticKeys)
+	if cfg.JwksUrl != "" {
+		keys = NewRemoteKeySet(cfg.JwksUrl)
+	}
+	return &Verifier{
+		keys:        keys,
+		issuer:      cfg.Issuer,
+		audience:    cfg.Audience,
+		clockSkew:   cfg.ClockSkew,
+		tenantClaim: cfg.TenantClaim,
+		now:         time.Now,
+	}
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the signature, issuer, audience and validity period of a token and returns
+// its principal
+func (v *Verifier) Verify(ctx context.Context, token string) (*common.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	hash, ok := algorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	keys, err := v.keys.Keys(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range keys {
+		if key.Algorithm != "" && key.Algorithm != header.Alg {
+			continue
+		}
+		if verifySignature(header.Alg, hash, key.Value, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims map[string]interface{}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err = decoder.Decode(&claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	if err = v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return principal(claims, v.tenantClaim), nil
+}
+
+func (v *Verifier) validateClaims(claims map[string]interface{}) error {
+	now := v.now()
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return errors.New("token has no expiry")
+	}
+	if now.After(exp.Add(v.clockSkew)) {
+		return ErrExpiredToken
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(v.clockSkew).Before(nbf) {
+		return errors.New("token is not valid yet")
+	}
+	if iat, ok := numericClaim(claims, "iat"); ok && now.Add(v.clockSkew).Before(iat) {
+		return errors.New("token is issued in the future")
+	}
+
+	if v.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.issuer {
+			return fmt.Errorf("invalid issuer %q", iss)
+		}
+	}
+
+	if len(v.audience) > 0 {
+		var audiences []string
+		switch aud := claims["aud"].(type) {
+		case string:
+			audiences = []string{aud}
+		case []interface{}:
+			for _, a := range aud {
+				if s, ok := a.(string); ok {
+					audiences = append(audiences, s)
+				}
+			}
+		}
+		if !containsAny(audiences, v.audience) {
+			return errors.New("invalid audience")
+		}
+	}
+
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return errors.New("token has no subject")
+	}
+	return nil
+}
+
+// algorithms are the accepted JWS algorithms with their hash, "none" is never accepted
+var algorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+	"HS256": crypto.SHA256, "HS384": crypto.SHA384, "HS512": crypto.SHA512,
+}
+
+// verifySignature checks a signature with a key of the type the algorithm needs, so an RSA
+// public key can never be used as an HMAC secret
+func verifySignature(alg string, hash crypto.Hash, key interface{}, signed, signature []byte) bool {
+	digest := hash.New()
+	digest.Write(signed)
+	sum := digest.Sum(nil)
+
+	switch alg[:2] {
+	case "RS":
+		if pub, ok := key.(*rsa.PublicKey); ok {
+			return rsa.VerifyPKCS1v15(pub, hash, sum, signature) == nil
+		}
+	case "PS":
+		if pub, ok := key.(*rsa.PublicKey); ok {
+			return rsa.VerifyPSS(pub, hash, sum, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		}
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return false
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		// each algorithm is bound to one curve, ES256 to P-256, ES384 to P-384, ES512 to P-521
+		if len(signature) != 2*size || curveHashes[pub.Curve.Params().BitSize] != hash {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(pub, sum, r, s)
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return false
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	}
+	return false
+}
+
+var curveHashes = map[int]crypto.Hash{
+	256: crypto.SHA256,
+	384: crypto.SHA384,
+	521: crypto.SHA512,
+}
+
+func principal(claims map[string]interface{}, tenantClaim string) *common.Principal {
+	p := &common.Principal{Claims: claims}
+	p.Subject, _ = claims["sub"].(string)
+	if tenantClaim != "" {
+		p.TenantId, _ = claims[tenantClaim].(string)
+	}
+	p.Email, _ = claims["email"].(string)
+	p.Name, _ = claims["preferred_username"].(string)
+	if p.Name == "" {
+		p.Name, _ = claims["name"].(string)
+	}
+
+	// OAuth 2 tokens carry a space separated scope, some providers a scp list
+	if scope, ok := claims["scope"].(string); ok {
+		p.Scopes = strings.Fields(scope)
+	} else if scp, ok := claims["scp"].([]interface{}); ok {
+		for _, v := range scp {
+			if s, ok := v.(string); ok {
+				p.Scopes = append(p.Scopes, s)
+			}
+		}
+	}
+	return p
+}
+
+func numericClaim(claims map[string]interface{}, name string) (time.Time, bool) {
+	number, ok := claims[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, v := range values {
+		for _, w := range wanted {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
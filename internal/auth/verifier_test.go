@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeSegment(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signToken builds a JWT signed with an *rsa.PrivateKey (RS256), an *ecdsa.PrivateKey (ES256)
+// or a []byte secret (HS256)
+func signToken(t *testing.T, key interface{}, kid string, claims map[string]interface{}) string {
+	var alg string
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		alg = "RS256"
+	case *ecdsa.PrivateKey:
+		alg = "ES256"
+	case []byte:
+		alg = "HS256"
+	}
+	signed := encodeSegment(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encodeSegment(claims)
+	sum := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, sum[:])
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+	assert.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func rsaJwk(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func validClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":                "https://id.example.com/",
+		"aud":                []string{"other-api", "assets-api"},
+		"sub":                "user-1",
+		"preferred_username": "jane",
+		"email":              "jane@example.com",
+		"scope":              "assets:read assets:write",
+		"org":                "acme",
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifierRemoteKeySet(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rotatedKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	// local stand-in for the JWKS endpoint of an identity provider
+	var jwks atomic.Value
+	jwks.Store([]map[string]string{rsaJwk("key-1", &rsaKey.PublicKey)})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": jwks.Load()})
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	verifier := NewVerifier(Config{JwksUrl: server.URL, Issuer: "https://id.example.com/", Audience: []string{"assets-api"}, ClockSkew: time.Minute, TenantClaim: "org"})
+	ctx := context.Background()
+
+	principal, err := verifier.Verify(ctx, signToken(t, rsaKey, "key-1", validClaims(now)))
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+	assert.Equal(t, "jane", principal.Name)
+	assert.Equal(t, "jane@example.com", principal.Email)
+	assert.Equal(t, []string{"assets:read", "assets:write"}, principal.Scopes)
+	assert.Equal(t, "acme", principal.TenantId)
+
+	_, err = verifier.Verify(ctx, signToken(t, rsaKey, "key-1", validClaims(now)))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// an unknown key ID right after a fetch does not reach the provider
+	_, err = verifier.Verify(ctx, signToken(t, rotatedKey, "key-2", validClaims(now)))
+	assert.Equal(t, ErrInvalidSignature, err)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// after the key is rotated at the provider it is picked up once the minimum interval passed
+	jwks.Store([]map[string]string{rsaJwk("key-1", &rsaKey.PublicKey), rsaJwk("key-2", &rotatedKey.PublicKey)})
+	verifier.keys.(*remoteKeySet).now = func() time.Time { return time.Now().Add(2 * jwksMinInterval) }
+	_, err = verifier.Verify(ctx, signToken(t, rotatedKey, "key-2", validClaims(now)))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestVerifierClaims(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	verifier := NewVerifier(Config{
+		StaticKeys: []*Key{{Id: "key-1", Value: &rsaKey.PublicKey}},
+		Issuer:     "https://id.example.com/",
+		Audience:   []string{"assets-api"},
+		ClockSkew:  time.Minute,
+	})
+	verifier.now = func() time.Time { return now }
+
+	withClaims := func(changes map[string]interface{}) map[string]interface{} {
+		claims := validClaims(now)
+		for k, v := range changes {
+			if v == nil {
+				delete(claims, k)
+				continue
+			}
+			claims[k] = v
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		expectError string
+	}{
+		{name: "Valid token", token: signToken(t, rsaKey, "key-1", validClaims(now))},
+		{name: "Expired within the clock skew", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()}))},
+		{name: "Not before within the clock skew", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"nbf": now.Add(30 * time.Second).Unix()}))},
+		{name: "Single audience", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"aud": "assets-api"}))},
+		{name: "Expired", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"exp": now.Add(-2 * time.Minute).Unix()})), expectError: "token is expired"},
+		{name: "No expiry", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"exp": nil})), expectError: "token has no expiry"},
+		{name: "Not valid yet", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"nbf": now.Add(2 * time.Minute).Unix()})), expectError: "token is not valid yet"},
+		{name: "Wrong issuer", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"iss": "https://evil.example.com/"})), expectError: `invalid issuer "https://evil.example.com/"`},
+		{name: "Wrong audience", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"aud": "billing-api"})), expectError: "invalid audience"},
+		{name: "No subject", token: signToken(t, rsaKey, "key-1", withClaims(map[string]interface{}{"sub": nil})), expectError: "token has no subject"},
+		{name: "Signed by another key", token: signToken(t, otherKey, "key-1", validClaims(now)), expectError: "invalid signature"},
+		{name: "Unsigned", token: encodeSegment(map[string]string{"alg": "none"}) + "." + encodeSegment(validClaims(now)) + ".", expectError: `unsupported algorithm "none"`},
+		{name: "Not a JWT", token: "abc.def", expectError: "malformed token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(context.Background(), tt.token)
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+				assert.Nil(t, principal)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", principal.Subject)
+		})
+	}
+}
+
+func TestVerifierKeyTypes(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	secret := []byte("a-shared-secret-of-at-least-32-bytes")
+	now := time.Now()
+
+	ecDer, _ := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	pemKeys, err := ParseKeys(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecDer}))
+	assert.NoError(t, err)
+	_, err = NewVerifier(Config{StaticKeys: pemKeys}).Verify(context.Background(), signToken(t, ecKey, "", validClaims(now)))
+	assert.NoError(t, err)
+
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "oct", "kid": "hmac", "alg": "HS256", "k": base64.RawURLEncoding.EncodeToString(secret)},
+		rsaJwk("rsa", &rsaKey.PublicKey),
+	}})
+	jwksKeys, err := ParseKeys(jwks)
+	assert.NoError(t, err)
+	verifier := NewVerifier(Config{StaticKeys: jwksKeys})
+	_, err = verifier.Verify(context.Background(), signToken(t, secret, "hmac", validClaims(now)))
+	assert.NoError(t, err)
+
+	// the RSA public key is public, it must never be accepted as an HMAC secret
+	rsaJwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{rsaJwk("rsa", &rsaKey.PublicKey)}})
+	forged := signToken(t, rsaJwks, "rsa", validClaims(now))
+	_, err = verifier.Verify(context.Background(), forged)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	_, err = ParseKeys([]byte("not a key"))
+	assert.EqualError(t, err, "no keys found, expected a JWKS document or PEM public keys")
+}
@@ -6,5 +6,201 @@ const (
 	InternalServerError = "internal_server_error"
 	BadRequest          = "bad_request"
 	NotFound            = "not_found"
+	Conflict            = "conflict"
+	InvalidTransition   = "invalid_transition"
+	PayloadTooLarge     = "payload_too_large"
+	UnsupportedMedia    = "unsupported_media_type"
+	Unauthorized        = "unauthorized"
+	Forbidden           = "forbidden"
+	PreconditionFailed  = "precondition_failed"
+	UnprocessableEntity = "unprocessable_entity"
 	Success             = "success"
 )
+
+const (
+	AuditEntityAsset           = "asset"
+	AuditEntityCategory        = "category"
+	AuditEntityLocation        = "location"
+	AuditEntityMaintenancePlan = "maintenance_plan"
+	AuditEntityWarranty        = "warranty"
+	AuditEntityAttachment      = "attachment"
+	AuditEntityRoleAssignment  = "role_assignment"
+	AuditEntityApiKey          = "api_key"
+	AuditEntityWebhook         = "webhook"
+)
+
+const (
+	AuditActionCreate      = "create"
+	AuditActionUpdate      = "update"
+	AuditActionDelete      = "delete"
+	AuditActionRestore     = "restore"
+	AuditActionPurge       = "purge"
+	AuditActionCheckout    = "checkout"
+	AuditActionCheckin     = "checkin"
+	AuditActionTransition  = "transition"
+	AuditActionMaintenance = "maintenance"
+)
+
+// location kinds from the top of the hierarchy down, each level sits below the previous one
+const (
+	LocationKindSite     = "site"
+	LocationKindBuilding = "building"
+	LocationKindFloor    = "floor"
+	LocationKindRoom     = "room"
+)
+
+var LocationKinds = []string{LocationKindSite, LocationKindBuilding, LocationKindFloor, LocationKindRoom}
+
+// asset assignment states used by the status filter of the asset listing
+const (
+	AssignmentStatusAvailable  = "available"
+	AssignmentStatusCheckedOut = "checked_out"
+	AssignmentStatusOverdue    = "overdue"
+)
+
+// conditions an asset can be returned in
+var ReturnConditions = []string{"good", "fair", "poor", "damaged"}
+
+// preventive maintenance plans are due after a number of days or a number of used units
+const (
+	MaintenancePlanKindCalendar = "calendar"
+	MaintenancePlanKindUsage    = "usage"
+)
+
+var MaintenancePlanKinds = []string{MaintenancePlanKindCalendar, MaintenancePlanKindUsage}
+
+// an asset can be covered by a manufacturer warranty or a support contract
+const (
+	WarrantyKindWarranty = "warranty"
+	WarrantyKindContract = "contract"
+)
+
+var WarrantyKinds = []string{WarrantyKindWarranty, WarrantyKindContract}
+
+// DefaultAttachmentTypes are the MIME types accepted for attachments when ATTACHMENT_ALLOWED_TYPES is not set
+var DefaultAttachmentTypes = []string{"application/pdf", "image/jpeg", "image/png", "image/gif", "image/webp", "text/plain"}
+
+// outcome of a row of an asset import
+const (
+	AssetImportRowValid    = "valid"
+	AssetImportRowInvalid  = "invalid"
+	AssetImportRowImported = "imported"
+	AssetImportRowFailed   = "failed"
+)
+
+// how a batch of asset changes is written
+const (
+	// AssetBatchModeAtomic writes all items in one transaction or none of them
+	AssetBatchModeAtomic = "atomic"
+	// AssetBatchModeBestEffort writes every item on its own and reports each outcome
+	AssetBatchModeBestEffort = "best_effort"
+)
+
+var AssetBatchModes = []string{AssetBatchModeAtomic, AssetBatchModeBestEffort}
+
+// roles a subject can be assigned
+const (
+	RoleViewer  = "viewer"
+	RoleEditor  = "editor"
+	RoleFinance = "finance"
+	RoleAdmin   = "admin"
+)
+
+// actions a route or a service check requires
+const (
+	PermissionRead   = "read"
+	PermissionCreate = "create"
+	PermissionUpdate = "update"
+	PermissionDelete = "delete"
+	PermissionExport = "export"
+	PermissionImport = "import"
+	// PermissionValue allows changing the value of an existing asset
+	PermissionValue          = "value"
+	PermissionManageRoles    = "manage_roles"
+	PermissionManageApiKeys  = "manage_api_keys"
+	PermissionManageWebhooks = "manage_webhooks"
+)
+
+var Roles = []string{RoleViewer, RoleEditor, RoleFinance, RoleAdmin}
+
+// RolePermissions lists what each role may do
+var RolePermissions = map[string][]string{
+	RoleViewer:  {PermissionRead},
+	RoleEditor:  {PermissionRead, PermissionCreate, PermissionUpdate, PermissionDelete, PermissionExport, PermissionImport},
+	RoleFinance: {PermissionRead, PermissionUpdate, PermissionExport, PermissionValue},
+	RoleAdmin:   {PermissionRead, PermissionCreate, PermissionUpdate, PermissionDelete, PermissionExport, PermissionImport, PermissionValue, PermissionManageRoles, PermissionManageApiKeys, PermissionManageWebhooks},
+}
+
+// scopes an API key can carry
+const (
+	ScopeAssetsRead  = "assets:read"
+	ScopeAssetsWrite = "assets:write"
+	ScopeAssetsValue = "assets:value"
+)
+
+var Scopes = []string{ScopeAssetsRead, ScopeAssetsWrite, ScopeAssetsValue}
+
+// ScopePermissions lists what each API key scope allows, API keys never manage roles or keys
+var ScopePermissions = map[string][]string{
+	ScopeAssetsRead:  {PermissionRead, PermissionExport},
+	ScopeAssetsWrite: {PermissionCreate, PermissionUpdate, PermissionDelete, PermissionImport},
+	ScopeAssetsValue: {PermissionValue},
+}
+
+// formats of an asset export
+const (
+	AssetExportCSV    = "csv"
+	AssetExportXLSX   = "xlsx"
+	AssetExportNDJSON = "ndjson"
+)
+
+// events emitted to the configured event sink
+const (
+	EventWarrantyExpiring = "warranty.expiring"
+)
+
+// asset events delivered to webhook subscriptions
+const (
+	EventAssetCreated       = "asset.created"
+	EventAssetUpdated       = "asset.updated"
+	EventAssetDeleted       = "asset.deleted"
+	EventAssetRestored      = "asset.restored"
+	EventAssetPurged        = "asset.purged"
+	EventAssetStatusChanged = "asset.status_changed"
+	// EventAll subscribes a webhook to every event
+	EventAll = "*"
+)
+
+var WebhookEvents = []string{EventAssetCreated, EventAssetUpdated, EventAssetDeleted, EventAssetRestored, EventAssetPurged, EventAssetStatusChanged}
+
+// states of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// asset lifecycle statuses
+const (
+	AssetStatusOrdered  = "ordered"
+	AssetStatusInStock  = "in_stock"
+	AssetStatusDeployed = "deployed"
+	AssetStatusInRepair = "in_repair"
+	AssetStatusRetired  = "retired"
+	AssetStatusDisposed = "disposed"
+	AssetStatusLost     = "lost"
+)
+
+var AssetStatuses = []string{AssetStatusOrdered, AssetStatusInStock, AssetStatusDeployed, AssetStatusInRepair, AssetStatusRetired, AssetStatusDisposed, AssetStatusLost}
+
+// DefaultAssetTransitions is the lifecycle graph used when ASSET_TRANSITIONS is not set,
+// it maps a status to the statuses an asset can move to from it
+var DefaultAssetTransitions = map[string][]string{
+	AssetStatusOrdered:  {AssetStatusInStock, AssetStatusLost},
+	AssetStatusInStock:  {AssetStatusDeployed, AssetStatusInRepair, AssetStatusRetired, AssetStatusLost},
+	AssetStatusDeployed: {AssetStatusInStock, AssetStatusInRepair, AssetStatusRetired, AssetStatusLost},
+	AssetStatusInRepair: {AssetStatusInStock, AssetStatusDeployed, AssetStatusRetired, AssetStatusDisposed},
+	AssetStatusRetired:  {AssetStatusInStock, AssetStatusDisposed},
+	AssetStatusLost:     {AssetStatusInStock, AssetStatusDisposed},
+	AssetStatusDisposed: {},
+}
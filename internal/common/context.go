@@ -0,0 +1,104 @@
+package common
+
+import "context"
+
+type contextKey string
+
+const (
+	actorKey     contextKey = "actor"
+	requestIdKey contextKey = "request_id"
+	principalKey contextKey = "principal"
+	tenantKey    contextKey = "tenant"
+)
+
+const (
+	AnonymousActor = "anonymous"
+	SystemActor    = "system"
+	// DefaultTenant owns every row when multi-tenancy is disabled, and the rows written
+	// before it was enabled
+	DefaultTenant = "default"
+)
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	if requestId, ok := ctx.Value(requestIdKey).(string); ok {
+		return requestId
+	}
+	return ""
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string
+	Name    string
+	Email   string
+	Scopes  []string
+	// Roles are the roles assigned to the subject, loaded for each request
+	Roles []string
+	// ApiKeyId is set when the caller authenticated with an API key, its Scopes then grant
+	// the permissions instead of roles
+	ApiKeyId string
+	// TenantId is the tenant of the token or API key, empty when it names none
+	TenantId string
+	// Claims holds every claim of the token, for features that need more than the fields above
+	Claims map[string]interface{}
+}
+
+// WithTenant scopes the database statements run with the context to a tenant
+func WithTenant(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenantId)
+}
+
+// TenantFromContext returns the tenant of the request, empty for background jobs, which
+// work across tenants with tenancy.WithoutTenant
+func TenantFromContext(ctx context.Context) string {
+	tenantId, _ := ctx.Value(tenantKey).(string)
+	return tenantId
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, nil when authentication is disabled
+// or the route is public
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey).(*Principal)
+	return principal
+}
+
+// HasPermission reports whether a role of the caller, or a scope of its API key, allows the
+// action. Without a principal, when authentication is disabled, every action is allowed.
+func HasPermission(ctx context.Context, permission string) bool {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil {
+		return true
+	}
+
+	grants, granted := principal.Roles, RolePermissions
+	if principal.ApiKeyId != "" {
+		grants, granted = principal.Scopes, ScopePermissions
+	}
+	for _, grant := range grants {
+		for _, v := range granted[grant] {
+			if v == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag returns the strong entity tag of a resource version
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// MatchesETag reports whether an If-Match header, a comma separated list of entity tags or *,
+// names the version
+func MatchesETag(ifMatch string, version int64) bool {
+	etag := ETag(version)
+	for _, v := range strings.Split(ifMatch, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || v == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,16 @@
 package config
 
 import (
+	"assets-api-go/internal/common"
 	"assets-api-go/internal/models"
+	"assets-api-go/internal/tenancy"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/glebarez/sqlite"
@@ -73,14 +78,77 @@ func InitDb(env *EnviConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("No database found, set the DB env")
 	}
 
+	if err != nil {
+		return nil, err
+	}
 	log.Println("gorm connection is created")
 
-	return db, err
+	// scope every statement to the tenant of the request
+	if err = db.Use(tenancy.Plugin{}); err != nil {
+		return nil, err
+	}
+	return db, nil
 }
 
 func AutoMigrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&models.Asset{}); err != nil {
+	if err := db.AutoMigrate(&models.Asset{}, &models.AuditLog{}, &models.Category{}, &models.Location{}, &models.Assignment{}, &models.AssetTransition{}, &models.MaintenanceRecord{}, &models.MaintenancePlan{}, &models.Warranty{}, &models.Attachment{}, &models.RoleAssignment{}, &models.ApiKey{}, &models.OutboxEvent{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.WebhookAttempt{}, &models.IdempotencyKey{}); err != nil {
 		return err
 	}
+
+	// role assignments became unique per tenant
+	if db.Migrator().HasIndex(&models.RoleAssignment{}, "idx_role_assignments_subject_role") {
+		if err := db.Migrator().DropIndex(&models.RoleAssignment{}, "idx_role_assignments_subject_role"); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// MigrateAssetCategories creates a category for every distinct type of the assets without
+// a category and links those assets to it, within the tenant of the assets. Types that only
+// differ in case or surrounding spaces share one category. Running it again only picks up
+// assets still without a category.
+func MigrateAssetCategories(db *gorm.DB) error {
+	var types []struct {
+		TenantId string
+		Type     string
+	}
+	// the assets of every tenant are read, the categories are written within their tenant
+	db = db.WithContext(tenancy.WithoutTenant(context.Background()))
+	if err := db.Unscoped().Model(&models.Asset{}).Where("category_id IS NULL").Distinct("tenant_id", "type").Order("tenant_id").Order("type").Scan(&types).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range types {
+			assetType := v.Type
+			name := strings.TrimSpace(assetType)
+			if name == "" {
+				continue
+			}
+
+			tenantTx := tx.WithContext(common.WithTenant(context.Background(), v.TenantId))
+
+			var category models.Category
+			err := tenantTx.Where("LOWER(name) = ?", strings.ToLower(name)).First(&category).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				category = models.Category{Name: name}
+				err = tenantTx.Create(&category).Error
+				if err == nil {
+					log.Println("created category", category.Name, "from asset type")
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if err = tenantTx.Unscoped().Model(&models.Asset{}).Where("category_id IS NULL AND type = ?", assetType).UpdateColumns(map[string]interface{}{
+				"category_id": category.Id,
+				"type":        category.Name,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
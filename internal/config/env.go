@@ -1,9 +1,16 @@
 package config
 
 import (
+	"assets-api-go/internal/auth"
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/events"
+	"assets-api-go/internal/storage"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var requiredEnvVars = []string{
@@ -14,10 +21,41 @@ var requiredEnvVars = []string{
 }
 
 type EnviConfig struct {
-	AppPort      string
-	AppEnv       string
-	DbDriver     string
-	DbConnection string
+	AppPort            string
+	AppEnv             string
+	DbDriver           string
+	DbConnection       string
+	TrashRetentionDays int
+	AssetTransitions   map[string][]string
+	EventSink          string
+	EventWebhookUrl    string
+	// days before its end date a warranty expiry event is emitted, 0 disables the checker
+	WarrantyNoticeDays int
+	Storage            storage.Config
+	// largest accepted attachment in bytes
+	AttachmentMaxSize      int64
+	AttachmentAllowedTypes []string
+	// assets written per transaction by an import, 0 imports a file in one transaction
+	AssetImportBatchSize int
+	// items accepted by a batch create, update or delete of assets
+	AssetBatchMaxItems int
+	Auth               auth.Config
+	// subjects that always have the admin role
+	RbacAdminSubjects []string
+	// role of subjects without an assignment, none when empty
+	RbacDefaultRole string
+	// requests name their tenant in the token or TenantHeader, when disabled every row
+	// belongs to common.DefaultTenant
+	TenancyEnabled bool
+	TenantHeader   string
+	// attempts before a webhook delivery is given up
+	WebhookMaxAttempts int
+	// delay after the first failed delivery attempt, doubled after each further one
+	WebhookRetryBase time.Duration
+	// how often committed events are dispatched to the webhooks, 0 disables the dispatcher
+	WebhookDispatchInterval time.Duration
+	// how long an Idempotency-Key and its response are kept
+	IdempotencyKeyTtl time.Duration
 }
 
 func GetEnv(key, defaultValue string) string {
@@ -27,6 +65,19 @@ func GetEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func GetEnvInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue, fmt.Errorf("%s must be a number", key)
+	}
+	return parsed, nil
+}
+
 func InitAndCheckEnv() (*EnviConfig, []error) {
 	var errs []error
 	if !strings.EqualFold(strings.ToLower(os.Getenv("APP_ENV")), "local") {
@@ -37,10 +88,226 @@ func InitAndCheckEnv() (*EnviConfig, []error) {
 		}
 	}
 
+	trashRetentionDays, err := GetEnvInt("TRASH_RETENTION_DAYS", 30)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	assetTransitions, err := ParseAssetTransitions(GetEnv("ASSET_TRANSITIONS", ""))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	warrantyNoticeDays, err := GetEnvInt("WARRANTY_NOTICE_DAYS", 30)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	eventSink := strings.ToLower(GetEnv("EVENT_SINK", events.SinkLog))
+	eventWebhookUrl := GetEnv("EVENT_WEBHOOK_URL", "")
+	if eventSink != events.SinkLog && eventSink != events.SinkWebhook {
+		errs = append(errs, fmt.Errorf("EVENT_SINK must be %s or %s", events.SinkLog, events.SinkWebhook))
+	} else if eventSink == events.SinkWebhook && eventWebhookUrl == "" {
+		errs = append(errs, fmt.Errorf("EVENT_WEBHOOK_URL is required when EVENT_SINK is %s", events.SinkWebhook))
+	}
+
+	storageConfig := storage.Config{
+		Backend:           strings.ToLower(GetEnv("STORAGE_BACKEND", storage.BackendLocal)),
+		LocalPath:         GetEnv("STORAGE_LOCAL_PATH", "./storage"),
+		S3Endpoint:        GetEnv("S3_ENDPOINT", ""),
+		S3Bucket:          GetEnv("S3_BUCKET", ""),
+		S3Region:          GetEnv("S3_REGION", "us-east-1"),
+		S3AccessKeyId:     GetEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: GetEnv("S3_SECRET_ACCESS_KEY", ""),
+	}
+	if storageConfig.Backend != storage.BackendLocal && storageConfig.Backend != storage.BackendS3 {
+		errs = append(errs, fmt.Errorf("STORAGE_BACKEND must be %s or %s", storage.BackendLocal, storage.BackendS3))
+	} else if storageConfig.Backend == storage.BackendS3 {
+		for _, v := range []string{"S3_ENDPOINT", "S3_BUCKET", "S3_ACCESS_KEY_ID", "S3_SECRET_ACCESS_KEY"} {
+			if GetEnv(v, "") == "" {
+				errs = append(errs, fmt.Errorf("%s is required when STORAGE_BACKEND is %s", v, storage.BackendS3))
+			}
+		}
+	}
+
+	attachmentMaxSizeMb, err := GetEnvInt("ATTACHMENT_MAX_SIZE_MB", 10)
+	if err != nil {
+		errs = append(errs, err)
+	} else if attachmentMaxSizeMb <= 0 {
+		errs = append(errs, fmt.Errorf("ATTACHMENT_MAX_SIZE_MB must be greater than 0"))
+	}
+
+	attachmentAllowedTypes := []string{}
+	for _, v := range strings.Split(GetEnv("ATTACHMENT_ALLOWED_TYPES", strings.Join(common.DefaultAttachmentTypes, ",")), ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			attachmentAllowedTypes = append(attachmentAllowedTypes, v)
+		}
+	}
+
+	assetImportBatchSize, err := GetEnvInt("ASSET_IMPORT_BATCH_SIZE", 0)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	assetBatchMaxItems, err := GetEnvInt("ASSET_BATCH_MAX_ITEMS", 500)
+	if err != nil {
+		errs = append(errs, err)
+	} else if assetBatchMaxItems <= 0 {
+		errs = append(errs, fmt.Errorf("ASSET_BATCH_MAX_ITEMS must be greater than 0"))
+	}
+
+	authConfig, authErrs := initAuth()
+	errs = append(errs, authErrs...)
+
+	rbacAdminSubjects := []string{}
+	for _, v := range strings.Split(GetEnv("RBAC_ADMIN_SUBJECTS", ""), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			rbacAdminSubjects = append(rbacAdminSubjects, v)
+		}
+	}
+	rbacDefaultRole := strings.ToLower(GetEnv("RBAC_DEFAULT_ROLE", ""))
+	if _, ok := common.RolePermissions[rbacDefaultRole]; rbacDefaultRole != "" && !ok {
+		errs = append(errs, fmt.Errorf("RBAC_DEFAULT_ROLE must be one of %s", strings.Join(common.Roles, ", ")))
+	}
+
+	webhookMaxAttempts, err := GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8)
+	if err != nil {
+		errs = append(errs, err)
+	} else if webhookMaxAttempts <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be greater than 0"))
+	}
+
+	webhookRetryBaseSeconds, err := GetEnvInt("WEBHOOK_RETRY_BASE_SECONDS", 30)
+	if err != nil {
+		errs = append(errs, err)
+	} else if webhookRetryBaseSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_RETRY_BASE_SECONDS must be greater than 0"))
+	}
+
+	webhookDispatchIntervalSeconds, err := GetEnvInt("WEBHOOK_DISPATCH_INTERVAL_SECONDS", 5)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	idempotencyKeyTtlHours, err := GetEnvInt("IDEMPOTENCY_KEY_TTL_HOURS", 24)
+	if err != nil {
+		errs = append(errs, err)
+	} else if idempotencyKeyTtlHours <= 0 {
+		errs = append(errs, fmt.Errorf("IDEMPOTENCY_KEY_TTL_HOURS must be greater than 0"))
+	}
+
+	// without authentication the tenant would come from a header any caller can set
+	tenancyEnabled := strings.EqualFold(GetEnv("TENANCY_ENABLED", "false"), "true")
+	if tenancyEnabled && !authConfig.Enabled {
+		errs = append(errs, fmt.Errorf("AUTH_ENABLED must be true when TENANCY_ENABLED is true"))
+	}
+
 	return &EnviConfig{
-		AppEnv:       GetEnv("APP_ENV", "local"),
-		AppPort:      GetEnv("APP_PORT", "8010"),
-		DbConnection: GetEnv("DB_CONNECTION", ""),
-		DbDriver:     GetEnv("DB_DRIVER", "sqlite"),
-	}, nil
+		AppEnv:                  GetEnv("APP_ENV", "local"),
+		AppPort:                 GetEnv("APP_PORT", "8010"),
+		DbConnection:            GetEnv("DB_CONNECTION", ""),
+		DbDriver:                GetEnv("DB_DRIVER", "sqlite"),
+		TrashRetentionDays:      trashRetentionDays,
+		AssetTransitions:        assetTransitions,
+		EventSink:               eventSink,
+		EventWebhookUrl:         eventWebhookUrl,
+		WarrantyNoticeDays:      warrantyNoticeDays,
+		Storage:                 storageConfig,
+		AttachmentMaxSize:       int64(attachmentMaxSizeMb) << 20,
+		AttachmentAllowedTypes:  attachmentAllowedTypes,
+		AssetImportBatchSize:    assetImportBatchSize,
+		AssetBatchMaxItems:      assetBatchMaxItems,
+		Auth:                    authConfig,
+		RbacAdminSubjects:       rbacAdminSubjects,
+		RbacDefaultRole:         rbacDefaultRole,
+		TenancyEnabled:          tenancyEnabled,
+		TenantHeader:            GetEnv("TENANT_HEADER", "X-Tenant-Id"),
+		WebhookMaxAttempts:      webhookMaxAttempts,
+		WebhookRetryBase:        time.Duration(webhookRetryBaseSeconds) * time.Second,
+		WebhookDispatchInterval: time.Duration(webhookDispatchIntervalSeconds) * time.Second,
+		IdempotencyKeyTtl:       time.Duration(idempotencyKeyTtlHours) * time.Hour,
+	}, errs
+}
+
+// initAuth reads the bearer token settings. Keys come from AUTH_JWKS_URL, or from
+// AUTH_STATIC_KEYS_FILE holding a JWKS document or PEM public keys.
+func initAuth() (auth.Config, []error) {
+	var errs []error
+	cfg := auth.Config{
+		Enabled:     strings.EqualFold(GetEnv("AUTH_ENABLED", "false"), "true"),
+		JwksUrl:     GetEnv("AUTH_JWKS_URL", ""),
+		Issuer:      GetEnv("AUTH_ISSUER", ""),
+		TenantClaim: GetEnv("AUTH_TENANT_CLAIM", "tenant_id"),
+	}
+	for _, v := range strings.Split(GetEnv("AUTH_AUDIENCE", ""), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			cfg.Audience = append(cfg.Audience, v)
+		}
+	}
+	for _, v := range strings.Split(GetEnv("AUTH_PUBLIC_PATHS", "/,/swagger/*"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			cfg.PublicPaths = append(cfg.PublicPaths, v)
+		}
+	}
+
+	clockSkewSeconds, err := GetEnvInt("AUTH_CLOCK_SKEW_SECONDS", 60)
+	if err != nil {
+		errs = append(errs, err)
+	} else if clockSkewSeconds < 0 {
+		errs = append(errs, fmt.Errorf("AUTH_CLOCK_SKEW_SECONDS must not be negative"))
+	}
+	cfg.ClockSkew = time.Duration(clockSkewSeconds) * time.Second
+
+	if !cfg.Enabled {
+		return cfg, errs
+	}
+
+	staticKeysFile := GetEnv("AUTH_STATIC_KEYS_FILE", "")
+	if cfg.JwksUrl == "" && staticKeysFile == "" {
+		errs = append(errs, fmt.Errorf("AUTH_JWKS_URL or AUTH_STATIC_KEYS_FILE is required when AUTH_ENABLED is true"))
+	} else if cfg.JwksUrl == "" {
+		data, err := os.ReadFile(staticKeysFile)
+		if err == nil {
+			cfg.StaticKeys, err = auth.ParseKeys(data)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("AUTH_STATIC_KEYS_FILE is invalid: %w", err))
+		}
+	}
+	for _, v := range []string{"AUTH_ISSUER", "AUTH_AUDIENCE"} {
+		if GetEnv(v, "") == "" {
+			errs = append(errs, fmt.Errorf("%s is required when AUTH_ENABLED is true", v))
+		}
+	}
+	return cfg, errs
+}
+
+// ParseAssetTransitions reads the asset lifecycle graph from a JSON object mapping a status
+// to the statuses it can move to, e.g. {"in_stock":["deployed"],"deployed":["in_stock"]}.
+// An empty value gives the default graph.
+func ParseAssetTransitions(value string) (map[string][]string, error) {
+	if value == "" {
+		return common.DefaultAssetTransitions, nil
+	}
+
+	var transitions map[string][]string
+	if err := json.Unmarshal([]byte(value), &transitions); err != nil {
+		return nil, fmt.Errorf("ASSET_TRANSITIONS must be a JSON object of status lists")
+	}
+
+	known := map[string]bool{}
+	for _, v := range common.AssetStatuses {
+		known[v] = true
+	}
+	for from, targets := range transitions {
+		if !known[from] {
+			return nil, fmt.Errorf("ASSET_TRANSITIONS has unknown status %s", from)
+		}
+		for _, to := range targets {
+			if !known[to] {
+				return nil, fmt.Errorf("ASSET_TRANSITIONS has unknown status %s", to)
+			}
+		}
+	}
+	return transitions, nil
 }
@@ -0,0 +1,244 @@
+package depreciation
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Supported depreciation methods
+const (
+	StraightLine      = "straight_line"
+	DecliningBalance  = "declining_balance"
+	DoubleDeclining   = "double_declining"
+	SumOfYearsDigits  = "sum_of_years_digits"
+	UnitsOfProduction = "units_of_production"
+)
+
+// Supported depreciation start conventions
+const (
+	// FullMonth starts depreciating on the first day of the acquisition month
+	FullMonth = "full_month"
+	// NextMonth starts depreciating on the first day of the month after acquisition
+	NextMonth = "next_month"
+	// HalfYear books half a year of depreciation in the first and last period
+	HalfYear = "half_year"
+)
+
+const DefaultDecliningFactor = 1.5
+
+// MaxUsefulLifeYears bounds the useful life, schedules hold one period per year
+const MaxUsefulLifeYears = 100
+
+var (
+	ErrUnknownMethod     = errors.New("unknown depreciation method")
+	ErrUnknownConvention = errors.New("unknown depreciation convention")
+	ErrInvalidUsefulLife = errors.New("useful life must be greater than zero")
+	ErrUsefulLifeTooLong = errors.New("useful life must be at most 100 years")
+	ErrInvalidSalvage    = errors.New("salvage value must be between zero and the asset value")
+	ErrInvalidFactor     = errors.New("declining factor must be greater than zero")
+	ErrInvalidUnits      = errors.New("total units must be greater than zero and units used must not be negative")
+)
+
+type Params struct {
+	Cost            float64
+	Salvage         float64
+	UsefulLifeYears int
+	Method          string
+	Convention      string
+	DecliningFactor float64
+	TotalUnits      float64
+	UnitsUsed       float64
+	AcquisitionDate time.Time
+}
+
+type Period struct {
+	Period      int
+	StartDate   time.Time
+	EndDate     time.Time
+	Amount      float64
+	Accumulated float64
+	BookValue   float64
+}
+
+func Validate(p Params) error {
+	switch p.Method {
+	case StraightLine, DoubleDeclining, SumOfYearsDigits:
+	case DecliningBalance:
+		if p.DecliningFactor < 0 {
+			return ErrInvalidFactor
+		}
+	case UnitsOfProduction:
+		if p.TotalUnits <= 0 || p.UnitsUsed < 0 {
+			return ErrInvalidUnits
+		}
+	default:
+		return ErrUnknownMethod
+	}
+
+	switch p.Convention {
+	case "", FullMonth, NextMonth, HalfYear:
+	default:
+		return ErrUnknownConvention
+	}
+
+	if p.UsefulLifeYears <= 0 {
+		return ErrInvalidUsefulLife
+	}
+	if p.UsefulLifeYears > MaxUsefulLifeYears {
+		return ErrUsefulLifeTooLong
+	}
+
+	if p.Salvage < 0 || p.Salvage > p.Cost {
+		return ErrInvalidSalvage
+	}
+
+	return nil
+}
+
+// StartDate returns the date depreciation begins for the given convention
+func StartDate(p Params) time.Time {
+	acq := p.AcquisitionDate
+	start := time.Date(acq.Year(), acq.Month(), 1, 0, 0, 0, 0, acq.Location())
+	if p.Convention == NextMonth {
+		start = start.AddDate(0, 1, 0)
+	}
+	return start
+}
+
+// Schedule returns the full period-by-period depreciation schedule. Periods are
+// twelve months long and start from StartDate. Units of production schedules
+// assume the remaining units are consumed evenly over the useful life.
+func Schedule(p Params) ([]Period, error) {
+	if err := Validate(p); err != nil {
+		return nil, err
+	}
+
+	annual := annualAmounts(p)
+	start := StartDate(p)
+
+	type span struct {
+		start, end time.Time
+		amount     float64
+	}
+	var spans []span
+
+	if p.Convention == HalfYear {
+		life := len(annual)
+		spans = append(spans, span{start, start.AddDate(0, 6, 0), annual[0] / 2})
+		for i := 1; i < life; i++ {
+			s := start.AddDate(0, 6+12*(i-1), 0)
+			spans = append(spans, span{s, s.AddDate(1, 0, 0), annual[i-1]/2 + annual[i]/2})
+		}
+		s := start.AddDate(0, 6+12*(life-1), 0)
+		spans = append(spans, span{s, s.AddDate(0, 6, 0), annual[life-1] / 2})
+	} else {
+		for i, amount := range annual {
+			s := start.AddDate(i, 0, 0)
+			spans = append(spans, span{s, s.AddDate(1, 0, 0), amount})
+		}
+	}
+
+	base := round(p.Cost - p.Salvage)
+	periods := make([]Period, 0, len(spans))
+	accumulated := 0.0
+	for i, s := range spans {
+		amount := round(s.amount)
+		// absorb rounding differences in the final period
+		if i == len(spans)-1 {
+			amount = round(base - accumulated)
+		}
+		accumulated = round(accumulated + amount)
+		periods = append(periods, Period{
+			Period:      i + 1,
+			StartDate:   s.start,
+			EndDate:     s.end.AddDate(0, 0, -1),
+			Amount:      amount,
+			Accumulated: accumulated,
+			BookValue:   round(p.Cost - accumulated),
+		})
+	}
+
+	return periods, nil
+}
+
+// ValueAt returns the accumulated depreciation and book value at the given time.
+// Time based methods prorate the running period by elapsed days, units of
+// production uses the recorded units used.
+func ValueAt(p Params, at time.Time) (accumulated float64, bookValue float64, err error) {
+	if err = Validate(p); err != nil {
+		return 0, 0, err
+	}
+
+	if p.Method == UnitsOfProduction {
+		ratio := math.Min(p.UnitsUsed/p.TotalUnits, 1)
+		accumulated = round((p.Cost - p.Salvage) * ratio)
+		return accumulated, round(p.Cost - accumulated), nil
+	}
+
+	periods, err := Schedule(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, period := range periods {
+		end := period.EndDate.AddDate(0, 0, 1)
+		if !at.Before(end) {
+			accumulated = period.Accumulated
+			continue
+		}
+		if at.After(period.StartDate) {
+			elapsed := at.Sub(period.StartDate).Hours()
+			total := end.Sub(period.StartDate).Hours()
+			accumulated = round(accumulated + period.Amount*elapsed/total)
+		}
+		break
+	}
+
+	return accumulated, round(p.Cost - accumulated), nil
+}
+
+// annualAmounts returns the depreciation for each full year of useful life
+func annualAmounts(p Params) []float64 {
+	life := p.UsefulLifeYears
+	base := p.Cost - p.Salvage
+	amounts := make([]float64, life)
+
+	switch p.Method {
+	case DecliningBalance, DoubleDeclining:
+		factor := 2.0
+		if p.Method == DecliningBalance {
+			factor = p.DecliningFactor
+			if factor == 0 {
+				factor = DefaultDecliningFactor
+			}
+		}
+		rate := factor / float64(life)
+		bookValue := p.Cost
+		for i := 0; i < life; i++ {
+			amount := bookValue * rate
+			// never go below salvage and fully depreciate in the last year
+			if amount > bookValue-p.Salvage || i == life-1 {
+				amount = bookValue - p.Salvage
+			}
+			amounts[i] = amount
+			bookValue -= amount
+		}
+	case SumOfYearsDigits:
+		digits := float64(life*(life+1)) / 2
+		for i := 0; i < life; i++ {
+			amounts[i] = base * float64(life-i) / digits
+		}
+	default:
+		// straight line, and the even projection used for units of production
+		for i := 0; i < life; i++ {
+			amounts[i] = base / float64(life)
+		}
+	}
+
+	return amounts
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
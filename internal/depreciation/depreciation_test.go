@@ -0,0 +1,224 @@
+package depreciation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	base := Params{
+		Cost:            1000,
+		Salvage:         100,
+		UsefulLifeYears: 5,
+		Method:          StraightLine,
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(p *Params)
+		expectedErr error
+	}{
+		{
+			name:   "Success - Valid straight line",
+			modify: func(p *Params) {},
+		},
+		{
+			name:        "Error - Unknown method",
+			modify:      func(p *Params) { p.Method = "magic" },
+			expectedErr: ErrUnknownMethod,
+		},
+		{
+			name:        "Error - Unknown convention",
+			modify:      func(p *Params) { p.Convention = "mid_quarter" },
+			expectedErr: ErrUnknownConvention,
+		},
+		{
+			name:        "Error - Zero useful life",
+			modify:      func(p *Params) { p.UsefulLifeYears = 0 },
+			expectedErr: ErrInvalidUsefulLife,
+		},
+		{
+			name:   "Success - Maximum useful life",
+			modify: func(p *Params) { p.UsefulLifeYears = MaxUsefulLifeYears },
+		},
+		{
+			name:        "Error - Useful life above maximum",
+			modify:      func(p *Params) { p.UsefulLifeYears = MaxUsefulLifeYears + 1 },
+			expectedErr: ErrUsefulLifeTooLong,
+		},
+		{
+			name:        "Error - Huge useful life",
+			modify:      func(p *Params) { p.UsefulLifeYears = 2000000000 },
+			expectedErr: ErrUsefulLifeTooLong,
+		},
+		{
+			name:        "Error - Salvage above cost",
+			modify:      func(p *Params) { p.Salvage = 2000 },
+			expectedErr: ErrInvalidSalvage,
+		},
+		{
+			name:        "Error - Negative declining factor",
+			modify:      func(p *Params) { p.Method = DecliningBalance; p.DecliningFactor = -1 },
+			expectedErr: ErrInvalidFactor,
+		},
+		{
+			name:        "Error - Units of production without total units",
+			modify:      func(p *Params) { p.Method = UnitsOfProduction },
+			expectedErr: ErrInvalidUnits,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := base
+			tt.modify(&p)
+			assert.Equal(t, tt.expectedErr, Validate(p))
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	acquired := time.Date(2020, 3, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		params          Params
+		expectedAmounts []float64
+		expectedStart   time.Time
+	}{
+		{
+			name: "Straight line",
+			params: Params{
+				Cost: 1000, Salvage: 100, UsefulLifeYears: 3,
+				Method: StraightLine, AcquisitionDate: acquired,
+			},
+			expectedAmounts: []float64{300, 300, 300},
+			expectedStart:   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Double declining stops at salvage",
+			params: Params{
+				Cost: 1000, Salvage: 100, UsefulLifeYears: 4,
+				Method: DoubleDeclining, AcquisitionDate: acquired,
+			},
+			expectedAmounts: []float64{500, 250, 125, 25},
+			expectedStart:   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Declining balance with default factor",
+			params: Params{
+				Cost: 1000, Salvage: 0, UsefulLifeYears: 3,
+				Method: DecliningBalance, AcquisitionDate: acquired,
+			},
+			expectedAmounts: []float64{500, 250, 250},
+			expectedStart:   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Sum of years digits",
+			params: Params{
+				Cost: 700, Salvage: 100, UsefulLifeYears: 3,
+				Method: SumOfYearsDigits, Convention: NextMonth, AcquisitionDate: acquired,
+			},
+			expectedAmounts: []float64{300, 200, 100},
+			expectedStart:   time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Units of production projected evenly",
+			params: Params{
+				Cost: 1000, Salvage: 0, UsefulLifeYears: 4, TotalUnits: 100,
+				Method: UnitsOfProduction, AcquisitionDate: acquired,
+			},
+			expectedAmounts: []float64{250, 250, 250, 250},
+			expectedStart:   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Straight line with half year convention",
+			params: Params{
+				Cost: 1000, Salvage: 0, UsefulLifeYears: 2,
+				Method: StraightLine, Convention: HalfYear, AcquisitionDate: acquired,
+			},
+			expectedAmounts: []float64{250, 500, 250},
+			expectedStart:   time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := Schedule(tt.params)
+			assert.NoError(t, err)
+			assert.Len(t, periods, len(tt.expectedAmounts))
+
+			amounts := []float64{}
+			for _, p := range periods {
+				amounts = append(amounts, p.Amount)
+			}
+			assert.Equal(t, tt.expectedAmounts, amounts)
+			assert.Equal(t, tt.expectedStart, periods[0].StartDate)
+
+			last := periods[len(periods)-1]
+			assert.Equal(t, tt.params.Cost-tt.params.Salvage, last.Accumulated)
+			assert.Equal(t, tt.params.Salvage, last.BookValue)
+		})
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	params := Params{
+		Cost:            1200,
+		Salvage:         0,
+		UsefulLifeYears: 2,
+		Method:          StraightLine,
+		AcquisitionDate: time.Date(2020, 1, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name                string
+		params              Params
+		at                  time.Time
+		expectedAccumulated float64
+		expectedBookValue   float64
+	}{
+		{
+			name:                "Before start date",
+			params:              params,
+			at:                  time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC),
+			expectedAccumulated: 0,
+			expectedBookValue:   1200,
+		},
+		{
+			name:                "End of first period",
+			params:              params,
+			at:                  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedAccumulated: 600,
+			expectedBookValue:   600,
+		},
+		{
+			name:                "Fully depreciated",
+			params:              params,
+			at:                  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedAccumulated: 1200,
+			expectedBookValue:   0,
+		},
+		{
+			name: "Units of production uses units used",
+			params: Params{
+				Cost: 1000, Salvage: 200, UsefulLifeYears: 5, TotalUnits: 400, UnitsUsed: 100,
+				Method: UnitsOfProduction, AcquisitionDate: params.AcquisitionDate,
+			},
+			at:                  time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedAccumulated: 200,
+			expectedBookValue:   800,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accumulated, bookValue, err := ValueAt(tt.params, tt.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAccumulated, accumulated)
+			assert.Equal(t, tt.expectedBookValue, bookValue)
+		})
+	}
+}
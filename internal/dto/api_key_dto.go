@@ -0,0 +1,27 @@
+package dto
+
+type ApiKeyInputDto struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required"`
+	// ExpiresAt as YYYY-MM-DD or RFC3339, the key does not expire when empty
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+type ApiKeyOutputDto struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at"`
+	LastUsedAt string   `json:"last_used_at"`
+	RevokedAt  string   `json:"revoked_at"`
+	CreatedBy  string   `json:"created_by"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// ApiKeySecretOutputDto is returned when a key is created or rotated, the only time the key
+// itself can be read
+type ApiKeySecretOutputDto struct {
+	ApiKeyOutputDto
+	Key string `json:"key"`
+}
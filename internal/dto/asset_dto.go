@@ -1,18 +1,185 @@
 package dto
 
+import "time"
+
 type AssetInputDto struct {
-	Name            string  `json:"name" validate:"required"`
-	Type            string  `json:"type" validate:"required"`
-	Value           float64 `json:"value" validate:"required"`
-	AcquisitionDate string  `json:"acquisition_date" validate:"required"`
+	Name                   string  `json:"name" validate:"required,max=255"`
+	Type                   string  `json:"type,omitempty" validate:"max=255"`
+	CategoryId             string  `json:"category_id,omitempty"`
+	LocationId             string  `json:"location_id,omitempty"`
+	Status                 string  `json:"status,omitempty"`
+	Value                  float64 `json:"value" validate:"gte=0"`
+	AcquisitionDate        string  `json:"acquisition_date" validate:"required,datetime=2006-01-02,notfuture"`
+	DepreciationMethod     string  `json:"depreciation_method,omitempty"`
+	DepreciationConvention string  `json:"depreciation_convention,omitempty"`
+	UsefulLifeYears        int     `json:"useful_life_years,omitempty" validate:"gte=0,lte=100"`
+	SalvageValue           float64 `json:"salvage_value,omitempty"`
+	DecliningFactor        float64 `json:"declining_factor,omitempty"`
+	TotalUnits             float64 `json:"total_units,omitempty"`
+	UnitsUsed              float64 `json:"units_used,omitempty"`
 }
 
 type AssetOutputDto struct {
-	Id              string  `json:"id"`
-	Name            string  `json:"name"`
-	Type            string  `json:"type"`
-	Value           float64 `json:"value"`
-	AcquisitionDate string  `json:"acquisition_date"`
-	CreatedAt       string  `json:"created_at"`
-	UpdatedAt       string  `json:"updated_at"`
+	Id              string                `json:"id"`
+	Name            string                `json:"name"`
+	Type            string                `json:"type"`
+	CategoryId      *string               `json:"category_id"`
+	LocationId      *string               `json:"location_id"`
+	Status          string                `json:"status"`
+	Value           float64               `json:"value"`
+	AcquisitionDate string                `json:"acquisition_date"`
+	Depreciation    *AssetDepreciationDto `json:"depreciation,omitempty"`
+	Version         int64                 `json:"version"`
+	CreatedAt       string                `json:"created_at"`
+	UpdatedAt       string                `json:"updated_at"`
+	DeletedAt       string                `json:"deleted_at,omitempty"`
+}
+
+type AssetTransitionInputDto struct {
+	Status string `json:"status" validate:"required"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+type AssetTransitionOutputDto struct {
+	Id             string `json:"id"`
+	AssetId        string `json:"asset_id"`
+	FromStatus     string `json:"from_status"`
+	ToStatus       string `json:"to_status"`
+	Reason         string `json:"reason"`
+	TransitionedAt string `json:"transitioned_at"`
+	TransitionedBy string `json:"transitioned_by"`
+}
+
+// InvalidTransitionDto is the data of the 409 returned for a move the lifecycle graph does not allow
+type InvalidTransitionDto struct {
+	CurrentStatus   string   `json:"current_status"`
+	RequestedStatus string   `json:"requested_status"`
+	AllowedStatuses []string `json:"allowed_statuses"`
+}
+
+type AssetDepreciationDto struct {
+	Method                  string  `json:"method"`
+	Convention              string  `json:"convention"`
+	UsefulLifeYears         int     `json:"useful_life_years"`
+	SalvageValue            float64 `json:"salvage_value"`
+	DecliningFactor         float64 `json:"declining_factor,omitempty"`
+	TotalUnits              float64 `json:"total_units,omitempty"`
+	UnitsUsed               float64 `json:"units_used,omitempty"`
+	StartDate               string  `json:"start_date"`
+	AccumulatedDepreciation float64 `json:"accumulated_depreciation"`
+	BookValue               float64 `json:"book_value"`
+	AsOf                    string  `json:"as_of"`
+}
+
+type DepreciationScheduleDto struct {
+	AssetId      string                   `json:"asset_id"`
+	Cost         float64                  `json:"cost"`
+	SalvageValue float64                  `json:"salvage_value"`
+	Method       string                   `json:"method"`
+	Convention   string                   `json:"convention"`
+	Periods      []*DepreciationPeriodDto `json:"periods"`
+}
+
+type DepreciationPeriodDto struct {
+	Period                  int     `json:"period"`
+	StartDate               string  `json:"start_date"`
+	EndDate                 string  `json:"end_date"`
+	Depreciation            float64 `json:"depreciation"`
+	AccumulatedDepreciation float64 `json:"accumulated_depreciation"`
+	BookValue               float64 `json:"book_value"`
+}
+
+type AssetFilterDto struct {
+	Q                   string   `json:"q,omitempty" query:"q"`
+	Name                string   `json:"name,omitempty" query:"name"`
+	Type                string   `json:"type,omitempty" query:"type"`
+	CategoryId          string   `json:"category_id,omitempty" query:"category_id"`
+	LocationId          string   `json:"location_id,omitempty" query:"location_id"`
+	Status              string   `json:"status,omitempty" query:"status"`
+	ValueMin            *float64 `json:"value_min,omitempty" query:"value_min"`
+	ValueMax            *float64 `json:"value_max,omitempty" query:"value_max"`
+	AcquisitionDateFrom string   `json:"acquisition_date_from,omitempty" query:"acquisition_date_from"`
+	AcquisitionDateTo   string   `json:"acquisition_date_to,omitempty" query:"acquisition_date_to"`
+	CreatedFrom         string   `json:"created_from,omitempty" query:"created_from"`
+	CreatedTo           string   `json:"created_to,omitempty" query:"created_to"`
+	UpdatedFrom         string   `json:"updated_from,omitempty" query:"updated_from"`
+	UpdatedTo           string   `json:"updated_to,omitempty" query:"updated_to"`
+
+	// parsed from the date filters by the service
+	AcquisitionDateFromTime time.Time `json:"-"`
+	AcquisitionDateToTime   time.Time `json:"-"`
+	CreatedFromTime         time.Time `json:"-"`
+	CreatedToTime           time.Time `json:"-"`
+	UpdatedFromTime         time.Time `json:"-"`
+	UpdatedToTime           time.Time `json:"-"`
+
+	// CategoryId and LocationId with their descendants, resolved by the service
+	CategoryIds []string `json:"-"`
+	LocationIds []string `json:"-"`
+
+	// assets checked out with a due date before DueBefore are overdue, set by the service
+	DueBefore time.Time `json:"-"`
+}
+
+// AssetEventDto is the data of the asset events. Asset holds the fields of the asset after the
+// change, or before it for a purge, Changes the fields the change touched.
+type AssetEventDto struct {
+	TenantId  string                 `json:"tenant_id"`
+	AssetId   string                 `json:"asset_id"`
+	Actor     string                 `json:"actor"`
+	RequestId string                 `json:"request_id"`
+	Asset     map[string]interface{} `json:"asset"`
+	Changes   []*AuditChangeDto      `json:"changes"`
+}
+
+// AssetBatchCreateInputDto creates several assets, Mode is atomic (default) or best_effort
+type AssetBatchCreateInputDto struct {
+	Mode  string           `json:"mode,omitempty"`
+	Items []*AssetInputDto `json:"items"`
+}
+
+// AssetBatchUpdateInputDto updates several assets, Mode is atomic (default) or best_effort
+type AssetBatchUpdateInputDto struct {
+	Mode  string                     `json:"mode,omitempty"`
+	Items []*AssetBatchUpdateItemDto `json:"items"`
+}
+
+// AssetBatchUpdateItemDto is an asset update of a batch. A Version only updates that version
+// of the asset, like an If-Match header.
+type AssetBatchUpdateItemDto struct {
+	Id      string `json:"id"`
+	Version int64  `json:"version,omitempty"`
+	AssetInputDto
+}
+
+// AssetBatchDeleteInputDto deletes several assets, Mode is atomic (default) or best_effort
+type AssetBatchDeleteInputDto struct {
+	Mode  string                     `json:"mode,omitempty"`
+	Items []*AssetBatchDeleteItemDto `json:"items"`
+}
+
+// AssetBatchDeleteItemDto is an asset delete of a batch. A Version only deletes that version
+// of the asset, like an If-Match header.
+type AssetBatchDeleteItemDto struct {
+	Id      string `json:"id"`
+	Version int64  `json:"version,omitempty"`
+}
+
+type AssetBatchResultDto struct {
+	Mode      string                     `json:"mode"`
+	Total     int                        `json:"total"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+	Items     []*AssetBatchItemResultDto `json:"items"`
+}
+
+// AssetBatchItemResultDto is the outcome of an item of a batch, Index is its position in the
+// request and Status the HTTP status it would have had as a single request
+type AssetBatchItemResultDto struct {
+	Index  int             `json:"index"`
+	Id     string          `json:"id,omitempty"`
+	Status int             `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	Errors []FieldErrorDto `json:"errors,omitempty"`
+	Data   *AssetOutputDto `json:"data,omitempty"`
 }
@@ -0,0 +1,10 @@
+package dto
+
+import "io"
+
+// AssetExportDto is an export ready to be streamed, Write sends the file content to w
+type AssetExportDto struct {
+	FileName    string
+	ContentType string
+	Write       func(w io.Writer) error
+}
@@ -0,0 +1,28 @@
+package dto
+
+// AssetImportInputDto is an uploaded CSV or XLSX file of assets. Mapping maps an asset input
+// field, such as acquisition_date, to the header of the column holding it. Fields without a
+// mapping are read from the column named like the field.
+type AssetImportInputDto struct {
+	FileName string
+	Content  []byte
+	Mapping  map[string]string
+	DryRun   bool
+}
+
+type AssetImportResultDto struct {
+	DryRun   bool                 `json:"dry_run"`
+	Total    int                  `json:"total"`
+	Valid    int                  `json:"valid"`
+	Invalid  int                  `json:"invalid"`
+	Imported int                  `json:"imported"`
+	Rows     []*AssetImportRowDto `json:"rows"`
+}
+
+type AssetImportRowDto struct {
+	Row     int    `json:"row"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	AssetId string `json:"asset_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+type CheckoutInputDto struct {
+	Assignee string `json:"assignee" validate:"required"`
+	DueDate  string `json:"due_date" validate:"required"`
+	Note     string `json:"note,omitempty"`
+}
+
+type CheckinInputDto struct {
+	Condition string `json:"condition" validate:"required"`
+	Note      string `json:"note,omitempty"`
+}
+
+type AssignmentOutputDto struct {
+	Id              string `json:"id"`
+	AssetId         string `json:"asset_id"`
+	Assignee        string `json:"assignee"`
+	DueDate         string `json:"due_date"`
+	Note            string `json:"note,omitempty"`
+	CheckedOutAt    string `json:"checked_out_at"`
+	CheckedOutBy    string `json:"checked_out_by"`
+	CheckedInAt     string `json:"checked_in_at,omitempty"`
+	CheckedInBy     string `json:"checked_in_by,omitempty"`
+	ReturnCondition string `json:"return_condition,omitempty"`
+	ReturnNote      string `json:"return_note,omitempty"`
+	Overdue         bool   `json:"overdue"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "io"
+
+type AttachmentOutputDto struct {
+	Id           string `json:"id"`
+	AssetId      string `json:"asset_id"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Checksum     string `json:"checksum"`
+	HasThumbnail bool   `json:"has_thumbnail"`
+	UploadedBy   string `json:"uploaded_by"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// AttachmentContentDto is a stored file ready to be streamed, the caller closes Content
+type AttachmentContentDto struct {
+	FileName    string
+	ContentType string
+	Checksum    string
+	Content     io.ReadCloser
+}
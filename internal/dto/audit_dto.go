@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type AuditChangeDto struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type AuditFilterDto struct {
+	EntityType string `json:"entity_type,omitempty" query:"entity_type"`
+	EntityId   string `json:"entity_id,omitempty" query:"entity_id"`
+	Action     string `json:"action,omitempty" query:"action"`
+	Actor      string `json:"actor,omitempty" query:"actor"`
+	RequestId  string `json:"request_id,omitempty" query:"request_id"`
+	From       string `json:"from,omitempty" query:"from"`
+	To         string `json:"to,omitempty" query:"to"`
+
+	// parsed from From and To by the service
+	FromTime time.Time `json:"-"`
+	ToTime   time.Time `json:"-"`
+}
+
+type AuditLogOutputDto struct {
+	Id         string            `json:"id"`
+	EntityType string            `json:"entity_type"`
+	EntityId   string            `json:"entity_id"`
+	Action     string            `json:"action"`
+	Actor      string            `json:"actor"`
+	RequestId  string            `json:"request_id,omitempty"`
+	Changes    []*AuditChangeDto `json:"changes"`
+	CreatedAt  string            `json:"created_at"`
+}
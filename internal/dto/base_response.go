@@ -1,8 +1,16 @@
 package dto
 
 type BaseResponse struct {
-	Data             interface{} `json:"data,omitempty"`
-	Message          string      `json:"message,omitempty"`
-	Error            string      `json:"error,omitempty"`
-	ErrorDescription string      `json:"error_description,omitempty"`
+	Data             interface{}     `json:"data,omitempty"`
+	Message          string          `json:"message,omitempty"`
+	Error            string          `json:"error,omitempty"`
+	ErrorDescription string          `json:"error_description,omitempty"`
+	Errors           []FieldErrorDto `json:"errors,omitempty"`
+}
+
+// FieldErrorDto is an invalid field of a request body, Field is its JSON name
+type FieldErrorDto struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
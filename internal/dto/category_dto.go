@@ -0,0 +1,32 @@
+package dto
+
+type CategoryInputDto struct {
+	Name                   string  `json:"name" validate:"required"`
+	ParentId               string  `json:"parent_id,omitempty"`
+	Code                   string  `json:"code,omitempty"`
+	Icon                   string  `json:"icon,omitempty"`
+	DepreciationMethod     string  `json:"depreciation_method,omitempty"`
+	DepreciationConvention string  `json:"depreciation_convention,omitempty"`
+	UsefulLifeYears        int     `json:"useful_life_years,omitempty" validate:"gte=0,lte=100"`
+	DecliningFactor        float64 `json:"declining_factor,omitempty"`
+}
+
+type CategoryOutputDto struct {
+	Id                     string               `json:"id"`
+	ParentId               *string              `json:"parent_id"`
+	Name                   string               `json:"name"`
+	Code                   string               `json:"code,omitempty"`
+	Icon                   string               `json:"icon,omitempty"`
+	DepreciationMethod     string               `json:"depreciation_method,omitempty"`
+	DepreciationConvention string               `json:"depreciation_convention,omitempty"`
+	UsefulLifeYears        int                  `json:"useful_life_years,omitempty"`
+	DecliningFactor        float64              `json:"declining_factor,omitempty"`
+	Children               []*CategoryOutputDto `json:"children,omitempty"`
+	CreatedAt              string               `json:"created_at"`
+	UpdatedAt              string               `json:"updated_at"`
+}
+
+type CategoryFilterDto struct {
+	Q        string `json:"q,omitempty" query:"q"`
+	ParentId string `json:"parent_id,omitempty" query:"parent_id"`
+}
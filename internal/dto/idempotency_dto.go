@@ -0,0 +1,10 @@
+package dto
+
+// IdempotentResponseDto is the response of a request with an Idempotency-Key, replayed to
+// the retries of the request
+type IdempotentResponseDto struct {
+	StatusCode int
+	// Header holds the response headers worth replaying, such as Content-Type and ETag
+	Header map[string]string
+	Body   []byte
+}
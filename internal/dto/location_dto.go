@@ -0,0 +1,36 @@
+package dto
+
+type LocationInputDto struct {
+	Name      string   `json:"name" validate:"required"`
+	Kind      string   `json:"kind" validate:"required"`
+	ParentId  string   `json:"parent_id,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type LocationOutputDto struct {
+	Id        string               `json:"id"`
+	ParentId  *string              `json:"parent_id"`
+	Name      string               `json:"name"`
+	Kind      string               `json:"kind"`
+	Latitude  *float64             `json:"latitude,omitempty"`
+	Longitude *float64             `json:"longitude,omitempty"`
+	Children  []*LocationOutputDto `json:"children,omitempty"`
+	CreatedAt string               `json:"created_at"`
+	UpdatedAt string               `json:"updated_at"`
+}
+
+type LocationFilterDto struct {
+	Q        string `json:"q,omitempty" query:"q"`
+	Kind     string `json:"kind,omitempty" query:"kind"`
+	ParentId string `json:"parent_id,omitempty" query:"parent_id"`
+}
+
+// LocationAssetsDto lists the assets of a location subtree. TotalValue and AssetCount
+// cover the whole subtree, not only the current page.
+type LocationAssetsDto struct {
+	Location   *LocationOutputDto `json:"location"`
+	AssetCount int64              `json:"asset_count"`
+	TotalValue float64            `json:"total_value"`
+	Assets     []*AssetOutputDto  `json:"assets"`
+}
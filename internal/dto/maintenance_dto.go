@@ -0,0 +1,65 @@
+package dto
+
+type MaintenanceRecordInputDto struct {
+	PerformedAt   string  `json:"performed_at" validate:"required"`
+	Vendor        string  `json:"vendor,omitempty"`
+	Cost          float64 `json:"cost"`
+	Description   string  `json:"description" validate:"required"`
+	DowntimeHours float64 `json:"downtime_hours"`
+	PlanId        string  `json:"plan_id,omitempty"`
+}
+
+type MaintenanceRecordOutputDto struct {
+	Id            string  `json:"id"`
+	AssetId       string  `json:"asset_id"`
+	PlanId        *string `json:"plan_id"`
+	PerformedAt   string  `json:"performed_at"`
+	Vendor        string  `json:"vendor"`
+	Cost          float64 `json:"cost"`
+	Description   string  `json:"description"`
+	DowntimeHours float64 `json:"downtime_hours"`
+	CreatedBy     string  `json:"created_by"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// MaintenancePlanInputDto describes a recurring maintenance. Calendar plans need interval_days
+// and are first due on next_due_date (today plus the interval by default), usage plans need
+// interval_units and are first due that many units after the asset current units used.
+type MaintenancePlanInputDto struct {
+	Name          string  `json:"name" validate:"required"`
+	Kind          string  `json:"kind" validate:"required"`
+	IntervalDays  int     `json:"interval_days,omitempty"`
+	IntervalUnits float64 `json:"interval_units,omitempty"`
+	NextDueDate   string  `json:"next_due_date,omitempty"`
+}
+
+type MaintenancePlanOutputDto struct {
+	Id                 string  `json:"id"`
+	AssetId            string  `json:"asset_id"`
+	Name               string  `json:"name"`
+	Kind               string  `json:"kind"`
+	IntervalDays       int     `json:"interval_days,omitempty"`
+	IntervalUnits      float64 `json:"interval_units,omitempty"`
+	NextDueDate        string  `json:"next_due_date,omitempty"`
+	NextDueUnits       float64 `json:"next_due_units,omitempty"`
+	LastPerformedAt    string  `json:"last_performed_at,omitempty"`
+	LastPerformedUnits float64 `json:"last_performed_units,omitempty"`
+	CreatedAt          string  `json:"created_at"`
+	UpdatedAt          string  `json:"updated_at"`
+}
+
+// MaintenanceDueDto is a plan that is due within the requested window or already overdue.
+// DaysUntilDue is negative for overdue calendar plans, RemainingUnits for usage plans.
+type MaintenanceDueDto struct {
+	PlanId         string   `json:"plan_id"`
+	PlanName       string   `json:"plan_name"`
+	Kind           string   `json:"kind"`
+	AssetId        string   `json:"asset_id"`
+	AssetName      string   `json:"asset_name"`
+	NextDueDate    string   `json:"next_due_date,omitempty"`
+	DaysUntilDue   *int     `json:"days_until_due,omitempty"`
+	NextDueUnits   float64  `json:"next_due_units,omitempty"`
+	UnitsUsed      *float64 `json:"units_used,omitempty"`
+	RemainingUnits *float64 `json:"remaining_units,omitempty"`
+	Overdue        bool     `json:"overdue"`
+}
@@ -1,6 +1,12 @@
 package dto
 
-import "strings"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
 
 type MetaPagination struct {
 	Page      int    `json:"page" query:"page"`
@@ -10,9 +16,35 @@ type MetaPagination struct {
 	Offset    int    `json:"offset,omitempty"`
 	Total     int64  `json:"total,omitempty"`
 	TotalPage int64  `json:"total_page,omitempty"`
+	// keyset pagination, used instead of Page when the cursor query param is present
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 	BaseResponse
+
+	// parsed from SortBy and Order by ParseSort
+	Sorts []SortField `json:"-"`
+
+	CursorMode    bool    `json:"-"`
+	Cursor        string  `json:"-"`
+	IncludeTotal  bool    `json:"-"`
+	DecodedCursor *Cursor `json:"-"`
 }
 
+type SortField struct {
+	Field string `json:"f"`
+	Desc  bool   `json:"d,omitempty"`
+}
+
+// Cursor points at a row of a keyset paginated listing. Values holds the row value of
+// every sort key, the last key is always id.
+type Cursor struct {
+	Sorts  []SortField   `json:"s"`
+	Values []interface{} `json:"v"`
+	Before bool          `json:"b,omitempty"`
+}
+
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
 func (p *MetaPagination) ParsePagination() *MetaPagination {
 	if p.Page <= 0 {
 		p.Page = 1
@@ -29,6 +61,9 @@ func (p *MetaPagination) ParsePagination() *MetaPagination {
 
 	if p.SortBy == "" {
 		p.SortBy = "created_at"
+	} else if p.Order == "" {
+		// explicit sort fields without an order sort ascending
+		p.Order = "asc"
 	}
 
 	if p.Order == "" {
@@ -42,3 +77,77 @@ func (p *MetaPagination) ParsePagination() *MetaPagination {
 	p.Offset = offset
 	return p
 }
+
+// ParseSort turns the comma separated SortBy into sort fields. A "-" prefix sorts that
+// field descending, fields without a prefix follow Order. Fields outside allowed are rejected.
+func (p *MetaPagination) ParseSort(allowed []string) error {
+	defaultDesc := strings.ToLower(strings.TrimSpace(p.Order)) == "desc"
+
+	p.Sorts = nil
+	for _, field := range strings.Split(p.SortBy, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		sort := SortField{Field: field, Desc: defaultDesc}
+		if strings.HasPrefix(field, "-") {
+			sort = SortField{Field: strings.TrimPrefix(field, "-"), Desc: true}
+		} else if strings.HasPrefix(field, "+") {
+			sort = SortField{Field: strings.TrimPrefix(field, "+"), Desc: false}
+		}
+
+		valid := false
+		for _, v := range allowed {
+			if v == sort.Field {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("Invalid sort field %s, allowed fields are %s", sort.Field, strings.Join(allowed, ", "))
+		}
+
+		p.Sorts = append(p.Sorts, sort)
+	}
+
+	return nil
+}
+
+// SortKeys returns the sort fields, or defaultSort when none are given, with id appended
+// as the final tie-breaker
+func (p *MetaPagination) SortKeys(defaultSort SortField) []SortField {
+	keys := append([]SortField{}, p.Sorts...)
+	if len(keys) == 0 {
+		keys = append(keys, defaultSort)
+	}
+
+	for _, key := range keys {
+		if key.Field == "id" {
+			return keys
+		}
+	}
+	return append(keys, SortField{Field: "id"})
+}
+
+func EncodeCursor(cursor *Cursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := new(Cursor)
+	if err = json.Unmarshal(decoded, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if len(cursor.Sorts) == 0 || len(cursor.Sorts) != len(cursor.Values) {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
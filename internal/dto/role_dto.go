@@ -0,0 +1,19 @@
+package dto
+
+type RoleOutputDto struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleAssignmentInputDto struct {
+	Subject string `json:"subject" validate:"required"`
+	Role    string `json:"role" validate:"required"`
+}
+
+type RoleAssignmentOutputDto struct {
+	Id         string `json:"id"`
+	Subject    string `json:"subject"`
+	Role       string `json:"role"`
+	AssignedBy string `json:"assigned_by"`
+	CreatedAt  string `json:"created_at"`
+}
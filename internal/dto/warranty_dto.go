@@ -0,0 +1,41 @@
+package dto
+
+type WarrantyInputDto struct {
+	Kind           string  `json:"kind" validate:"required"`
+	Provider       string  `json:"provider" validate:"required"`
+	CoverageType   string  `json:"coverage_type,omitempty"`
+	ContractNumber string  `json:"contract_number,omitempty"`
+	StartDate      string  `json:"start_date" validate:"required"`
+	EndDate        string  `json:"end_date" validate:"required"`
+	Cost           float64 `json:"cost"`
+}
+
+type WarrantyOutputDto struct {
+	Id             string  `json:"id"`
+	AssetId        string  `json:"asset_id"`
+	AssetName      string  `json:"asset_name,omitempty"`
+	Kind           string  `json:"kind"`
+	Provider       string  `json:"provider"`
+	CoverageType   string  `json:"coverage_type"`
+	ContractNumber string  `json:"contract_number"`
+	StartDate      string  `json:"start_date"`
+	EndDate        string  `json:"end_date"`
+	Cost           float64 `json:"cost"`
+	DaysLeft       int     `json:"days_left"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+// WarrantyExpiringEventDto is the data of the warranty.expiring event
+type WarrantyExpiringEventDto struct {
+	TenantId       string `json:"tenant_id"`
+	WarrantyId     string `json:"warranty_id"`
+	AssetId        string `json:"asset_id"`
+	AssetName      string `json:"asset_name"`
+	Kind           string `json:"kind"`
+	Provider       string `json:"provider"`
+	CoverageType   string `json:"coverage_type"`
+	ContractNumber string `json:"contract_number"`
+	EndDate        string `json:"end_date"`
+	DaysLeft       int    `json:"days_left"`
+}
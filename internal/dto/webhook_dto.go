@@ -0,0 +1,56 @@
+package dto
+
+type WebhookInputDto struct {
+	Url string `json:"url" validate:"required"`
+	// Events the webhook receives, ["*"] for every event
+	Events []string `json:"events" validate:"required"`
+	// Secret signing the deliveries, a random one is generated on create when empty and the
+	// current one is kept on update
+	Secret      string `json:"secret,omitempty"`
+	Description string `json:"description"`
+	// Active defaults to true
+	Active *bool `json:"active,omitempty"`
+}
+
+type WebhookOutputDto struct {
+	Id          string   `json:"id"`
+	Url         string   `json:"url"`
+	Events      []string `json:"events"`
+	Description string   `json:"description"`
+	Active      bool     `json:"active"`
+	CreatedBy   string   `json:"created_by"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// WebhookSecretOutputDto is returned when a webhook is created, the only time its secret can
+// be read
+type WebhookSecretOutputDto struct {
+	WebhookOutputDto
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryOutputDto struct {
+	Id            string `json:"id"`
+	WebhookId     string `json:"webhook_id"`
+	EventId       string `json:"event_id"`
+	EventType     string `json:"event_type"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt string `json:"next_attempt_at"`
+	LastError     string `json:"last_error"`
+	DeliveredAt   string `json:"delivered_at"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	// AttemptHistory is only set on a single delivery
+	AttemptHistory []*WebhookAttemptOutputDto `json:"attempt_history,omitempty"`
+}
+
+type WebhookAttemptOutputDto struct {
+	Id           string `json:"id"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody string `json:"response_body"`
+	Error        string `json:"error"`
+	DurationMs   int64  `json:"duration_ms"`
+	AttemptedAt  string `json:"attempted_at"`
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers of a webhook delivery. The signature lets a receiver check the body came from us,
+// the timestamp is part of the signed content so an old delivery cannot be replayed as new.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxResponseBody is how much of a webhook response is kept with the attempt
+const maxResponseBody = 1024
+
+// Sign returns the signature of a delivery, "sha256=" followed by the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Delivery is one signed request of an event to a webhook
+type Delivery struct {
+	Id        string
+	EventType string
+	Url       string
+	Secret    string
+	Body      []byte
+}
+
+// DeliveryResult is what came of a delivery request, StatusCode is 0 when no response came
+type DeliveryResult struct {
+	StatusCode   int
+	ResponseBody string
+	Duration     time.Duration
+}
+
+// Deliverer posts deliveries. The result is set even when an error is returned, an error means
+// the request failed or the webhook responded outside 2xx and the delivery should be retried.
+type Deliverer interface {
+	Deliver(ctx context.Context, delivery *Delivery) (*DeliveryResult, error)
+}
+
+type httpDeliverer struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewDeliverer returns a Deliverer that only connects to public addresses
+func NewDeliverer() Deliverer {
+	return &httpDeliverer{client: &http.Client{Timeout: webhookTimeout, Transport: publicTransport()}, now: time.Now}
+}
+
+func (d *httpDeliverer) Deliver(ctx context.Context, delivery *Delivery) (*DeliveryResult, error) {
+	result := &DeliveryResult{}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return result, err
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.Id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Body))
+
+	start := time.Now()
+	res, err := d.client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	result.StatusCode = res.StatusCode
+	result.ResponseBody = string(body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return result, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 of "1700000000.{}" keyed with "secret"
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", Sign("secret", 1700000000, []byte("{}")))
+	assert.NotEqual(t, Sign("secret", 1700000000, []byte("{}")), Sign("secret", 1700000001, []byte("{}")))
+	assert.NotEqual(t, Sign("secret", 1700000000, []byte("{}")), Sign("other", 1700000000, []byte("{}")))
+}
+
+func TestDeliver(t *testing.T) {
+	t.Run("Success - Posts a signed delivery", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, Sign("whsec_test", timestamp, body), r.Header.Get(HeaderSignature))
+			assert.Equal(t, "asset.created", r.Header.Get(HeaderEvent))
+			assert.Equal(t, "delivery-id", r.Header.Get(HeaderDelivery))
+			w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		result, err := (&httpDeliverer{client: server.Client(), now: time.Now}).Deliver(context.Background(), &Delivery{Id: "delivery-id", EventType: "asset.created", Url: server.URL, Secret: "whsec_test", Body: []byte(`{"type":"asset.created"}`)})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "ok", result.ResponseBody)
+	})
+
+	t.Run("Error - Non 2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("boom"))
+		}))
+		defer server.Close()
+
+		result, err := (&httpDeliverer{client: server.Client(), now: time.Now}).Deliver(context.Background(), &Delivery{Id: "delivery-id", Url: server.URL, Secret: "whsec_test", Body: []byte("{}")})
+		assert.EqualError(t, err, "webhook responded with status 500")
+		assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		assert.Equal(t, "boom", result.ResponseBody)
+	})
+
+	t.Run("Error - Loopback target", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("delivery reached a loopback address")
+		}))
+		defer server.Close()
+
+		result, err := NewDeliverer().Deliver(context.Background(), &Delivery{Id: "delivery-id", Url: server.URL, Secret: "whsec_test", Body: []byte("{}")})
+		assert.ErrorIs(t, err, ErrForbiddenTarget)
+		assert.Equal(t, 0, result.StatusCode)
+	})
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	SinkLog     = "log"
+	SinkWebhook = "webhook"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Event is something that happened to an asset that outside systems may want to act on
+type Event struct {
+	Id         string      `json:"id,omitempty"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Sink delivers events. An error means the event was not delivered and should be emitted again.
+type Sink interface {
+	Emit(ctx context.Context, event *Event) error
+}
+
+// NewSink returns the sink configured by kind, a webhook posting to webhookUrl or the log
+func NewSink(kind string, webhookUrl string) Sink {
+	if kind == SinkWebhook {
+		return NewWebhookSink(webhookUrl)
+	}
+	return NewLogSink()
+}
+
+type logSink struct{}
+
+func NewLogSink() Sink {
+	return &logSink{}
+}
+
+func (s *logSink) Emit(ctx context.Context, event *Event) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	log.Println("[events][Emit] event :", string(encoded))
+	return nil
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) Sink {
+	return &webhookSink{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Emit posts the event as JSON, any response outside 2xx is a failed delivery
+func (s *webhookSink) Emit(ctx context.Context, event *Event) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
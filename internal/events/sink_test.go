@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSink(t *testing.T) {
+	t.Run("Success - Posts the event", func(t *testing.T) {
+		var received Event
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := NewSink(SinkWebhook, server.URL).Emit(context.Background(), &Event{Type: "warranty.expiring", OccurredAt: time.Now().UTC(), Data: map[string]string{"warranty_id": "warranty-id"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "warranty.expiring", received.Type)
+	})
+
+	t.Run("Error - Non 2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewSink(SinkWebhook, server.URL).Emit(context.Background(), &Event{Type: "warranty.expiring"})
+		assert.EqualError(t, err, "webhook responded with status 503")
+	})
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenTarget is returned for a webhook host that is, or resolves to, a loopback,
+// link-local, private or otherwise non-public address
+var ErrForbiddenTarget = errors.New("webhook target is not a public address")
+
+// nonPublicPrefixes are the IPv4 ranges not covered by the netip.Addr checks: "this network",
+// carrier-grade NAT, IETF protocol assignments, benchmarking and the reserved block with
+// broadcast
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// lookupHost resolves the host of a webhook URL, replaced in tests
+var lookupHost = net.DefaultResolver.LookupNetIP
+
+// IsPublicAddr reports whether a webhook may be delivered to the address
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, v := range nonPublicPrefixes {
+		if v.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckTarget rejects a webhook host that is a non-public address or resolves to one. A host
+// that does not resolve is accepted, the delivery dialer checks the address it connects to
+// again so a name later pointed elsewhere cannot get around it.
+func CheckTarget(ctx context.Context, host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenTarget
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddr(addr) {
+			return ErrForbiddenTarget
+		}
+		return nil
+	}
+
+	addrs, err := lookupHost(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, v := range addrs {
+		if !IsPublicAddr(v) {
+			return ErrForbiddenTarget
+		}
+	}
+	return nil
+}
+
+// publicTransport only connects to public addresses, checked on the resolved address of every
+// connection including redirects. Proxies are not used, they would be dialed in place of the
+// target.
+func publicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !IsPublicAddr(addr) {
+				return ErrForbiddenTarget
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
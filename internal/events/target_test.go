@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTarget(t *testing.T) {
+	lookupHost = func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		switch host {
+		case "hooks.example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+		case "internal.example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("10.0.0.5")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	defer func() { lookupHost = net.DefaultResolver.LookupNetIP }()
+
+	tests := []struct {
+		host        string
+		expectedErr error
+	}{
+		{host: "hooks.example.com"},
+		{host: "93.184.216.34"},
+		{host: "2606:2800:220:1:248:1893:25c8:1946"},
+		{host: "unresolved.example.com"},
+		{host: "internal.example.com", expectedErr: ErrForbiddenTarget},
+		{host: "localhost", expectedErr: ErrForbiddenTarget},
+		{host: "api.LOCALHOST.", expectedErr: ErrForbiddenTarget},
+		{host: "127.0.0.1", expectedErr: ErrForbiddenTarget},
+		{host: "::1", expectedErr: ErrForbiddenTarget},
+		{host: "::ffff:127.0.0.1", expectedErr: ErrForbiddenTarget},
+		{host: "169.254.169.254", expectedErr: ErrForbiddenTarget},
+		{host: "fe80::1", expectedErr: ErrForbiddenTarget},
+		{host: "10.1.2.3", expectedErr: ErrForbiddenTarget},
+		{host: "172.16.0.1", expectedErr: ErrForbiddenTarget},
+		{host: "192.168.1.1", expectedErr: ErrForbiddenTarget},
+		{host: "fd00::1", expectedErr: ErrForbiddenTarget},
+		{host: "100.64.0.1", expectedErr: ErrForbiddenTarget},
+		{host: "0.0.0.0", expectedErr: ErrForbiddenTarget},
+		{host: "255.255.255.255", expectedErr: ErrForbiddenTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, CheckTarget(context.Background(), tt.host))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApiKeyHandlerInterface interface {
+	CreateApiKey(c *gin.Context)
+	GetApiKeys(c *gin.Context)
+	RevokeApiKey(c *gin.Context)
+	RotateApiKey(c *gin.Context)
+}
+
+type apiKeyHandler struct {
+	service services.ApiKeyServiceInterface
+}
+
+func NewApiKeyHandler(service services.ApiKeyServiceInterface) ApiKeyHandlerInterface {
+	return &apiKeyHandler{service: service}
+}
+
+// CreateApiKey creates an API key
+//
+//	@Summary      Create an API key
+//	@Description  Creates an API key for service-to-service calls, sent in the X-API-Key header. Scopes are assets:read, assets:write and assets:value. The key is only returned once.
+//	@Tags         api-keys
+//	@Accept       json
+//	@Produce      json
+//	@Param        api_key  body      dto.ApiKeyInputDto  true  "API key JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.ApiKeySecretOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /api-keys [post]
+func (h *apiKeyHandler) CreateApiKey(c *gin.Context) {
+	request := new(dto.ApiKeyInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[apiKeyHandler][CreateApiKey] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateApiKey(c.Request.Context(), request))
+}
+
+// GetApiKeys returns the API keys
+//
+//	@Summary      List API keys
+//	@Description  Returns the API keys, newest first, including revoked ones. Keys are never returned.
+//	@Tags         api-keys
+//	@Accept       json
+//	@Produce      json
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.ApiKeyOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /api-keys [get]
+func (h *apiKeyHandler) GetApiKeys(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[apiKeyHandler][GetApiKeys] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetApiKeys(c.Request.Context(), pagination))
+}
+
+// RevokeApiKey revokes an API key
+//
+//	@Summary      Revoke an API key
+//	@Description  Revokes an API key, it is rejected from the next request on.
+//	@Tags         api-keys
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "API key ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /api-keys/{id} [delete]
+func (h *apiKeyHandler) RevokeApiKey(c *gin.Context) {
+	c.JSON(h.service.RevokeApiKey(c.Request.Context(), c.Param("id")))
+}
+
+// RotateApiKey replaces the key of an API key
+//
+//	@Summary      Rotate an API key
+//	@Description  Issues a new key with the same name, scopes and expiry. The previous key stops working at once.
+//	@Tags         api-keys
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "API key ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.ApiKeySecretOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /api-keys/{id}/rotate [post]
+func (h *apiKeyHandler) RotateApiKey(c *gin.Context) {
+	c.JSON(h.service.RotateApiKey(c.Request.Context(), c.Param("id")))
+}
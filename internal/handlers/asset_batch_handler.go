@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetBatchHandlerInterface interface {
+	BatchCreateAssets(c *gin.Context)
+	BatchUpdateAssets(c *gin.Context)
+	BatchDeleteAssets(c *gin.Context)
+}
+
+type assetBatchHandler struct {
+	service services.AssetBatchServiceInterface
+}
+
+func NewAssetBatchHandler(service services.AssetBatchServiceInterface) AssetBatchHandlerInterface {
+	return &assetBatchHandler{service: service}
+}
+
+// BatchCreateAssets creates several assets
+//
+//	@Summary      Create assets in a batch
+//	@Description  Creates the items, each validated like a single create. In atomic mode (default) they are written in one transaction and nothing is written when an item fails, the other items then get 424. In best_effort mode every item is written on its own and 207 is returned when some of them failed. The number of items is limited by ASSET_BATCH_MAX_ITEMS.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        batch  body      dto.AssetBatchCreateInputDto  true  "Assets to create"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Success      207    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      403    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Router       /assets:batchCreate [post]
+func (h *assetBatchHandler) BatchCreateAssets(c *gin.Context) {
+	request := new(dto.AssetBatchCreateInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[assetBatchHandler][BatchCreateAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, invalidRequest(err))
+		return
+	}
+
+	c.JSON(h.service.BatchCreateAssets(c.Request.Context(), request))
+}
+
+// BatchUpdateAssets updates several assets
+//
+//	@Summary      Update assets in a batch
+//	@Description  Updates the assets of the items, each validated like a single update and, when it has a version, only applied to that version of the asset like If-Match. In atomic mode (default) they are written in one transaction and nothing is written when an item fails, the other items then get 424. In best_effort mode every item is written on its own and 207 is returned when some of them failed. The number of items is limited by ASSET_BATCH_MAX_ITEMS.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        batch  body      dto.AssetBatchUpdateInputDto  true  "Asset updates"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Success      207    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      403    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      412    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Router       /assets:batchUpdate [post]
+func (h *assetBatchHandler) BatchUpdateAssets(c *gin.Context) {
+	request := new(dto.AssetBatchUpdateInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[assetBatchHandler][BatchUpdateAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, invalidRequest(err))
+		return
+	}
+
+	c.JSON(h.service.BatchUpdateAssets(c.Request.Context(), request))
+}
+
+// BatchDeleteAssets moves several assets to the trash
+//
+//	@Summary      Delete assets in a batch
+//	@Description  Moves the assets of the items to the trash, an item with a version only deletes that version of the asset like If-Match. In atomic mode (default) they are deleted in one transaction and nothing is deleted when an item fails, the other items then get 424. In best_effort mode every item is deleted on its own and 207 is returned when some of them failed. The number of items is limited by ASSET_BATCH_MAX_ITEMS.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        batch  body      dto.AssetBatchDeleteInputDto  true  "Assets to delete"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Success      207    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      403    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      412    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=dto.AssetBatchResultDto}
+//	@Router       /assets:batchDelete [post]
+func (h *assetBatchHandler) BatchDeleteAssets(c *gin.Context) {
+	request := new(dto.AssetBatchDeleteInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[assetBatchHandler][BatchDeleteAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, invalidRequest(err))
+		return
+	}
+
+	c.JSON(h.service.BatchDeleteAssets(c.Request.Context(), request))
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetExportHandlerInterface interface {
+	ExportAssets(c *gin.Context)
+}
+
+type assetExportHandler struct {
+	service services.AssetExportServiceInterface
+}
+
+func NewAssetExportHandler(service services.AssetExportServiceInterface) AssetExportHandlerInterface {
+	return &assetExportHandler{service: service}
+}
+
+// ExportAssets streams every asset matching the filters as a file
+//
+//	@Summary      Export assets
+//	@Description  Downloads all assets matching the filters of the asset list as CSV, XLSX or NDJSON (one JSON asset per line). The file is streamed from the database, so there is no page or limit.
+//	@Tags         assets
+//	@Produce      text/csv
+//	@Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Produce      application/x-ndjson
+//	@Produce      json
+//	@Param        format   query      string  false  "File format (csv, xlsx, ndjson), defaults to csv"
+//	@Param        order   query      string  false  "Sort direction (asc, desc)"
+//	@Param        sort_by   query      string  false  "Sort fields, comma separated, prefix - for descending (e.g. -value,name)"
+//	@Param        q   query      string  false  "Search name or type (case insensitive, partial match)"
+//	@Param        name   query      string  false  "Name contains (case insensitive)"
+//	@Param        type   query      string  false  "Type contains (case insensitive)"
+//	@Param        category_id   query      string  false  "Category ID, includes the assets of its sub categories"
+//	@Param        location_id   query      string  false  "Location ID, includes the assets of its sub locations"
+//	@Param        status   query      string  false  "Assignment status (available, checked_out, overdue)"
+//	@Param        value_min   query      number  false  "Minimum value"
+//	@Param        value_max   query      number  false  "Maximum value"
+//	@Param        acquisition_date_from   query      string  false  "Acquired on or after (YYYY-MM-DD)"
+//	@Param        acquisition_date_to   query      string  false  "Acquired on or before (YYYY-MM-DD)"
+//	@Param        created_from   query      string  false  "Created on or after (YYYY-MM-DD or RFC3339)"
+//	@Param        created_to   query      string  false  "Created on or before (YYYY-MM-DD or RFC3339)"
+//	@Param        updated_from   query      string  false  "Updated on or after (YYYY-MM-DD or RFC3339)"
+//	@Param        updated_to   query      string  false  "Updated on or before (YYYY-MM-DD or RFC3339)"
+//	@Success      200    {file}  file
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/export [get]
+func (h *assetExportHandler) ExportAssets(c *gin.Context) {
+	filter, err := bindAssetFilter(c)
+	if err != nil {
+		log.Println("[assetExportHandler][ExportAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		})
+		return
+	}
+	pagination := &dto.MetaPagination{
+		Order:  c.Query("order"),
+		SortBy: c.Query("sort_by"),
+	}
+
+	code, export, response := h.service.ExportAssets(c.Request.Context(), filter, pagination, c.DefaultQuery("format", common.AssetExportCSV))
+	if response != nil {
+		c.JSON(code, response)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.FileName}))
+	c.Status(code)
+	if err = export.Write(c.Writer); err != nil {
+		log.Println("[assetExportHandler][ExportAssets] error write export :", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"assets-api-go/internal/common"
 	"assets-api-go/internal/dto"
 	"assets-api-go/internal/services"
+	"assets-api-go/internal/validation"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -14,9 +16,16 @@ import (
 type AssetHandlerInterface interface {
 	CreateAsset(c *gin.Context)
 	UpdateAsset(c *gin.Context)
+	PatchAsset(c *gin.Context)
 	GetAssetById(c *gin.Context)
 	GetAssets(c *gin.Context)
 	DeleteAsset(c *gin.Context)
+	GetDepreciationSchedule(c *gin.Context)
+	GetDeletedAssets(c *gin.Context)
+	RestoreAsset(c *gin.Context)
+	PurgeAsset(c *gin.Context)
+	TransitionAsset(c *gin.Context)
+	GetAssetTransitions(c *gin.Context)
 }
 
 type assetHandler struct {
@@ -30,42 +39,47 @@ func NewAssetHandler(service services.AssetServiceInterface) AssetHandlerInterfa
 // CreateAsset creates a new asset
 //
 //	@Summary      Create a new asset
-//	@Description  Takes an asset JSON and store in DB. Return saved JSON.
+//	@Description  Takes an asset JSON and store in DB. The asset references a category by category_id, or by name through type. The lifecycle status defaults to in_stock. Depreciation settings default to the category ones. Return saved JSON.
 //	@Tags         assets
 //	@Accept       json
 //	@Produce      json
 //	@Param        asset  body      dto.AssetInputDto  true  "Asset JSON"
 //	@Success      200    {object}  dto.BaseResponse{data=dto.AssetOutputDto}
+//	@Header       200    {string}  ETag  "Version of the asset"
 //	@Failure      400    {object}  dto.BaseResponse{data=nil}
 //	@Failure      404    {object}  dto.BaseResponse{data=nil}
 //	@Failure      500    {object}  dto.BaseResponse{data=nil}
 //	@Router       /assets [post]
 func (h *assetHandler) CreateAsset(c *gin.Context) {
 	request := new(dto.AssetInputDto)
-	err := c.Bind(&request)
-	if err != nil {
+	if err := c.ShouldBindJSON(request); err != nil {
 		log.Println("[assetHandler][CreateAsset] error binding request :", err)
-		c.JSON(http.StatusBadRequest, dto.BaseResponse{
-			Error:            common.BadRequest,
-			ErrorDescription: "invalid request",
-		})
+		c.JSON(http.StatusBadRequest, invalidRequest(err))
+		return
 	}
 
-	c.JSON(h.service.CreateAsset(request))
+	code, response := h.service.CreateAsset(c.Request.Context(), request)
+	setAssetETag(c, response)
+	c.JSON(code, response)
 }
 
 // UpdateAsset updates an asset
 //
 //	@Summary      Update an asset
-//	@Description  Takes an asset JSON and update in DB. Return updated JSON.
+//	@Description  Takes an asset JSON and update in DB. Return updated JSON. Send the ETag of the asset in If-Match to only update the version that was read, a mismatch returns 412.
 //	@Tags         assets
 //	@Accept       json
 //	@Produce      json
 //	@Param        id   path      string  true  "Asset ID"
+//	@Param        If-Match   header      string  false  "ETag of the asset version being updated"
 //	@Param        asset  body      dto.AssetInputDto  true  "Asset JSON"
 //	@Success      200    {object}  dto.BaseResponse{data=dto.AssetOutputDto}
+//	@Header       200    {string}  ETag  "Version of the asset"
 //	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      403    {object}  dto.BaseResponse{data=nil}
 //	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      412    {object}  dto.BaseResponse{data=nil}
 //	@Failure      500    {object}  dto.BaseResponse{data=nil}
 //	@Router       /assets/{id} [put]
 func (h *assetHandler) UpdateAsset(c *gin.Context) {
@@ -76,29 +90,66 @@ func (h *assetHandler) UpdateAsset(c *gin.Context) {
 			Error:            common.BadRequest,
 			ErrorDescription: "invalid request",
 		})
+		return
 	}
-	err := c.Bind(&request)
-	if err != nil {
+	if err := c.ShouldBindJSON(request); err != nil {
 		log.Println("[assetHandler][UpdateAsset] error binding request :", err)
+		c.JSON(http.StatusBadRequest, invalidRequest(err))
+		return
+	}
+
+	code, response := h.service.UpdateAsset(c.Request.Context(), id, request, c.GetHeader("If-Match"))
+	setAssetETag(c, response)
+	c.JSON(code, response)
+}
+
+// PatchAsset partially updates an asset
+//
+//	@Summary      Patch an asset
+//	@Description  Applies a JSON Merge Patch (application/merge-patch+json) or a JSON Patch (application/json-patch+json) to the fields of AssetInputDto. The patched asset is validated like an update and only the changed fields are written. Send the ETag of the asset in If-Match to only patch the version that was read, a mismatch returns 412.
+//	@Tags         assets
+//	@Accept       application/merge-patch+json
+//	@Accept       application/json-patch+json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        If-Match   header      string  false  "ETag of the asset version being patched"
+//	@Param        patch  body      object  true  "Merge patch object or JSON Patch operations"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssetOutputDto}
+//	@Header       200    {string}  ETag  "Version of the asset"
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      403    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      412    {object}  dto.BaseResponse{data=nil}
+//	@Failure      415    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id} [patch]
+func (h *assetHandler) PatchAsset(c *gin.Context) {
+	patch, err := c.GetRawData()
+	if err != nil {
+		log.Println("[assetHandler][PatchAsset] error reading request :", err)
 		c.JSON(http.StatusBadRequest, dto.BaseResponse{
 			Error:            common.BadRequest,
 			ErrorDescription: "invalid request",
 		})
+		return
 	}
 
-	c.JSON(h.service.UpdateAsset(id, request))
-
+	code, response := h.service.PatchAsset(c.Request.Context(), c.Param("id"), c.ContentType(), patch, c.GetHeader("If-Match"))
+	setAssetETag(c, response)
+	c.JSON(code, response)
 }
 
 // GetAssetById returns an asset
 //
 //	@Summary      Get an asset
-//	@Description  Returns an asset JSON.
+//	@Description  Returns an asset JSON. Assets with depreciation settings include the current book value and accumulated depreciation.
 //	@Tags         assets
 //	@Accept       json
 //	@Produce      json
 //	@Param        id   path      string  true  "Asset ID"
 //	@Success      200    {object}  dto.BaseResponse{data=dto.AssetOutputDto}
+//	@Header       200    {string}  ETag  "Version of the asset"
 //	@Failure      400    {object}  dto.BaseResponse{data=nil}
 //	@Failure      404    {object}  dto.BaseResponse{data=nil}
 //	@Failure      500    {object}  dto.BaseResponse{data=nil}
@@ -110,21 +161,40 @@ func (h *assetHandler) GetAssetById(c *gin.Context) {
 			Error:            common.BadRequest,
 			ErrorDescription: "invalid request",
 		})
+		return
 	}
-	c.JSON(h.service.GetAssetById(id))
+	code, response := h.service.GetAssetById(c.Request.Context(), id)
+	setAssetETag(c, response)
+	c.JSON(code, response)
 }
 
 // GetAssets returns a list of assets
 //
 //	@Summary      List assets
-//	@Description  Returns a list of assets JSON.
+//	@Description  Returns a list of assets JSON. Uses page/limit pagination unless the cursor param is present.
 //	@Tags         assets
 //	@Accept       json
 //	@Produce      json
 //	@Param        page   query      int  false  "Page number"
 //	@Param        limit   query      int  false  "Limit number"
-//	@Param        order   query      string  false  "Order"
-//	@Param        sort_by   query      string  false  "Sort by"
+//	@Param        order   query      string  false  "Default direction (asc or desc) for sort fields without a prefix"
+//	@Param        sort_by   query      string  false  "Comma separated sort fields, prefix with - for descending (e.g. type,-value). Allowed: id, name, type, value, acquisition_date, created_at, updated_at"
+//	@Param        cursor   query      string  false  "Keyset pagination cursor, send it empty for the first page and then pass next_cursor or prev_cursor. Replaces page"
+//	@Param        include_total   query      bool  false  "Count the total in cursor mode"
+//	@Param        q   query      string  false  "Search name and type"
+//	@Param        name   query      string  false  "Name contains, case-insensitive"
+//	@Param        type   query      string  false  "Type, case-insensitive"
+//	@Param        category_id   query      string  false  "Category ID, includes the assets of its sub categories"
+//	@Param        location_id   query      string  false  "Location ID, includes the assets of its sub locations"
+//	@Param        status   query      string  false  "Assignment status (available, checked_out, overdue)"
+//	@Param        value_min   query      number  false  "Minimum value"
+//	@Param        value_max   query      number  false  "Maximum value"
+//	@Param        acquisition_date_from   query      string  false  "Acquired on or after (YYYY-MM-DD)"
+//	@Param        acquisition_date_to   query      string  false  "Acquired on or before (YYYY-MM-DD)"
+//	@Param        created_from   query      string  false  "Created on or after (YYYY-MM-DD or RFC3339)"
+//	@Param        created_to   query      string  false  "Created on or before (YYYY-MM-DD or RFC3339)"
+//	@Param        updated_from   query      string  false  "Updated on or after (YYYY-MM-DD or RFC3339)"
+//	@Param        updated_to   query      string  false  "Updated on or before (YYYY-MM-DD or RFC3339)"
 //	@Success      200    {object}  dto.MetaPagination{data=[]dto.AssetOutputDto}
 //	@Failure      400    {object}  dto.MetaPagination{data=nil}
 //	@Failure      500    {object}  dto.MetaPagination{data=nil}
@@ -137,6 +207,7 @@ func (h *assetHandler) GetAssets(c *gin.Context) {
 			Error:            common.BadRequest,
 			ErrorDescription: "invalid request",
 		})
+		return
 	}
 	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	if err != nil {
@@ -145,6 +216,7 @@ func (h *assetHandler) GetAssets(c *gin.Context) {
 			Error:            common.BadRequest,
 			ErrorDescription: "invalid request",
 		})
+		return
 	}
 	pagination := &dto.MetaPagination{
 		Page:   page,
@@ -153,21 +225,37 @@ func (h *assetHandler) GetAssets(c *gin.Context) {
 		SortBy: c.Query("sort_by"),
 	}
 
+	filter, err := bindAssetFilter(c)
+	if err != nil {
+		log.Println("[assetHandler][GetAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		})
+		return
+	}
+
+	pagination.Cursor, pagination.CursorMode = c.GetQuery("cursor")
+	pagination.IncludeTotal = c.Query("include_total") == "true"
+
 	pagination = pagination.ParsePagination()
-	c.JSON(h.service.GetAssets(pagination))
+	c.JSON(h.service.GetAssets(c.Request.Context(), filter, pagination))
 }
 
 // DeleteAsset deletes an asset
 //
 //	@Summary      Delete an asset
-//	@Description  Delete an asset.
+//	@Description  Moves an asset to the trash. Trashed assets can be restored until they are purged. Send the ETag of the asset in If-Match to only delete the version that was read, a mismatch returns 412.
 //	@Tags         assets
 //	@Accept       json
 //	@Produce      json
 //	@Param        id   path      string  true  "Asset ID"
+//	@Param        If-Match   header      string  false  "ETag of the asset version being deleted"
 //	@Success      200    {object}  dto.BaseResponse{data=nil,}
 //	@Failure      400    {object}  dto.BaseResponse{data=nil}
 //	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      412    {object}  dto.BaseResponse{data=nil}
 //	@Failure      500    {object}  dto.BaseResponse{data=nil}
 //	@Router       /assets/{id} [delete]
 func (h *assetHandler) DeleteAsset(c *gin.Context) {
@@ -177,6 +265,241 @@ func (h *assetHandler) DeleteAsset(c *gin.Context) {
 			Error:            common.BadRequest,
 			ErrorDescription: "invalid request",
 		})
+		return
+	}
+	c.JSON(h.service.DeleteAsset(c.Request.Context(), id, c.GetHeader("If-Match")))
+}
+
+// GetDepreciationSchedule returns the depreciation schedule of an asset
+//
+//	@Summary      Get asset depreciation schedule
+//	@Description  Returns the period-by-period depreciation schedule of an asset. Units of production schedules assume even usage over the useful life.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.DepreciationScheduleDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/depreciation-schedule [get]
+func (h *assetHandler) GetDepreciationSchedule(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	c.JSON(h.service.GetDepreciationSchedule(c.Request.Context(), id))
+}
+
+// GetDeletedAssets returns a list of assets in the trash
+//
+//	@Summary      List trashed assets
+//	@Description  Returns a list of deleted assets JSON.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.AssetOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /assets/trash [get]
+func (h *assetHandler) GetDeletedAssets(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		log.Println("[assetHandler][GetDeletedAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		log.Println("[assetHandler][GetDeletedAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	pagination := &dto.MetaPagination{
+		Page:  page,
+		Limit: limit,
+	}
+
+	pagination = pagination.ParsePagination()
+	c.JSON(h.service.GetDeletedAssets(c.Request.Context(), pagination))
+}
+
+// RestoreAsset restores an asset from the trash
+//
+//	@Summary      Restore an asset
+//	@Description  Restores a deleted asset from the trash. Return restored JSON.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssetOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/restore [post]
+func (h *assetHandler) RestoreAsset(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	code, response := h.service.RestoreAsset(c.Request.Context(), id)
+	setAssetETag(c, response)
+	c.JSON(code, response)
+}
+
+// PurgeAsset permanently deletes an asset
+//
+//	@Summary      Purge an asset
+//	@Description  Permanently removes an asset from the trash. This cannot be undone.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/purge [delete]
+func (h *assetHandler) PurgeAsset(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	c.JSON(h.service.PurgeAsset(c.Request.Context(), id))
+}
+
+// bindAssetFilter reads the asset list filters from the query
+func bindAssetFilter(c *gin.Context) (*dto.AssetFilterDto, error) {
+	filter := &dto.AssetFilterDto{
+		Q:                   c.Query("q"),
+		Name:                c.Query("name"),
+		Type:                c.Query("type"),
+		CategoryId:          c.Query("category_id"),
+		LocationId:          c.Query("location_id"),
+		Status:              c.Query("status"),
+		AcquisitionDateFrom: c.Query("acquisition_date_from"),
+		AcquisitionDateTo:   c.Query("acquisition_date_to"),
+		CreatedFrom:         c.Query("created_from"),
+		CreatedTo:           c.Query("created_to"),
+		UpdatedFrom:         c.Query("updated_from"),
+		UpdatedTo:           c.Query("updated_to"),
+	}
+
+	var err error
+	if filter.ValueMin, err = queryFloat(c, "value_min"); err != nil {
+		return nil, errors.New("invalid value_min")
+	}
+	if filter.ValueMax, err = queryFloat(c, "value_max"); err != nil {
+		return nil, errors.New("invalid value_max")
+	}
+	return filter, nil
+}
+
+// queryFloat reads an optional float query param
+func queryFloat(c *gin.Context, key string) (*float64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// TransitionAsset moves an asset to another lifecycle status
+//
+//	@Summary      Transition an asset
+//	@Description  Moves an asset to another lifecycle status (ordered, in_stock, deployed, in_repair, retired, disposed, lost) with a reason. Moves the transition graph does not allow are rejected with the allowed statuses.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        transition  body      dto.AssetTransitionInputDto  true  "Transition JSON"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssetTransitionOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=dto.InvalidTransitionDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/transitions [post]
+func (h *assetHandler) TransitionAsset(c *gin.Context) {
+	request := new(dto.AssetTransitionInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[assetHandler][TransitionAsset] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.TransitionAsset(c.Request.Context(), c.Param("id"), request))
+}
+
+// GetAssetTransitions returns the lifecycle transitions of an asset
+//
+//	@Summary      Get asset transitions
+//	@Description  Returns the lifecycle transitions of an asset with their reasons, newest first.
+//	@Tags         assets
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.AssetTransitionOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /assets/{id}/transitions [get]
+func (h *assetHandler) GetAssetTransitions(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[assetHandler][GetAssetTransitions] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetAssetTransitions(c.Request.Context(), c.Param("id"), pagination))
+}
+
+// setAssetETag sets the version of the returned asset in the ETag header, for clients to send
+// it back in If-Match
+func setAssetETag(c *gin.Context, response *dto.BaseResponse) {
+	if asset, ok := response.Data.(*dto.AssetOutputDto); ok {
+		c.Header("ETag", common.ETag(asset.Version))
+	}
+}
+
+// invalidRequest is the 400 of a request body that does not decode, naming the field when a
+// value has the wrong type
+func invalidRequest(err error) dto.BaseResponse {
+	return dto.BaseResponse{
+		Error:            common.BadRequest,
+		ErrorDescription: "invalid request",
+		Errors:           validation.BindingErrors(err),
 	}
-	c.JSON(h.service.DeleteAsset(id))
 }
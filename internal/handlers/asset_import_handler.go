@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetImportHandlerInterface interface {
+	ImportAssets(c *gin.Context)
+}
+
+type assetImportHandler struct {
+	service services.AssetImportServiceInterface
+}
+
+func NewAssetImportHandler(service services.AssetImportServiceInterface) AssetImportHandlerInterface {
+	return &assetImportHandler{service: service}
+}
+
+// assetImportMaxSize is the largest import file accepted in bytes
+const assetImportMaxSize = 20 << 20
+
+// ImportAssets creates assets from a CSV or XLSX file
+//
+//	@Summary      Import assets
+//	@Description  Creates the assets of a CSV or XLSX file (first worksheet) uploaded as multipart field "file". The first row holds the column headers, columns named like the asset fields (e.g. name, type, value, acquisition_date) are read as is and "mapping" maps other headers, e.g. {"name":"Asset Name","acquisition_date":"Bought On"}. Every row is validated like a single create. With dry_run=true the per-row results are returned without writing, otherwise nothing is written unless all rows are valid.
+//	@Tags         assets
+//	@Accept       multipart/form-data
+//	@Produce      json
+//	@Param        file  formData  file  true  "CSV or XLSX file"
+//	@Param        mapping  formData  string  false  "JSON object mapping asset fields to column headers"
+//	@Param        dry_run  query      bool  false  "Validate only"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssetImportResultDto}
+//	@Success      201    {object}  dto.BaseResponse{data=dto.AssetImportResultDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=dto.AssetImportResultDto}
+//	@Failure      413    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=dto.AssetImportResultDto}
+//	@Router       /assets/import [post]
+func (h *assetImportHandler) ImportAssets(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, assetImportMaxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		log.Println("[assetImportHandler][ImportAssets] error binding request :", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.BaseResponse{
+				Error:            common.PayloadTooLarge,
+				ErrorDescription: "request body is too large",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request, expected a multipart form with a file field",
+		})
+		return
+	}
+
+	request := &dto.AssetImportInputDto{FileName: header.Filename}
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		if err = json.Unmarshal([]byte(mapping), &request.Mapping); err != nil {
+			log.Println("[assetImportHandler][ImportAssets] error binding mapping :", err)
+			c.JSON(http.StatusBadRequest, dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "invalid mapping, expected a JSON object of column headers",
+			})
+			return
+		}
+	}
+	if dryRun := c.Query("dry_run"); dryRun != "" {
+		if request.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			c.JSON(http.StatusBadRequest, dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "invalid dry_run, expected true or false",
+			})
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err == nil {
+		request.Content, err = io.ReadAll(file)
+		file.Close()
+	}
+	if err != nil {
+		log.Println("[assetImportHandler][ImportAssets] error read file :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.ImportAssets(c.Request.Context(), request))
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssignmentHandlerInterface interface {
+	CheckoutAsset(c *gin.Context)
+	CheckinAsset(c *gin.Context)
+	GetAssignments(c *gin.Context)
+}
+
+type assignmentHandler struct {
+	service services.AssignmentServiceInterface
+}
+
+func NewAssignmentHandler(service services.AssignmentServiceInterface) AssignmentHandlerInterface {
+	return &assignmentHandler{service: service}
+}
+
+// CheckoutAsset checks an asset out to a person
+//
+//	@Summary      Check out an asset
+//	@Description  Assigns an asset to a person until the due date. An asset that is already checked out cannot be checked out again.
+//	@Tags         assignments
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        checkout  body      dto.CheckoutInputDto  true  "Checkout JSON, due_date as YYYY-MM-DD"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.AssignmentOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/checkout [post]
+func (h *assignmentHandler) CheckoutAsset(c *gin.Context) {
+	request := new(dto.CheckoutInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[assignmentHandler][CheckoutAsset] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CheckoutAsset(c.Request.Context(), c.Param("id"), request))
+}
+
+// CheckinAsset returns a checked out asset
+//
+//	@Summary      Check in an asset
+//	@Description  Closes the open assignment of an asset and records the condition it was returned in (good, fair, poor or damaged).
+//	@Tags         assignments
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        checkin  body      dto.CheckinInputDto  true  "Checkin JSON"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.AssignmentOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/checkin [post]
+func (h *assignmentHandler) CheckinAsset(c *gin.Context) {
+	request := new(dto.CheckinInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[assignmentHandler][CheckinAsset] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CheckinAsset(c.Request.Context(), c.Param("id"), request))
+}
+
+// GetAssignments returns the assignment history of an asset
+//
+//	@Summary      Get asset assignments
+//	@Description  Returns every check-out of an asset, newest first.
+//	@Tags         assignments
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.AssignmentOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /assets/{id}/assignments [get]
+func (h *assignmentHandler) GetAssignments(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[assignmentHandler][GetAssignments] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetAssignments(c.Request.Context(), c.Param("id"), pagination))
+}
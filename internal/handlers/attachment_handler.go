@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttachmentHandlerInterface interface {
+	UploadAttachment(c *gin.Context)
+	GetAttachments(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DownloadThumbnail(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+}
+
+type attachmentHandler struct {
+	service services.AttachmentServiceInterface
+	maxSize int64
+}
+
+func NewAttachmentHandler(service services.AttachmentServiceInterface, maxSize int64) AttachmentHandlerInterface {
+	return &attachmentHandler{service: service, maxSize: maxSize}
+}
+
+// multipartOverhead is the room left for the multipart framing and other form fields above
+// the largest accepted file
+const multipartOverhead = 1 << 20
+
+// MaxRequestBodySize returns the largest request body any handler accepts, the bigger of the
+// attachment and the import upload limits
+func MaxRequestBodySize(attachmentMaxSize int64) int64 {
+	if attachmentMaxSize > assetImportMaxSize {
+		return attachmentMaxSize + multipartOverhead
+	}
+	return assetImportMaxSize + multipartOverhead
+}
+
+// UploadAttachment stores a file with an asset
+//
+//	@Summary      Upload an attachment
+//	@Description  Uploads a file such as an invoice, photo or manual as multipart form field "file". The type is detected from the content and must be one of the allowed types, images get a thumbnail.
+//	@Tags         attachments
+//	@Accept       multipart/form-data
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        file  formData  file  true  "File to upload"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.AttachmentOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      413    {object}  dto.BaseResponse{data=nil}
+//	@Failure      415    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/attachments [post]
+func (h *attachmentHandler) UploadAttachment(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		log.Println("[attachmentHandler][UploadAttachment] error binding request :", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.BaseResponse{
+				Error:            common.PayloadTooLarge,
+				ErrorDescription: "request body is too large",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request, expected a multipart form with a file field",
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Println("[attachmentHandler][UploadAttachment] error open file :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	defer file.Close()
+
+	c.JSON(h.service.UploadAttachment(c.Request.Context(), c.Param("id"), header.Filename, header.Size, file))
+}
+
+// GetAttachments returns the attachments of an asset
+//
+//	@Summary      Get asset attachments
+//	@Description  Returns the attachments of an asset, newest first.
+//	@Tags         attachments
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Success      200    {object}  dto.BaseResponse{data=[]dto.AttachmentOutputDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/attachments [get]
+func (h *attachmentHandler) GetAttachments(c *gin.Context) {
+	c.JSON(h.service.GetAttachments(c.Request.Context(), c.Param("id")))
+}
+
+// DownloadAttachment streams the file of an attachment
+//
+//	@Summary      Download an attachment
+//	@Description  Returns the stored file with its original name. The X-Checksum-Sha256 header carries the SHA-256 of the content.
+//	@Tags         attachments
+//	@Produce      octet-stream
+//	@Param        id   path      string  true  "Attachment ID"
+//	@Success      200    {file}  file
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /attachments/{id}/download [get]
+func (h *attachmentHandler) DownloadAttachment(c *gin.Context) {
+	h.download(c, false)
+}
+
+// DownloadThumbnail streams the thumbnail of an image attachment
+//
+//	@Summary      Download an attachment thumbnail
+//	@Description  Returns a JPEG thumbnail of an image attachment, at most 256 pixels on its longest side.
+//	@Tags         attachments
+//	@Produce      jpeg
+//	@Param        id   path      string  true  "Attachment ID"
+//	@Success      200    {file}  file
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /attachments/{id}/thumbnail [get]
+func (h *attachmentHandler) DownloadThumbnail(c *gin.Context) {
+	h.download(c, true)
+}
+
+// DeleteAttachment removes an attachment
+//
+//	@Summary      Delete an attachment
+//	@Description  Removes an attachment and its stored files.
+//	@Tags         attachments
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Attachment ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /attachments/{id} [delete]
+func (h *attachmentHandler) DeleteAttachment(c *gin.Context) {
+	c.JSON(h.service.DeleteAttachment(c.Request.Context(), c.Param("id")))
+}
+
+func (h *attachmentHandler) download(c *gin.Context, thumbnail bool) {
+	code, content, response := h.service.DownloadAttachment(c.Request.Context(), c.Param("id"), thumbnail)
+	if response != nil {
+		c.JSON(code, response)
+		return
+	}
+	defer content.Content.Close()
+
+	c.Header("Content-Type", content.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": content.FileName}))
+	if content.Checksum != "" {
+		c.Header("X-Checksum-Sha256", content.Checksum)
+	}
+	c.Status(code)
+	if _, err := io.Copy(c.Writer, content.Content); err != nil {
+		log.Println("[attachmentHandler][download] error write file :", err)
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandlerInterface interface {
+	GetAssetHistory(c *gin.Context)
+	GetAuditLogs(c *gin.Context)
+}
+
+type auditHandler struct {
+	service services.AuditServiceInterface
+}
+
+func NewAuditHandler(service services.AuditServiceInterface) AuditHandlerInterface {
+	return &auditHandler{service: service}
+}
+
+// GetAssetHistory returns the audit trail of an asset
+//
+//	@Summary      Get asset history
+//	@Description  Returns every recorded change of an asset, newest first.
+//	@Tags         audit
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.AuditLogOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /assets/{id}/history [get]
+func (h *auditHandler) GetAssetHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[auditHandler][GetAssetHistory] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetAssetHistory(c.Request.Context(), id, pagination))
+}
+
+// GetAuditLogs returns audit records across all assets
+//
+//	@Summary      List audit records
+//	@Description  Returns audit records, newest first. Dates accept YYYY-MM-DD or RFC3339.
+//	@Tags         audit
+//	@Accept       json
+//	@Produce      json
+//	@Param        entity_type   query      string  false  "Entity type"
+//	@Param        entity_id   query      string  false  "Entity ID"
+//	@Param        action   query      string  false  "Action (create, update, delete, restore, purge)"
+//	@Param        actor   query      string  false  "Actor"
+//	@Param        request_id   query      string  false  "Request ID"
+//	@Param        from   query      string  false  "Recorded at or after"
+//	@Param        to   query      string  false  "Recorded before, a date includes the whole day"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.AuditLogOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /audit [get]
+func (h *auditHandler) GetAuditLogs(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[auditHandler][GetAuditLogs] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	filter := &dto.AuditFilterDto{
+		EntityType: c.Query("entity_type"),
+		EntityId:   c.Query("entity_id"),
+		Action:     c.Query("action"),
+		Actor:      c.Query("actor"),
+		RequestId:  c.Query("request_id"),
+		From:       c.Query("from"),
+		To:         c.Query("to"),
+	}
+
+	c.JSON(h.service.GetAuditLogs(c.Request.Context(), filter, pagination))
+}
+
+// bindPagination reads the page and limit query params
+func bindPagination(c *gin.Context) (*dto.MetaPagination, error) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		return nil, err
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := &dto.MetaPagination{
+		Page:  page,
+		Limit: limit,
+	}
+	return pagination.ParsePagination(), nil
+}
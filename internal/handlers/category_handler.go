@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandlerInterface interface {
+	CreateCategory(c *gin.Context)
+	UpdateCategory(c *gin.Context)
+	GetCategoryById(c *gin.Context)
+	GetCategories(c *gin.Context)
+	GetCategoryTree(c *gin.Context)
+	DeleteCategory(c *gin.Context)
+}
+
+type categoryHandler struct {
+	service services.CategoryServiceInterface
+}
+
+func NewCategoryHandler(service services.CategoryServiceInterface) CategoryHandlerInterface {
+	return &categoryHandler{service: service}
+}
+
+// CreateCategory creates a new category
+//
+//	@Summary      Create a new category
+//	@Description  Takes a category JSON and store in DB. Names and codes are unique, case-insensitive. Return saved JSON.
+//	@Tags         categories
+//	@Accept       json
+//	@Produce      json
+//	@Param        category  body      dto.CategoryInputDto  true  "Category JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.CategoryOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /categories [post]
+func (h *categoryHandler) CreateCategory(c *gin.Context) {
+	request := new(dto.CategoryInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[categoryHandler][CreateCategory] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateCategory(c.Request.Context(), request))
+}
+
+// UpdateCategory updates a category
+//
+//	@Summary      Update a category
+//	@Description  Takes a category JSON and update in DB. Renaming a category renames the type of its assets. Return updated JSON.
+//	@Tags         categories
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Category ID"
+//	@Param        category  body      dto.CategoryInputDto  true  "Category JSON"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.CategoryOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /categories/{id} [put]
+func (h *categoryHandler) UpdateCategory(c *gin.Context) {
+	id := c.Param("id")
+	request := new(dto.CategoryInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[categoryHandler][UpdateCategory] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.UpdateCategory(c.Request.Context(), id, request))
+}
+
+// GetCategoryById returns a category
+//
+//	@Summary      Get a category
+//	@Description  Returns a category JSON.
+//	@Tags         categories
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Category ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.CategoryOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /categories/{id} [get]
+func (h *categoryHandler) GetCategoryById(c *gin.Context) {
+	c.JSON(h.service.GetCategoryById(c.Request.Context(), c.Param("id")))
+}
+
+// GetCategories returns a list of categories
+//
+//	@Summary      List categories
+//	@Description  Returns a list of categories JSON ordered by name.
+//	@Tags         categories
+//	@Accept       json
+//	@Produce      json
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Param        q   query      string  false  "Search name and code"
+//	@Param        parent_id   query      string  false  "Direct children of a category, root for the top level categories"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.CategoryOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /categories [get]
+func (h *categoryHandler) GetCategories(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[categoryHandler][GetCategories] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	filter := &dto.CategoryFilterDto{
+		Q:        c.Query("q"),
+		ParentId: c.Query("parent_id"),
+	}
+
+	c.JSON(h.service.GetCategories(c.Request.Context(), filter, pagination))
+}
+
+// GetCategoryTree returns the category hierarchy
+//
+//	@Summary      Get category tree
+//	@Description  Returns every category nested under its parent.
+//	@Tags         categories
+//	@Accept       json
+//	@Produce      json
+//	@Success      200    {object}  dto.BaseResponse{data=[]dto.CategoryOutputDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /categories/tree [get]
+func (h *categoryHandler) GetCategoryTree(c *gin.Context) {
+	c.JSON(h.service.GetCategoryTree(c.Request.Context()))
+}
+
+// DeleteCategory deletes a category
+//
+//	@Summary      Delete a category
+//	@Description  Deletes a category without sub categories or assets, trashed assets included.
+//	@Tags         categories
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Category ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /categories/{id} [delete]
+func (h *categoryHandler) DeleteCategory(c *gin.Context) {
+	c.JSON(h.service.DeleteCategory(c.Request.Context(), c.Param("id")))
+}
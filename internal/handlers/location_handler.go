@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationHandlerInterface interface {
+	CreateLocation(c *gin.Context)
+	UpdateLocation(c *gin.Context)
+	GetLocationById(c *gin.Context)
+	GetLocations(c *gin.Context)
+	GetLocationTree(c *gin.Context)
+	DeleteLocation(c *gin.Context)
+	GetLocationAssets(c *gin.Context)
+}
+
+type locationHandler struct {
+	service services.LocationServiceInterface
+}
+
+func NewLocationHandler(service services.LocationServiceInterface) LocationHandlerInterface {
+	return &locationHandler{service: service}
+}
+
+// CreateLocation creates a new location
+//
+//	@Summary      Create a new location
+//	@Description  Takes a location JSON and store in DB. Sites are top level, buildings sit in a site, floors in a building and rooms in a floor. Return saved JSON.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Param        location  body      dto.LocationInputDto  true  "Location JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.LocationOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /locations [post]
+func (h *locationHandler) CreateLocation(c *gin.Context) {
+	request := new(dto.LocationInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[locationHandler][CreateLocation] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateLocation(c.Request.Context(), request))
+}
+
+// UpdateLocation updates a location
+//
+//	@Summary      Update a location
+//	@Description  Takes a location JSON and update in DB. The kind of a location with sub locations cannot change. Return updated JSON.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Location ID"
+//	@Param        location  body      dto.LocationInputDto  true  "Location JSON"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.LocationOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /locations/{id} [put]
+func (h *locationHandler) UpdateLocation(c *gin.Context) {
+	id := c.Param("id")
+	request := new(dto.LocationInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[locationHandler][UpdateLocation] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.UpdateLocation(c.Request.Context(), id, request))
+}
+
+// GetLocationById returns a location
+//
+//	@Summary      Get a location
+//	@Description  Returns a location JSON.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Location ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.LocationOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /locations/{id} [get]
+func (h *locationHandler) GetLocationById(c *gin.Context) {
+	c.JSON(h.service.GetLocationById(c.Request.Context(), c.Param("id")))
+}
+
+// GetLocations returns a list of locations
+//
+//	@Summary      List locations
+//	@Description  Returns a list of locations JSON ordered by name.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Param        q   query      string  false  "Search name"
+//	@Param        kind   query      string  false  "Kind (site, building, floor, room)"
+//	@Param        parent_id   query      string  false  "Direct children of a location, root for the top level locations"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.LocationOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /locations [get]
+func (h *locationHandler) GetLocations(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[locationHandler][GetLocations] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	filter := &dto.LocationFilterDto{
+		Q:        c.Query("q"),
+		Kind:     c.Query("kind"),
+		ParentId: c.Query("parent_id"),
+	}
+
+	c.JSON(h.service.GetLocations(c.Request.Context(), filter, pagination))
+}
+
+// GetLocationTree returns the location hierarchy
+//
+//	@Summary      Get location tree
+//	@Description  Returns every location nested under its parent.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Success      200    {object}  dto.BaseResponse{data=[]dto.LocationOutputDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /locations/tree [get]
+func (h *locationHandler) GetLocationTree(c *gin.Context) {
+	c.JSON(h.service.GetLocationTree(c.Request.Context()))
+}
+
+// DeleteLocation deletes a location
+//
+//	@Summary      Delete a location
+//	@Description  Deletes a location without sub locations or assets, trashed assets included.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Location ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /locations/{id} [delete]
+func (h *locationHandler) DeleteLocation(c *gin.Context) {
+	c.JSON(h.service.DeleteLocation(c.Request.Context(), c.Param("id")))
+}
+
+// GetLocationAssets returns the assets of a location subtree
+//
+//	@Summary      List location assets
+//	@Description  Returns the assets of a location and every location below it, with the asset count and total value of the whole subtree.
+//	@Tags         locations
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Location ID"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=dto.LocationAssetsDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      404    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /locations/{id}/assets [get]
+func (h *locationHandler) GetLocationAssets(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[locationHandler][GetLocationAssets] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetLocationAssets(c.Request.Context(), c.Param("id"), pagination))
+}
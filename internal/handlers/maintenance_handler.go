@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceHandlerInterface interface {
+	CreateMaintenanceRecord(c *gin.Context)
+	GetMaintenanceRecords(c *gin.Context)
+	CreateMaintenancePlan(c *gin.Context)
+	GetMaintenancePlans(c *gin.Context)
+	DeleteMaintenancePlan(c *gin.Context)
+	GetDueMaintenance(c *gin.Context)
+}
+
+type maintenanceHandler struct {
+	service services.MaintenanceServiceInterface
+}
+
+func NewMaintenanceHandler(service services.MaintenanceServiceInterface) MaintenanceHandlerInterface {
+	return &maintenanceHandler{service: service}
+}
+
+// CreateMaintenanceRecord records maintenance work on an asset
+//
+//	@Summary      Create a maintenance record
+//	@Description  Records maintenance work done on an asset. Work done for a plan_id moves that plan to its next due date or units.
+//	@Tags         maintenance
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        record  body      dto.MaintenanceRecordInputDto  true  "Maintenance record JSON, performed_at as YYYY-MM-DD"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.MaintenanceRecordOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/maintenance [post]
+func (h *maintenanceHandler) CreateMaintenanceRecord(c *gin.Context) {
+	request := new(dto.MaintenanceRecordInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[maintenanceHandler][CreateMaintenanceRecord] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateMaintenanceRecord(c.Request.Context(), c.Param("id"), request))
+}
+
+// GetMaintenanceRecords returns the maintenance records of an asset
+//
+//	@Summary      Get maintenance records
+//	@Description  Returns the maintenance work done on an asset, latest first.
+//	@Tags         maintenance
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.MaintenanceRecordOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /assets/{id}/maintenance [get]
+func (h *maintenanceHandler) GetMaintenanceRecords(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[maintenanceHandler][GetMaintenanceRecords] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetMaintenanceRecords(c.Request.Context(), c.Param("id"), pagination))
+}
+
+// CreateMaintenancePlan creates a preventive maintenance plan for an asset
+//
+//	@Summary      Create a maintenance plan
+//	@Description  Creates a recurring maintenance plan, either calendar based with interval_days or usage based with interval_units counted on the asset units used.
+//	@Tags         maintenance
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        plan  body      dto.MaintenancePlanInputDto  true  "Maintenance plan JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.MaintenancePlanOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/maintenance-plans [post]
+func (h *maintenanceHandler) CreateMaintenancePlan(c *gin.Context) {
+	request := new(dto.MaintenancePlanInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[maintenanceHandler][CreateMaintenancePlan] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateMaintenancePlan(c.Request.Context(), c.Param("id"), request))
+}
+
+// GetMaintenancePlans returns the maintenance plans of an asset
+//
+//	@Summary      Get maintenance plans
+//	@Description  Returns the preventive maintenance plans of an asset.
+//	@Tags         maintenance
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Success      200    {object}  dto.BaseResponse{data=[]dto.MaintenancePlanOutputDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/maintenance-plans [get]
+func (h *maintenanceHandler) GetMaintenancePlans(c *gin.Context) {
+	c.JSON(h.service.GetMaintenancePlans(c.Request.Context(), c.Param("id")))
+}
+
+// DeleteMaintenancePlan deletes a maintenance plan
+//
+//	@Summary      Delete a maintenance plan
+//	@Description  Deletes a preventive maintenance plan, its maintenance records are kept.
+//	@Tags         maintenance
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Maintenance plan ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /maintenance-plans/{id} [delete]
+func (h *maintenanceHandler) DeleteMaintenancePlan(c *gin.Context) {
+	c.JSON(h.service.DeleteMaintenancePlan(c.Request.Context(), c.Param("id")))
+}
+
+// GetDueMaintenance returns the maintenance due across all assets
+//
+//	@Summary      Get due maintenance
+//	@Description  Lists the maintenance plans of all in-service assets that are overdue or due within the window. Usage plans are listed once the asset reached the due units.
+//	@Tags         maintenance
+//	@Accept       json
+//	@Produce      json
+//	@Param        within   query      string  false  "Window such as 30d or 4w, defaults to 30d"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.MaintenanceDueDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /maintenance/due [get]
+func (h *maintenanceHandler) GetDueMaintenance(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[maintenanceHandler][GetDueMaintenance] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetDueMaintenance(c.Request.Context(), c.Query("within"), pagination))
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandlerInterface interface {
+	GetRoles(c *gin.Context)
+	GetRoleAssignments(c *gin.Context)
+	AssignRole(c *gin.Context)
+	RevokeRole(c *gin.Context)
+}
+
+type roleHandler struct {
+	service services.RoleServiceInterface
+}
+
+func NewRoleHandler(service services.RoleServiceInterface) RoleHandlerInterface {
+	return &roleHandler{service: service}
+}
+
+// GetRoles returns the roles and their permissions
+//
+//	@Summary      List roles
+//	@Description  Returns the roles that can be assigned (viewer, editor, finance, admin) with the actions each allows.
+//	@Tags         roles
+//	@Accept       json
+//	@Produce      json
+//	@Success      200    {object}  dto.BaseResponse{data=[]dto.RoleOutputDto}
+//	@Router       /roles [get]
+func (h *roleHandler) GetRoles(c *gin.Context) {
+	c.JSON(h.service.GetRoles(c.Request.Context()))
+}
+
+// GetRoleAssignments returns the role assignments
+//
+//	@Summary      List role assignments
+//	@Description  Returns the roles assigned to token subjects, ordered by subject.
+//	@Tags         roles
+//	@Accept       json
+//	@Produce      json
+//	@Param        subject   query      string  false  "Token subject"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.RoleAssignmentOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /roles/assignments [get]
+func (h *roleHandler) GetRoleAssignments(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[roleHandler][GetRoleAssignments] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetRoleAssignments(c.Request.Context(), c.Query("subject"), pagination))
+}
+
+// AssignRole gives a role to a token subject
+//
+//	@Summary      Assign a role
+//	@Description  Gives a role to the subject (sub claim) of a token. A subject can hold several roles.
+//	@Tags         roles
+//	@Accept       json
+//	@Produce      json
+//	@Param        assignment  body      dto.RoleAssignmentInputDto  true  "Role assignment JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.RoleAssignmentOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /roles/assignments [post]
+func (h *roleHandler) AssignRole(c *gin.Context) {
+	request := new(dto.RoleAssignmentInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[roleHandler][AssignRole] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.AssignRole(c.Request.Context(), request))
+}
+
+// RevokeRole removes a role assignment
+//
+//	@Summary      Revoke a role
+//	@Description  Removes a role assignment, the subject loses the role on its next request.
+//	@Tags         roles
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Role assignment ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /roles/assignments/{id} [delete]
+func (h *roleHandler) RevokeRole(c *gin.Context) {
+	c.JSON(h.service.RevokeRole(c.Request.Context(), c.Param("id")))
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WarrantyHandlerInterface interface {
+	CreateWarranty(c *gin.Context)
+	GetWarranties(c *gin.Context)
+	GetWarrantyById(c *gin.Context)
+	UpdateWarranty(c *gin.Context)
+	DeleteWarranty(c *gin.Context)
+	GetExpiringWarranties(c *gin.Context)
+}
+
+type warrantyHandler struct {
+	service services.WarrantyServiceInterface
+}
+
+func NewWarrantyHandler(service services.WarrantyServiceInterface) WarrantyHandlerInterface {
+	return &warrantyHandler{service: service}
+}
+
+// CreateWarranty adds a warranty or support contract to an asset
+//
+//	@Summary      Create a warranty
+//	@Description  Adds a warranty or support contract (kind warranty or contract) to an asset. Dates as YYYY-MM-DD.
+//	@Tags         warranties
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Param        warranty  body      dto.WarrantyInputDto  true  "Warranty JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.WarrantyOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/warranties [post]
+func (h *warrantyHandler) CreateWarranty(c *gin.Context) {
+	request := new(dto.WarrantyInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[warrantyHandler][CreateWarranty] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateWarranty(c.Request.Context(), c.Param("id"), request))
+}
+
+// GetWarranties returns the warranties of an asset
+//
+//	@Summary      Get asset warranties
+//	@Description  Returns the warranties and support contracts of an asset, latest ending first.
+//	@Tags         warranties
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Asset ID"
+//	@Success      200    {object}  dto.BaseResponse{data=[]dto.WarrantyOutputDto}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /assets/{id}/warranties [get]
+func (h *warrantyHandler) GetWarranties(c *gin.Context) {
+	c.JSON(h.service.GetWarranties(c.Request.Context(), c.Param("id")))
+}
+
+// GetWarrantyById returns a warranty
+//
+//	@Summary      Get a warranty
+//	@Description  Returns a warranty or support contract by ID.
+//	@Tags         warranties
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Warranty ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.WarrantyOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /warranties/{id} [get]
+func (h *warrantyHandler) GetWarrantyById(c *gin.Context) {
+	c.JSON(h.service.GetWarrantyById(c.Request.Context(), c.Param("id")))
+}
+
+// UpdateWarranty updates a warranty
+//
+//	@Summary      Update a warranty
+//	@Description  Updates a warranty or support contract. A new end date re-arms its expiry event.
+//	@Tags         warranties
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Warranty ID"
+//	@Param        warranty  body      dto.WarrantyInputDto  true  "Warranty JSON"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.WarrantyOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /warranties/{id} [put]
+func (h *warrantyHandler) UpdateWarranty(c *gin.Context) {
+	request := new(dto.WarrantyInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[warrantyHandler][UpdateWarranty] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.UpdateWarranty(c.Request.Context(), c.Param("id"), request))
+}
+
+// DeleteWarranty deletes a warranty
+//
+//	@Summary      Delete a warranty
+//	@Description  Deletes a warranty or support contract.
+//	@Tags         warranties
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Warranty ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /warranties/{id} [delete]
+func (h *warrantyHandler) DeleteWarranty(c *gin.Context) {
+	c.JSON(h.service.DeleteWarranty(c.Request.Context(), c.Param("id")))
+}
+
+// GetExpiringWarranties returns the warranties ending soon
+//
+//	@Summary      Get expiring warranties
+//	@Description  Lists the warranties and support contracts ending from today up to the before date, soonest first.
+//	@Tags         warranties
+//	@Accept       json
+//	@Produce      json
+//	@Param        before   query      string  false  "Last end date (YYYY-MM-DD), defaults to 90 days from today"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.WarrantyOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /warranties/expiring [get]
+func (h *warrantyHandler) GetExpiringWarranties(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[warrantyHandler][GetExpiringWarranties] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetExpiringWarranties(c.Request.Context(), c.Query("before"), pagination))
+}
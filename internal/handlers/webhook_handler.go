@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/services"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandlerInterface interface {
+	CreateWebhook(c *gin.Context)
+	GetWebhooks(c *gin.Context)
+	GetWebhookById(c *gin.Context)
+	UpdateWebhook(c *gin.Context)
+	DeleteWebhook(c *gin.Context)
+	GetWebhookDeliveries(c *gin.Context)
+	GetWebhookDeliveryById(c *gin.Context)
+	RedeliverWebhookDelivery(c *gin.Context)
+}
+
+type webhookHandler struct {
+	service services.WebhookServiceInterface
+}
+
+func NewWebhookHandler(service services.WebhookServiceInterface) WebhookHandlerInterface {
+	return &webhookHandler{service: service}
+}
+
+// CreateWebhook creates a webhook
+//
+//	@Summary      Create a webhook
+//	@Description  Subscribes a URL to asset events: asset.created, asset.updated, asset.deleted, asset.restored, asset.purged and asset.status_changed, or * for all. Deliveries are signed in the X-Webhook-Signature header as sha256=HMAC-SHA256(secret, "<X-Webhook-Timestamp>.<body>"). The secret is only returned once.
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        webhook  body      dto.WebhookInputDto  true  "Webhook JSON"
+//	@Success      201    {object}  dto.BaseResponse{data=dto.WebhookSecretOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /webhooks [post]
+func (h *webhookHandler) CreateWebhook(c *gin.Context) {
+	request := new(dto.WebhookInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[webhookHandler][CreateWebhook] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.CreateWebhook(c.Request.Context(), request))
+}
+
+// GetWebhooks returns the webhooks
+//
+//	@Summary      List webhooks
+//	@Description  Returns the webhooks, newest first. Secrets are never returned.
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.WebhookOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /webhooks [get]
+func (h *webhookHandler) GetWebhooks(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[webhookHandler][GetWebhooks] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetWebhooks(c.Request.Context(), pagination))
+}
+
+// GetWebhookById returns a webhook
+//
+//	@Summary      Get a webhook
+//	@Description  Returns a webhook by its ID
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Webhook ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.WebhookOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /webhooks/{id} [get]
+func (h *webhookHandler) GetWebhookById(c *gin.Context) {
+	c.JSON(h.service.GetWebhookById(c.Request.Context(), c.Param("id")))
+}
+
+// UpdateWebhook updates a webhook
+//
+//	@Summary      Update a webhook
+//	@Description  Replaces the URL, events and description of a webhook. An empty secret keeps the current one, active can pause the deliveries.
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Webhook ID"
+//	@Param        webhook  body      dto.WebhookInputDto  true  "Webhook JSON"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.WebhookOutputDto}
+//	@Failure      400    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /webhooks/{id} [put]
+func (h *webhookHandler) UpdateWebhook(c *gin.Context) {
+	request := new(dto.WebhookInputDto)
+	if err := c.ShouldBindJSON(request); err != nil {
+		log.Println("[webhookHandler][UpdateWebhook] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.UpdateWebhook(c.Request.Context(), c.Param("id"), request))
+}
+
+// DeleteWebhook deletes a webhook
+//
+//	@Summary      Delete a webhook
+//	@Description  Deletes a webhook, its pending deliveries are given up
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Webhook ID"
+//	@Success      200    {object}  dto.BaseResponse{data=nil}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /webhooks/{id} [delete]
+func (h *webhookHandler) DeleteWebhook(c *gin.Context) {
+	c.JSON(h.service.DeleteWebhook(c.Request.Context(), c.Param("id")))
+}
+
+// GetWebhookDeliveries returns the deliveries of a webhook
+//
+//	@Summary      List webhook deliveries
+//	@Description  Returns the deliveries of a webhook, newest first
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Webhook ID"
+//	@Param        page   query      int  false  "Page number"
+//	@Param        limit   query      int  false  "Limit number"
+//	@Success      200    {object}  dto.MetaPagination{data=[]dto.WebhookDeliveryOutputDto}
+//	@Failure      400    {object}  dto.MetaPagination{data=nil}
+//	@Failure      500    {object}  dto.MetaPagination{data=nil}
+//	@Router       /webhooks/{id}/deliveries [get]
+func (h *webhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	pagination, err := bindPagination(c)
+	if err != nil {
+		log.Println("[webhookHandler][GetWebhookDeliveries] error binding request :", err)
+		c.JSON(http.StatusBadRequest, dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "invalid request",
+		})
+		return
+	}
+
+	c.JSON(h.service.GetWebhookDeliveries(c.Request.Context(), c.Param("id"), pagination))
+}
+
+// GetWebhookDeliveryById returns a webhook delivery
+//
+//	@Summary      Get a webhook delivery
+//	@Description  Returns a delivery with the status code, response and error of each attempt
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Delivery ID"
+//	@Success      200    {object}  dto.BaseResponse{data=dto.WebhookDeliveryOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /webhooks/deliveries/{id} [get]
+func (h *webhookHandler) GetWebhookDeliveryById(c *gin.Context) {
+	c.JSON(h.service.GetWebhookDeliveryById(c.Request.Context(), c.Param("id")))
+}
+
+// RedeliverWebhookDelivery sends a delivery again
+//
+//	@Summary      Redeliver a webhook delivery
+//	@Description  Schedules a succeeded or failed delivery to be sent again right away, with the same event and a new set of retries
+//	@Tags         webhooks
+//	@Accept       json
+//	@Produce      json
+//	@Param        id   path      string  true  "Delivery ID"
+//	@Success      202    {object}  dto.BaseResponse{data=dto.WebhookDeliveryOutputDto}
+//	@Failure      404    {object}  dto.BaseResponse{data=nil}
+//	@Failure      409    {object}  dto.BaseResponse{data=nil}
+//	@Failure      500    {object}  dto.BaseResponse{data=nil}
+//	@Router       /webhooks/deliveries/{id}/redeliver [post]
+func (h *webhookHandler) RedeliverWebhookDelivery(c *gin.Context) {
+	c.JSON(h.service.RedeliverWebhookDelivery(c.Request.Context(), c.Param("id")))
+}
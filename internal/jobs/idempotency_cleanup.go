@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"assets-api-go/internal/services"
+	"assets-api-go/internal/tenancy"
+	"context"
+	"log"
+	"time"
+)
+
+const idempotencyCleanupInterval = time.Hour
+
+// StartIdempotencyCleanup periodically removes the Idempotency-Keys older than their TTL
+func StartIdempotencyCleanup(service services.IdempotencyServiceInterface) {
+	// expired keys of every tenant are removed
+	ctx := tenancy.WithoutTenant(context.Background())
+	go func() {
+		ticker := time.NewTicker(idempotencyCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := service.PurgeExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				log.Println("[jobs][StartIdempotencyCleanup] error purge expired idempotency keys :", err)
+			} else if purged > 0 {
+				log.Println("[jobs][StartIdempotencyCleanup] purged expired idempotency keys :", purged)
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/services"
+	"assets-api-go/internal/tenancy"
+	"context"
+	"log"
+	"time"
+)
+
+const trashPurgeInterval = time.Hour
+
+// StartTrashPurge periodically removes assets that have been in the trash longer than retentionDays.
+// A retention of zero or less disables the job.
+func StartTrashPurge(service services.AssetServiceInterface, retentionDays int) {
+	if retentionDays <= 0 {
+		log.Println("[jobs][StartTrashPurge] trash retention is disabled")
+		return
+	}
+
+	// the expired assets of every tenant are found, each is purged in its own tenant
+	ctx := tenancy.WithoutTenant(common.WithActor(context.Background(), common.SystemActor))
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := service.PurgeExpiredAssets(ctx, retentionDays)
+			if err != nil {
+				log.Println("[jobs][StartTrashPurge] error purge expired assets :", err)
+			} else if purged > 0 {
+				log.Println("[jobs][StartTrashPurge] purged expired assets :", purged)
+			}
+			<-ticker.C
+		}
+	}()
+}
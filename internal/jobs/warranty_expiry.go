@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/services"
+	"assets-api-go/internal/tenancy"
+	"context"
+	"log"
+	"time"
+)
+
+const warrantyExpiryInterval = time.Hour
+
+// StartWarrantyExpiryCheck periodically emits an expiry event for warranties ending within
+// noticeDays. A notice of zero or less disables the job.
+func StartWarrantyExpiryCheck(service services.WarrantyServiceInterface, noticeDays int) {
+	if noticeDays <= 0 {
+		log.Println("[jobs][StartWarrantyExpiryCheck] warranty expiry check is disabled")
+		return
+	}
+
+	// warranties of every tenant are checked
+	ctx := tenancy.WithoutTenant(common.WithActor(context.Background(), common.SystemActor))
+	go func() {
+		ticker := time.NewTicker(warrantyExpiryInterval)
+		defer ticker.Stop()
+
+		for {
+			notified, err := service.NotifyExpiringWarranties(ctx, noticeDays)
+			if err != nil {
+				log.Println("[jobs][StartWarrantyExpiryCheck] error notify expiring warranties :", err)
+			} else if notified > 0 {
+				log.Println("[jobs][StartWarrantyExpiryCheck] notified expiring warranties :", notified)
+			}
+			<-ticker.C
+		}
+	}()
+}
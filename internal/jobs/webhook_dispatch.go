@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/services"
+	"assets-api-go/internal/tenancy"
+	"context"
+	"log"
+	"time"
+)
+
+// StartWebhookDispatch periodically fans out the committed asset events to the webhooks and
+// attempts the deliveries that are due. An interval of zero or less disables the job.
+func StartWebhookDispatch(service services.WebhookServiceInterface, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("[jobs][StartWebhookDispatch] webhook dispatch is disabled")
+		return
+	}
+
+	// the outbox and deliveries of every tenant are processed, each in its own tenant
+	ctx := tenancy.WithoutTenant(common.WithActor(context.Background(), common.SystemActor))
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			dispatched, err := service.DispatchOutboxEvents(ctx)
+			if err != nil {
+				log.Println("[jobs][StartWebhookDispatch] error dispatch outbox events :", err)
+			} else if dispatched > 0 {
+				log.Println("[jobs][StartWebhookDispatch] dispatched outbox events :", dispatched)
+			}
+
+			attempted, err := service.DeliverDueWebhooks(ctx)
+			if err != nil {
+				log.Println("[jobs][StartWebhookDispatch] error deliver webhooks :", err)
+			} else if attempted > 0 {
+				log.Println("[jobs][StartWebhookDispatch] attempted webhook deliveries :", attempted)
+			}
+			<-ticker.C
+		}
+	}()
+}
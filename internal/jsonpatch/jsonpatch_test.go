@@ -0,0 +1,81 @@
+package jsonpatch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		patch  string
+		expect string
+	}{
+		{"Replace a member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Add a member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"Remove a member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"Replace an array", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"Nested objects", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"f","d":null}}`, `{"a":{"b":"f"}}`},
+		{"Object over a scalar", `{"a":"b"}`, `{"a":{"c":null,"d":1}}`, `{"a":{"d":1}}`},
+		{"Non object patch", `{"a":"b"}`, `["c"]`, `["c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expect, string(result))
+		})
+	}
+
+	_, err := MergePatch([]byte(`{}`), []byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         string
+		patch       string
+		expect      string
+		expectError error
+	}{
+		{"Add a member", `{"a":1}`, `[{"op":"add","path":"/b","value":2}]`, `{"a":1,"b":2}`, nil},
+		{"Add to an array", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2},{"op":"add","path":"/a/-","value":4}]`, `{"a":[1,2,3,4]}`, nil},
+		{"Remove", `{"a":[1,2,3],"b":1}`, `[{"op":"remove","path":"/a/0"},{"op":"remove","path":"/b"}]`, `{"a":[2,3]}`, nil},
+		{"Replace", `{"a":{"b":[1,2]}}`, `[{"op":"replace","path":"/a/b/1","value":5}]`, `{"a":{"b":[1,5]}}`, nil},
+		{"Move and copy", `{"a":{"b":1},"c":[]}`, `[{"op":"move","from":"/a/b","path":"/d"},{"op":"copy","from":"/d","path":"/c/0"}]`, `{"a":{},"c":[1],"d":1}`, nil},
+		{"Escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`, nil},
+		{"Test passes", `{"a":{"b":[1,"c"]}}`, `[{"op":"test","path":"/a","value":{"b":[1,"c"]}},{"op":"replace","path":"/a","value":null}]`, `{"a":null}`, nil},
+		{"Test fails", `{"a":1}`, `[{"op":"test","path":"/a","value":2}]`, ``, ErrTestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if tt.expectError != nil {
+				assert.True(t, errors.Is(err, tt.expectError))
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expect, string(result))
+		})
+	}
+
+	for _, patch := range []string{
+		`{"op":"add"}`,
+		`[{"op":"add","path":"/a"}]`,
+		`[{"op":"remove","path":"/missing"}]`,
+		`[{"op":"replace","path":"/missing","value":1}]`,
+		`[{"op":"add","path":"/a/5","value":1}]`,
+		`[{"op":"add","path":"a","value":1}]`,
+		`[{"op":"move","from":"/a","path":"/a/0"}]`,
+		`[{"op":"frobnicate","path":"/a"}]`,
+	} {
+		_, err := Apply([]byte(`{"a":[]}`), []byte(patch))
+		assert.Error(t, err, patch)
+	}
+}
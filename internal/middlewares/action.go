@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Actions serves the custom methods of a resource, such as POST /assets:batchCreate. gin reads
+// the colon as the start of a path parameter, so the route is registered as /assets:action and
+// the value of action, colon included, picks the handlers that run. It must be the last
+// handler of the route.
+func Actions(actions map[string]gin.HandlersChain) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		handlers, ok := actions[c.Param("action")]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, dto.BaseResponse{
+				Error:            common.NotFound,
+				ErrorDescription: "Unknown action " + c.Param("action"),
+			})
+			return
+		}
+
+		for _, handler := range handlers {
+			handler(c)
+			if c.IsAborted() {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"assets-api-go/internal/auth"
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrincipalKey is the gin context key of the authenticated *common.Principal
+const PrincipalKey = "principal"
+
+// ApiKeyHeader carries an API key in place of a bearer token
+const ApiKeyHeader = "X-API-Key"
+
+// ApiKeyAuthenticator returns the principal of an API key
+type ApiKeyAuthenticator interface {
+	AuthenticateApiKey(ctx context.Context, key string) (*common.Principal, error)
+}
+
+// Authenticate requires a valid bearer token or API key on every path except the public
+// ones. The principal is stored in the gin context and the request context, and its
+// subject becomes the actor recorded in the audit trail in place of the X-Actor header.
+func Authenticate(verifier *auth.Verifier, apiKeys ApiKeyAuthenticator, publicPaths []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isPublicPath(c.Request.URL.Path, publicPaths) {
+			c.Next()
+			return
+		}
+
+		if key := c.GetHeader(ApiKeyHeader); key != "" {
+			principal, err := apiKeys.AuthenticateApiKey(c.Request.Context(), key)
+			if err != nil {
+				log.Println("[middlewares][Authenticate] error authenticate api key :", err)
+				c.AbortWithStatusJSON(http.StatusUnauthorized, dto.BaseResponse{
+					Error:            common.Unauthorized,
+					ErrorDescription: "Invalid API key",
+				})
+				return
+			}
+			setPrincipal(c, principal)
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.BaseResponse{
+				Error:            common.Unauthorized,
+				ErrorDescription: "Bearer token is required",
+			})
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			log.Println("[middlewares][Authenticate] error verify token :", err)
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.BaseResponse{
+				Error:            common.Unauthorized,
+				ErrorDescription: "Invalid bearer token",
+			})
+			return
+		}
+
+		setPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+func setPrincipal(c *gin.Context, principal *common.Principal) {
+	c.Set(PrincipalKey, principal)
+	ctx := common.WithPrincipal(c.Request.Context(), principal)
+	ctx = common.WithActor(ctx, principal.Subject)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func isPublicPath(path string, publicPaths []string) bool {
+	for _, v := range publicPaths {
+		if prefix, ok := strings.CutSuffix(v, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == v {
+			return true
+		}
+	}
+	return false
+}
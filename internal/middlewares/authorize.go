@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleResolver returns the roles of a token subject
+type RoleResolver interface {
+	ResolveRoles(ctx context.Context, subject string) ([]string, error)
+}
+
+// LoadRoles sets the roles of the authenticated principal, it runs after Authenticate. API
+// keys are authorized by their scopes and get no roles.
+func LoadRoles(resolver RoleResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := common.PrincipalFromContext(c.Request.Context())
+		if principal == nil || principal.ApiKeyId != "" {
+			c.Next()
+			return
+		}
+
+		roles, err := resolver.ResolveRoles(c.Request.Context(), principal.Subject)
+		if err != nil {
+			log.Println("[middlewares][LoadRoles] error resolve roles :", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			})
+			return
+		}
+		principal.Roles = roles
+
+		c.Next()
+	}
+}
+
+// RequirePermission rejects callers without a role or scope allowing the action with 403
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !common.HasPermission(c.Request.Context(), permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.BaseResponse{
+				Error:            common.Forbidden,
+				ErrorDescription: "Missing permission " + permission,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed from an earlier request with the key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// idempotentReplayHeaders are the response headers stored and replayed with the body
+var idempotentReplayHeaders = []string{"Content-Type", "ETag"}
+
+// IdempotencyStore keeps the responses of requests with an Idempotency-Key
+type IdempotencyStore interface {
+	BeginIdempotentRequest(ctx context.Context, key string, fingerprint string) (stored *dto.IdempotentResponseDto, code int, response *dto.BaseResponse)
+	CompleteIdempotentRequest(ctx context.Context, key string, stored *dto.IdempotentResponseDto) error
+	AbortIdempotentRequest(ctx context.Context, key string) error
+}
+
+// Idempotency makes POST, PUT, PATCH and DELETE requests with an Idempotency-Key header safe to
+// retry. The first request with a key runs and its response is stored, a retry with the same
+// method, path and body gets the stored response and a different request with the key gets
+// 422. Server errors and the rejections a caller can clear without changing the request (401,
+// 403 and 429) are not stored, so the retry of such a request runs again. Bodies above
+// maxBodySize get 413 before they are buffered.
+func Idempotency(store IdempotencyStore, maxBodySize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutation(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize))
+		if err != nil {
+			log.Println("[middlewares][Idempotency] error read body :", err)
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, dto.BaseResponse{
+					Error:            common.PayloadTooLarge,
+					ErrorDescription: "request body is too large",
+				})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "invalid request",
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		stored, code, response := store.BeginIdempotentRequest(ctx, key, requestFingerprint(c.Request, body))
+		if response != nil {
+			c.AbortWithStatusJSON(code, response)
+			return
+		}
+		if stored != nil {
+			for k, v := range stored.Header {
+				c.Header(k, v)
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(stored.StatusCode, stored.Header["Content-Type"], stored.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		completed := false
+		// a panicking handler releases the key as well
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.AbortIdempotentRequest(ctx, key); err != nil {
+				log.Println("[middlewares][Idempotency] error abort idempotent request :", err)
+			}
+		}()
+
+		c.Next()
+
+		if !isStoredStatus(writer.Status()) {
+			return
+		}
+		stored = &dto.IdempotentResponseDto{
+			StatusCode: writer.Status(),
+			Header:     map[string]string{},
+			Body:       writer.body.Bytes(),
+		}
+		for _, v := range idempotentReplayHeaders {
+			if value := writer.Header().Get(v); value != "" {
+				stored.Header[v] = value
+			}
+		}
+		if err := store.CompleteIdempotentRequest(ctx, key, stored); err != nil {
+			log.Println("[middlewares][Idempotency] error complete idempotent request :", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// isStoredStatus reports whether a response with the status is replayed to the retries
+func isStoredStatus(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	}
+	return status < http.StatusInternalServerError
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint identifies a request by its method, path, query and body
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter keeps a copy of the response body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
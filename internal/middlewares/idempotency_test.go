@@ -0,0 +1,112 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyStore keeps the idempotent responses in memory, a nil response marks a
+// request still running
+type memoryIdempotencyStore struct {
+	fingerprints map[string]string
+	responses    map[string]*dto.IdempotentResponseDto
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{fingerprints: map[string]string{}, responses: map[string]*dto.IdempotentResponseDto{}}
+}
+
+func (s *memoryIdempotencyStore) BeginIdempotentRequest(ctx context.Context, key string, fingerprint string) (*dto.IdempotentResponseDto, int, *dto.BaseResponse) {
+	if stored, ok := s.fingerprints[key]; ok {
+		if stored != fingerprint {
+			return nil, http.StatusUnprocessableEntity, &dto.BaseResponse{Error: common.UnprocessableEntity}
+		}
+		if s.responses[key] == nil {
+			return nil, http.StatusConflict, &dto.BaseResponse{Error: common.Conflict}
+		}
+		return s.responses[key], 0, nil
+	}
+	s.fingerprints[key] = fingerprint
+	return nil, 0, nil
+}
+
+func (s *memoryIdempotencyStore) CompleteIdempotentRequest(ctx context.Context, key string, stored *dto.IdempotentResponseDto) error {
+	s.responses[key] = stored
+	return nil
+}
+
+func (s *memoryIdempotencyStore) AbortIdempotentRequest(ctx context.Context, key string) error {
+	delete(s.fingerprints, key)
+	delete(s.responses, key)
+	return nil
+}
+
+func TestIdempotencyRetryAfterPermissionGranted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newMemoryIdempotencyStore()
+	roles := []string{common.RoleViewer}
+	created := 0
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		principal := &common.Principal{Subject: "alice", Roles: roles}
+		c.Request = c.Request.WithContext(common.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	})
+	router.Use(Idempotency(store, 1<<20))
+	router.POST("/assets", RequirePermission(common.PermissionCreate), func(c *gin.Context) {
+		created++
+		c.JSON(http.StatusCreated, dto.BaseResponse{Message: common.Success})
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/assets", strings.NewReader(`{"name":"Laptop"}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	res := send()
+	assert.Equal(t, http.StatusForbidden, res.Code)
+	assert.Equal(t, 0, created)
+
+	// the role is fixed and the request retried with the same key
+	roles = []string{common.RoleEditor}
+	res = send()
+	assert.Equal(t, http.StatusCreated, res.Code)
+	assert.Empty(t, res.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, created)
+
+	// the successful response is the one replayed
+	res = send()
+	assert.Equal(t, http.StatusCreated, res.Code)
+	assert.Equal(t, "true", res.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, created)
+}
+
+func TestIsStoredStatus(t *testing.T) {
+	for status, expected := range map[int]bool{
+		http.StatusOK:                  true,
+		http.StatusCreated:             true,
+		http.StatusBadRequest:          true,
+		http.StatusNotFound:            true,
+		http.StatusConflict:            true,
+		http.StatusUnauthorized:        false,
+		http.StatusForbidden:           false,
+		http.StatusTooManyRequests:     false,
+		http.StatusInternalServerError: false,
+		http.StatusServiceUnavailable:  false,
+	} {
+		assert.Equal(t, expected, isStoredStatus(status), http.StatusText(status))
+	}
+}
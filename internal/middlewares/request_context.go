@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"assets-api-go/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	RequestIdHeader = "X-Request-Id"
+	ActorHeader     = "X-Actor"
+)
+
+// RequestContext puts the request ID and the acting user into the request context.
+// A request ID is generated when the client does not send one and is echoed back in the response.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(RequestIdHeader)
+		if requestId == "" {
+			requestId = uuid.New().String()
+		}
+		c.Header(RequestIdHeader, requestId)
+
+		ctx := common.WithRequestId(c.Request.Context(), requestId)
+		if actor := c.GetHeader(ActorHeader); actor != "" {
+			ctx = common.WithActor(ctx, actor)
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// ResolveTenant sets the tenant of the request, which scopes every database statement it
+// runs. A caller belongs to the tenant of its token or API key and the tenant header may only
+// repeat it, multi-tenancy requires authentication so a request without a principal is
+// rejected. When multi-tenancy is disabled every request belongs to common.DefaultTenant. It
+// runs after Authenticate.
+func ResolveTenant(enabled bool, header string, publicPaths []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			c.Request = c.Request.WithContext(common.WithTenant(c.Request.Context(), common.DefaultTenant))
+			c.Next()
+			return
+		}
+		if isPublicPath(c.Request.URL.Path, publicPaths) {
+			c.Next()
+			return
+		}
+
+		principal := common.PrincipalFromContext(c.Request.Context())
+		if principal == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.BaseResponse{
+				Error:            common.Unauthorized,
+				ErrorDescription: "Authentication is required",
+			})
+			return
+		}
+		if principal.TenantId == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.BaseResponse{
+				Error:            common.Forbidden,
+				ErrorDescription: "Credentials are not bound to a tenant",
+			})
+			return
+		}
+		if tenantId := strings.TrimSpace(c.GetHeader(header)); tenantId != "" && tenantId != principal.TenantId {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.BaseResponse{
+				Error:            common.Forbidden,
+				ErrorDescription: "Tenant does not match the credentials",
+			})
+			return
+		}
+		if !tenantPattern.MatchString(principal.TenantId) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Invalid tenant",
+			})
+			return
+		}
+
+		c.Request = c.Request.WithContext(common.WithTenant(c.Request.Context(), principal.TenantId))
+		c.Next()
+	}
+}
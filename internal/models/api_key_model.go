@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApiKey is a credential for machine clients. Only the SHA-256 of the key is stored, the
+// prefix is kept in clear to find the key and to tell keys apart. Scopes is comma separated.
+type ApiKey struct {
+	Id         string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId   string     `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	Name       string     `json:"name" gorm:"type:varchar(255);not null"`
+	Prefix     string     `json:"prefix" gorm:"type:varchar(20);not null;uniqueIndex"`
+	KeyHash    string     `json:"-" gorm:"type:varchar(64);not null"`
+	Scopes     string     `json:"scopes" gorm:"type:varchar(255);not null"`
+	ExpiresAt  *time.Time `json:"expires_at" gorm:"type:timestamp;default:null"`
+	LastUsedAt *time.Time `json:"last_used_at" gorm:"type:timestamp;default:null"`
+	RevokedAt  *time.Time `json:"revoked_at" gorm:"type:timestamp;default:null"`
+	CreatedBy  string     `json:"created_by" gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (a ApiKey) TableName() string {
+	return "api_keys"
+}
+
+func (a *ApiKey) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if a.Id == "" {
+		a.Id = uuid.New().String()
+	}
+	a.CreatedAt = tNow
+	a.UpdatedAt = tNow
+	return
+}
+
+func (a *ApiKey) BeforeUpdate(tx *gorm.DB) (err error) {
+	a.UpdatedAt = time.Now().UTC()
+	return
+}
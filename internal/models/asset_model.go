@@ -8,14 +8,26 @@ import (
 )
 
 type Asset struct {
-	Id              string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
-	Name            string     `json:"name" gorm:"type:varchar(255);not null"`
-	Type            string     `json:"type" gorm:"type:varchar(255);not null"`
-	Value           float64    `json:"value" gorm:"type:float;not null"`
-	AcquisitionDate time.Time  `json:"acquisition_date" gorm:"type:date;not null"`
-	CreatedAt       time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
-	UpdatedAt       time.Time  `json:"updated_at" gorm:"type:timestamp;not null"`
-	DeletedAt       *time.Time `json:"deleted_at" gorm:"type:timestamp;default:null"`
+	Id                     string         `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId               string         `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	Name                   string         `json:"name" gorm:"type:varchar(255);not null"`
+	Type                   string         `json:"type" gorm:"type:varchar(255);not null"`
+	CategoryId             *string        `json:"category_id" gorm:"type:varchar(36);index"`
+	LocationId             *string        `json:"location_id" gorm:"type:varchar(36);index"`
+	Status                 string         `json:"status" gorm:"type:varchar(20);not null;default:'in_stock';index"`
+	Value                  float64        `json:"value" gorm:"type:float;not null"`
+	AcquisitionDate        time.Time      `json:"acquisition_date" gorm:"type:date;not null"`
+	DepreciationMethod     string         `json:"depreciation_method" gorm:"type:varchar(50);not null;default:''"`
+	DepreciationConvention string         `json:"depreciation_convention" gorm:"type:varchar(50);not null;default:''"`
+	UsefulLifeYears        int            `json:"useful_life_years" gorm:"type:int;not null;default:0"`
+	SalvageValue           float64        `json:"salvage_value" gorm:"type:float;not null;default:0"`
+	DecliningFactor        float64        `json:"declining_factor" gorm:"type:float;not null;default:0"`
+	TotalUnits             float64        `json:"total_units" gorm:"type:float;not null;default:0"`
+	UnitsUsed              float64        `json:"units_used" gorm:"type:float;not null;default:0"`
+	Version                int64          `json:"version" gorm:"type:bigint;not null;default:1"`
+	CreatedAt              time.Time      `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt              time.Time      `json:"updated_at" gorm:"type:timestamp;not null"`
+	DeletedAt              gorm.DeletedAt `json:"deleted_at" gorm:"type:timestamp;default:null;index"`
 }
 
 func (a Asset) TableName() string {
@@ -27,6 +39,9 @@ func (l *Asset) BeforeCreate(tx *gorm.DB) (err error) {
 	if l.Id == "" {
 		l.Id = uuid.New().String()
 	}
+	if l.Version == 0 {
+		l.Version = 1
+	}
 	l.CreatedAt = tNow
 	l.UpdatedAt = tNow
 	return
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AssetTransition is one move of an asset through its lifecycle
+type AssetTransition struct {
+	Id             string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId       string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	AssetId        string    `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+	FromStatus     string    `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus       string    `json:"to_status" gorm:"type:varchar(20);not null"`
+	Reason         string    `json:"reason" gorm:"type:text;not null"`
+	TransitionedAt time.Time `json:"transitioned_at" gorm:"type:timestamp;not null;index"`
+	TransitionedBy string    `json:"transitioned_by" gorm:"type:varchar(255);not null"`
+}
+
+func (a AssetTransition) TableName() string {
+	return "asset_transitions"
+}
+
+func (l *AssetTransition) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	if l.TransitionedAt.IsZero() {
+		l.TransitionedAt = time.Now().UTC()
+	}
+	return
+}
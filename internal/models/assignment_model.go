@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Assignment is one check-out of an asset. It stays open until the asset is checked in,
+// and an asset has at most one open assignment.
+type Assignment struct {
+	Id              string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId        string     `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	AssetId         string     `json:"asset_id" gorm:"type:varchar(36);not null;index;uniqueIndex:idx_assignments_open,where:checked_in_at IS NULL"`
+	Assignee        string     `json:"assignee" gorm:"type:varchar(255);not null"`
+	DueDate         time.Time  `json:"due_date" gorm:"type:date;not null;index"`
+	Note            string     `json:"note" gorm:"type:text"`
+	CheckedOutAt    time.Time  `json:"checked_out_at" gorm:"type:timestamp;not null"`
+	CheckedOutBy    string     `json:"checked_out_by" gorm:"type:varchar(255);not null"`
+	CheckedInAt     *time.Time `json:"checked_in_at" gorm:"type:timestamp;default:null"`
+	CheckedInBy     string     `json:"checked_in_by" gorm:"type:varchar(255);not null;default:''"`
+	ReturnCondition string     `json:"return_condition" gorm:"type:varchar(50);not null;default:''"`
+	ReturnNote      string     `json:"return_note" gorm:"type:text"`
+}
+
+func (a Assignment) TableName() string {
+	return "assignments"
+}
+
+func (l *Assignment) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	if l.CheckedOutAt.IsZero() {
+		l.CheckedOutAt = time.Now().UTC()
+	}
+	return
+}
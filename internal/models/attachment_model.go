@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attachment is a file such as an invoice, photo or manual kept with an asset. The content is
+// held by the configured storage under StorageKey, ThumbnailKey is set for images only.
+type Attachment struct {
+	Id           string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId     string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	AssetId      string    `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+	FileName     string    `json:"file_name" gorm:"type:varchar(255);not null"`
+	ContentType  string    `json:"content_type" gorm:"type:varchar(100);not null"`
+	Size         int64     `json:"size" gorm:"not null"`
+	Checksum     string    `json:"checksum" gorm:"type:varchar(64);not null"`
+	StorageKey   string    `json:"storage_key" gorm:"type:varchar(255);not null"`
+	ThumbnailKey string    `json:"thumbnail_key" gorm:"type:varchar(255);not null;default:''"`
+	UploadedBy   string    `json:"uploaded_by" gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+}
+
+func (a Attachment) TableName() string {
+	return "attachments"
+}
+
+func (a *Attachment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.Id == "" {
+		a.Id = uuid.New().String()
+	}
+	a.CreatedAt = time.Now().UTC()
+	return
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditLog struct {
+	Id         string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId   string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	EntityType string    `json:"entity_type" gorm:"type:varchar(50);not null;index:idx_audit_logs_entity"`
+	EntityId   string    `json:"entity_id" gorm:"type:varchar(36);not null;index:idx_audit_logs_entity"`
+	Action     string    `json:"action" gorm:"type:varchar(50);not null"`
+	Actor      string    `json:"actor" gorm:"type:varchar(255);not null"`
+	RequestId  string    `json:"request_id" gorm:"type:varchar(100);not null;default:''"`
+	Changes    string    `json:"changes" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp;not null;index"`
+}
+
+func (a AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (l *AuditLog) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now().UTC()
+	}
+	return
+}
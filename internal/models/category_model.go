@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Category struct {
+	Id                     string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId               string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	ParentId               *string   `json:"parent_id" gorm:"type:varchar(36);index"`
+	Name                   string    `json:"name" gorm:"type:varchar(255);not null"`
+	Code                   string    `json:"code" gorm:"type:varchar(50);not null;default:''"`
+	Icon                   string    `json:"icon" gorm:"type:varchar(255);not null;default:''"`
+	DepreciationMethod     string    `json:"depreciation_method" gorm:"type:varchar(50);not null;default:''"`
+	DepreciationConvention string    `json:"depreciation_convention" gorm:"type:varchar(50);not null;default:''"`
+	UsefulLifeYears        int       `json:"useful_life_years" gorm:"type:int;not null;default:0"`
+	DecliningFactor        float64   `json:"declining_factor" gorm:"type:float;not null;default:0"`
+	CreatedAt              time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt              time.Time `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (c Category) TableName() string {
+	return "categories"
+}
+
+func (l *Category) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	l.CreatedAt = tNow
+	l.UpdatedAt = tNow
+	return
+}
+
+func (l *Category) BeforeUpdate(tx *gorm.DB) (err error) {
+	l.UpdatedAt = time.Now().UTC()
+	return
+}
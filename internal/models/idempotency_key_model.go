@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey is the Idempotency-Key of a mutating request with the response it got, so a
+// retry with the same key gets the stored response instead of running again. StatusCode is
+// 0 while the first request is still running.
+type IdempotencyKey struct {
+	Id          string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId    string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';uniqueIndex:idx_idempotency_keys_tenant_actor_key"`
+	Actor       string    `json:"actor" gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_tenant_actor_key"`
+	Key         string    `json:"key" gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_tenant_actor_key"`
+	Fingerprint string    `json:"fingerprint" gorm:"type:varchar(64);not null"`
+	StatusCode  int       `json:"status_code" gorm:"type:int;not null;default:0"`
+	Header      string    `json:"header" gorm:"type:text;not null;default:''"`
+	Body        string    `json:"body" gorm:"type:text;not null;default:''"`
+	CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"type:timestamp;not null;index"`
+}
+
+func (i IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+func (i *IdempotencyKey) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.Id == "" {
+		i.Id = uuid.New().String()
+	}
+	i.CreatedAt = time.Now().UTC()
+	return
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Location struct {
+	Id        string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId  string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	ParentId  *string   `json:"parent_id" gorm:"type:varchar(36);index"`
+	Name      string    `json:"name" gorm:"type:varchar(255);not null"`
+	Kind      string    `json:"kind" gorm:"type:varchar(20);not null"`
+	Latitude  *float64  `json:"latitude" gorm:"type:float"`
+	Longitude *float64  `json:"longitude" gorm:"type:float"`
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (l Location) TableName() string {
+	return "locations"
+}
+
+func (l *Location) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	l.CreatedAt = tNow
+	l.UpdatedAt = tNow
+	return
+}
+
+func (l *Location) BeforeUpdate(tx *gorm.DB) (err error) {
+	l.UpdatedAt = time.Now().UTC()
+	return
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaintenanceRecord is a piece of maintenance work done on an asset, optionally as part of a
+// preventive maintenance plan
+type MaintenanceRecord struct {
+	Id            string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId      string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	AssetId       string    `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+	PlanId        *string   `json:"plan_id" gorm:"type:varchar(36);index"`
+	PerformedAt   time.Time `json:"performed_at" gorm:"type:date;not null;index"`
+	Vendor        string    `json:"vendor" gorm:"type:varchar(255);not null;default:''"`
+	Cost          float64   `json:"cost" gorm:"type:float;not null;default:0"`
+	Description   string    `json:"description" gorm:"type:text;not null"`
+	DowntimeHours float64   `json:"downtime_hours" gorm:"type:float;not null;default:0"`
+	CreatedBy     string    `json:"created_by" gorm:"type:varchar(255);not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+}
+
+func (m MaintenanceRecord) TableName() string {
+	return "maintenance_records"
+}
+
+func (l *MaintenanceRecord) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	l.CreatedAt = time.Now().UTC()
+	return
+}
+
+// MaintenancePlan is a recurring preventive maintenance of an asset. Calendar plans are due
+// on NextDueDate, usage plans once the asset units used reach NextDueUnits.
+type MaintenancePlan struct {
+	Id                 string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId           string     `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	AssetId            string     `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+	Name               string     `json:"name" gorm:"type:varchar(255);not null"`
+	Kind               string     `json:"kind" gorm:"type:varchar(20);not null"`
+	IntervalDays       int        `json:"interval_days" gorm:"type:int;not null;default:0"`
+	IntervalUnits      float64    `json:"interval_units" gorm:"type:float;not null;default:0"`
+	NextDueDate        *time.Time `json:"next_due_date" gorm:"type:date;index"`
+	NextDueUnits       float64    `json:"next_due_units" gorm:"type:float;not null;default:0"`
+	LastPerformedAt    *time.Time `json:"last_performed_at" gorm:"type:date"`
+	LastPerformedUnits float64    `json:"last_performed_units" gorm:"type:float;not null;default:0"`
+	CreatedAt          time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt          time.Time  `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (m MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
+
+func (l *MaintenancePlan) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	l.CreatedAt = tNow
+	l.UpdatedAt = tNow
+	return
+}
+
+func (l *MaintenancePlan) BeforeUpdate(tx *gorm.DB) (err error) {
+	l.UpdatedAt = time.Now().UTC()
+	return
+}
+
+// MaintenanceDue is a row of the due maintenance listing, a plan joined with its asset
+type MaintenanceDue struct {
+	MaintenancePlan
+	AssetName      string  `json:"asset_name"`
+	AssetUnitsUsed float64 `json:"asset_units_used"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is an event written in the transaction of the change it describes. It is fanned
+// out to the webhook subscriptions after the commit, so a rolled back change never sends one.
+// Payload is the JSON body delivered to the webhooks.
+type OutboxEvent struct {
+	Id           string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId     string     `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	Type         string     `json:"type" gorm:"type:varchar(50);not null"`
+	Payload      string     `json:"payload" gorm:"type:text;not null"`
+	OccurredAt   time.Time  `json:"occurred_at" gorm:"type:timestamp;not null"`
+	DispatchedAt *time.Time `json:"dispatched_at" gorm:"type:timestamp;default:null;index"`
+}
+
+func (o OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+func (o *OutboxEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.Id == "" {
+		o.Id = uuid.New().String()
+	}
+	return
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoleAssignment grants a role to the subject of a token
+type RoleAssignment struct {
+	Id         string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId   string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';uniqueIndex:idx_role_assignments_tenant_subject_role"`
+	Subject    string    `json:"subject" gorm:"type:varchar(255);not null;uniqueIndex:idx_role_assignments_tenant_subject_role"`
+	Role       string    `json:"role" gorm:"type:varchar(20);not null;uniqueIndex:idx_role_assignments_tenant_subject_role"`
+	AssignedBy string    `json:"assigned_by" gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+}
+
+func (r RoleAssignment) TableName() string {
+	return "role_assignments"
+}
+
+func (r *RoleAssignment) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.Id == "" {
+		r.Id = uuid.New().String()
+	}
+	r.CreatedAt = time.Now().UTC()
+	return
+}
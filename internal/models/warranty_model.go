@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Warranty is a warranty or support contract covering an asset. ExpiryNotifiedAt is set once
+// the expiry event has been emitted and cleared when the end date changes.
+type Warranty struct {
+	Id               string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId         string     `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	AssetId          string     `json:"asset_id" gorm:"type:varchar(36);not null;index"`
+	Kind             string     `json:"kind" gorm:"type:varchar(20);not null"`
+	Provider         string     `json:"provider" gorm:"type:varchar(255);not null"`
+	CoverageType     string     `json:"coverage_type" gorm:"type:varchar(100);not null;default:''"`
+	ContractNumber   string     `json:"contract_number" gorm:"type:varchar(100);not null;default:''"`
+	StartDate        time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate          time.Time  `json:"end_date" gorm:"type:date;not null;index"`
+	Cost             float64    `json:"cost" gorm:"type:float;not null;default:0"`
+	ExpiryNotifiedAt *time.Time `json:"expiry_notified_at" gorm:"type:timestamp;default:null"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (w Warranty) TableName() string {
+	return "warranties"
+}
+
+func (l *Warranty) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if l.Id == "" {
+		l.Id = uuid.New().String()
+	}
+	l.CreatedAt = tNow
+	l.UpdatedAt = tNow
+	return
+}
+
+func (l *Warranty) BeforeUpdate(tx *gorm.DB) (err error) {
+	l.UpdatedAt = time.Now().UTC()
+	return
+}
+
+// WarrantyExpiry is a row of the expiring warranties listing, a warranty joined with its asset
+type WarrantyExpiry struct {
+	Warranty
+	AssetName string `json:"asset_name"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook is a subscription posting asset events to a URL. Events is comma separated, * stands
+// for every event. Secret signs the deliveries, it is kept in clear as the signature needs it.
+type Webhook struct {
+	Id          string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId    string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	Url         string    `json:"url" gorm:"type:varchar(2048);not null"`
+	Events      string    `json:"events" gorm:"type:varchar(255);not null"`
+	Secret      string    `json:"-" gorm:"type:varchar(255);not null"`
+	Description string    `json:"description" gorm:"type:varchar(255);not null;default:''"`
+	Active      bool      `json:"active" gorm:"not null"`
+	CreatedBy   string    `json:"created_by" gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt   time.Time `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (w Webhook) TableName() string {
+	return "webhooks"
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if w.Id == "" {
+		w.Id = uuid.New().String()
+	}
+	w.CreatedAt = tNow
+	w.UpdatedAt = tNow
+	return
+}
+
+func (w *Webhook) BeforeUpdate(tx *gorm.DB) (err error) {
+	w.UpdatedAt = time.Now().UTC()
+	return
+}
+
+// WebhookDelivery is an event to deliver to one webhook. A pending delivery is attempted once
+// NextAttemptAt has passed, Attempts counts the attempts since it was last (re)scheduled.
+type WebhookDelivery struct {
+	Id            string     `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId      string     `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	WebhookId     string     `json:"webhook_id" gorm:"type:varchar(36);not null;index"`
+	EventId       string     `json:"event_id" gorm:"type:varchar(36);not null;index"`
+	EventType     string     `json:"event_type" gorm:"type:varchar(50);not null"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due"`
+	Attempts      int        `json:"attempts" gorm:"type:int;not null;default:0"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" gorm:"type:timestamp;default:null;index:idx_webhook_deliveries_due"`
+	LastError     string     `json:"last_error" gorm:"type:text;not null;default:''"`
+	DeliveredAt   *time.Time `json:"delivered_at" gorm:"type:timestamp;default:null"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"type:timestamp;not null"`
+}
+
+func (w WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (w *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	tNow := time.Now().UTC()
+	if w.Id == "" {
+		w.Id = uuid.New().String()
+	}
+	w.CreatedAt = tNow
+	w.UpdatedAt = tNow
+	return
+}
+
+func (w *WebhookDelivery) BeforeUpdate(tx *gorm.DB) (err error) {
+	w.UpdatedAt = time.Now().UTC()
+	return
+}
+
+// WebhookAttempt records one request of a delivery, StatusCode is 0 when no response came
+type WebhookAttempt struct {
+	Id           string    `json:"id" gorm:"primaryKey;type:varchar(36);not null"`
+	TenantId     string    `json:"tenant_id" gorm:"type:varchar(64);not null;default:'default';index"`
+	DeliveryId   string    `json:"delivery_id" gorm:"type:varchar(36);not null;index"`
+	StatusCode   int       `json:"status_code" gorm:"type:int;not null;default:0"`
+	ResponseBody string    `json:"response_body" gorm:"type:text;not null;default:''"`
+	Error        string    `json:"error" gorm:"type:text;not null;default:''"`
+	DurationMs   int64     `json:"duration_ms" gorm:"not null;default:0"`
+	AttemptedAt  time.Time `json:"attempted_at" gorm:"type:timestamp;not null;index"`
+}
+
+func (w WebhookAttempt) TableName() string {
+	return "webhook_attempts"
+}
+
+func (w *WebhookAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.Id == "" {
+		w.Id = uuid.New().String()
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateApiKey(ctx context.Context, apiKey *models.ApiKey, tx *gorm.DB) (*models.ApiKey, error)
+	GetApiKeyByAttribute(ctx context.Context, whereClause interface{}) (*models.ApiKey, error)
+	GetApiKeys(ctx context.Context, pagination *dto.MetaPagination) ([]*models.ApiKey, int64, error)
+	UpdateApiKey(ctx context.Context, apiKey *models.ApiKey, tx *gorm.DB) (*models.ApiKey, error)
+	TouchApiKey(ctx context.Context, id string, usedAt time.Time, interval time.Duration) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepositoryInterface {
+	return &apiKeyRepository{db}
+}
+
+func (repo *apiKeyRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *apiKeyRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *apiKeyRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *apiKeyRepository) CreateApiKey(ctx context.Context, apiKey *models.ApiKey, tx *gorm.DB) (*models.ApiKey, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(apiKey).Error; err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (r *apiKeyRepository) GetApiKeyByAttribute(ctx context.Context, whereClause interface{}) (*models.ApiKey, error) {
+	var apiKey models.ApiKey
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&apiKey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) GetApiKeys(ctx context.Context, pagination *dto.MetaPagination) ([]*models.ApiKey, int64, error) {
+	var apiKeys []*models.ApiKey
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.ApiKey{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&apiKeys).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return apiKeys, total, nil
+}
+
+func (r *apiKeyRepository) UpdateApiKey(ctx context.Context, apiKey *models.ApiKey, tx *gorm.DB) (*models.ApiKey, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(apiKey).Error; err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// TouchApiKey records the use of a key. The write is skipped when the key was already marked
+// used within interval, so busy clients do not update the row on every request.
+func (r *apiKeyRepository) TouchApiKey(ctx context.Context, id string, usedAt time.Time, interval time.Duration) error {
+	return r.db.WithContext(ctx).Model(&models.ApiKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-interval)).
+		UpdateColumn("last_used_at", usedAt).Error
+}
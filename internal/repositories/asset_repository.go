@@ -1,21 +1,39 @@
 package repositories
 
 import (
+	"assets-api-go/internal/common"
 	"assets-api-go/internal/dto"
 	"assets-api-go/internal/models"
+	"context"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AssetRepositoryInterface interface {
-	StartTransaction() *gorm.DB
+	StartTransaction(ctx context.Context) *gorm.DB
 	CommitTransaction(*gorm.DB) error
 	RollbackTransaction(*gorm.DB) error
-	CreateAsset(asset *models.Asset, tx *gorm.DB) (*models.Asset, error)
-	GetAssetByAttribute(whereClause interface{}) (*models.Asset, error)
-	GetAssets(pagination *dto.MetaPagination) ([]*models.Asset, int64, error)
-	UpdateAsset(asset *models.Asset, tx *gorm.DB) (*models.Asset, error)
-	DeleteAsset(asset *models.Asset, tx *gorm.DB) error
+	CreateAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error)
+	GetAssetByAttribute(ctx context.Context, whereClause interface{}) (*models.Asset, error)
+	GetAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error)
+	GetAssetsByCursor(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, bool, error)
+	StreamAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, fn func(asset *models.Asset) error) error
+	CountAssets(ctx context.Context, filter *dto.AssetFilterDto) (int64, error)
+	SumAssetValue(ctx context.Context, filter *dto.AssetFilterDto) (float64, error)
+	UpdateAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error)
+	PatchAsset(ctx context.Context, asset *models.Asset, columns []string, tx *gorm.DB) (bool, error)
+	DeleteAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error)
+	GetDeletedAssetByAttribute(ctx context.Context, whereClause interface{}) (*models.Asset, error)
+	GetDeletedAssets(ctx context.Context, pagination *dto.MetaPagination) ([]*models.Asset, int64, error)
+	RestoreAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) error
+	PurgeAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) error
+	GetDeletedAssetsBefore(ctx context.Context, before time.Time) ([]*models.Asset, error)
+	TransitionAsset(ctx context.Context, asset *models.Asset, fromStatus string, tx *gorm.DB) (bool, error)
+	CreateAssetTransition(ctx context.Context, transition *models.AssetTransition, tx *gorm.DB) (*models.AssetTransition, error)
+	GetAssetTransitions(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.AssetTransition, int64, error)
 }
 
 type assetRepository struct {
@@ -26,8 +44,8 @@ func NewAssetRepository(db *gorm.DB) AssetRepositoryInterface {
 	return &assetRepository{db}
 }
 
-func (repo *assetRepository) StartTransaction() *gorm.DB {
-	return repo.db.Begin()
+func (repo *assetRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
 }
 
 func (repo *assetRepository) CommitTransaction(tx *gorm.DB) error {
@@ -38,9 +56,9 @@ func (repo *assetRepository) RollbackTransaction(tx *gorm.DB) error {
 	return tx.Rollback().Error
 }
 
-func (r *assetRepository) CreateAsset(asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+func (r *assetRepository) CreateAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
 	if tx == nil {
-		tx = r.db
+		tx = r.db.WithContext(ctx)
 	}
 
 	if err := tx.Create(asset).Error; err != nil {
@@ -50,10 +68,10 @@ func (r *assetRepository) CreateAsset(asset *models.Asset, tx *gorm.DB) (*models
 	return asset, nil
 }
 
-func (r *assetRepository) GetAssetByAttribute(whereClause interface{}) (*models.Asset, error) {
+func (r *assetRepository) GetAssetByAttribute(ctx context.Context, whereClause interface{}) (*models.Asset, error) {
 	var asset models.Asset
 
-	if err := r.db.Where(whereClause).Where("deleted_at is NULL").Order("created_at desc").First(&asset).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where(whereClause).Order("created_at desc").First(&asset).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -63,40 +81,389 @@ func (r *assetRepository) GetAssetByAttribute(whereClause interface{}) (*models.
 	return &asset, nil
 }
 
-func (r *assetRepository) GetAssets(pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+func (r *assetRepository) GetAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
 	var assets []*models.Asset
 	var total int64
 
-	query := r.db.Where("deleted_at is NULL").Order("created_at desc")
+	query := applyAssetFilter(r.db.WithContext(ctx), filter)
 
 	if err := query.Model(&models.Asset{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := query.Limit(pagination.Limit).Offset(pagination.Offset).Find(&assets).Error; err != nil {
+	if err := applyAssetSort(query, pagination.SortKeys(DefaultAssetSort), false).Limit(pagination.Limit).Offset(pagination.Offset).Find(&assets).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return assets, total, nil
 }
 
-func (r *assetRepository) UpdateAsset(asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+// GetAssetsByCursor returns one page of assets after, or before, pagination.DecodedCursor
+// in display order and whether more rows exist in the paging direction
+func (r *assetRepository) GetAssetsByCursor(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, bool, error) {
+	var assets []*models.Asset
+
+	keys := pagination.SortKeys(DefaultAssetSort)
+	before := false
+	query := applyAssetFilter(r.db.WithContext(ctx), filter)
+	if pagination.DecodedCursor != nil {
+		keys = pagination.DecodedCursor.Sorts
+		before = pagination.DecodedCursor.Before
+		query = applyKeyset(query, keys, pagination.DecodedCursor.Values, before)
+	}
+
+	if err := applyAssetSort(query, keys, before).Limit(pagination.Limit + 1).Find(&assets).Error; err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(assets) > pagination.Limit
+	if hasMore {
+		assets = assets[:pagination.Limit]
+	}
+	if before {
+		for i, j := 0, len(assets)-1; i < j; i, j = i+1, j-1 {
+			assets[i], assets[j] = assets[j], assets[i]
+		}
+	}
+
+	return assets, hasMore, nil
+}
+
+// StreamAssets calls fn for every asset matching the filter in display order. Rows are read
+// one at a time from the cursor, so memory does not grow with the result. A fn error stops
+// the iteration and is returned.
+func (r *assetRepository) StreamAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, fn func(asset *models.Asset) error) error {
+	rows, err := applyAssetSort(applyAssetFilter(r.db.WithContext(ctx), filter), pagination.SortKeys(DefaultAssetSort), false).Model(&models.Asset{}).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var asset models.Asset
+		if err = r.db.ScanRows(rows, &asset); err != nil {
+			return err
+		}
+		if err = fn(&asset); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *assetRepository) CountAssets(ctx context.Context, filter *dto.AssetFilterDto) (int64, error) {
+	var total int64
+
+	if err := applyAssetFilter(r.db.WithContext(ctx), filter).Model(&models.Asset{}).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *assetRepository) SumAssetValue(ctx context.Context, filter *dto.AssetFilterDto) (float64, error) {
+	var total float64
+
+	if err := applyAssetFilter(r.db.WithContext(ctx), filter).Model(&models.Asset{}).Select("COALESCE(SUM(value), 0)").Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// UpdateAsset saves the asset only while it is still at asset.Version and moves it to the
+// next version. It reports false when another request updated the asset first.
+func (r *assetRepository) UpdateAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
+	return r.updateAsset(ctx, asset, nil, tx)
+}
+
+// PatchAsset is UpdateAsset writing only the given columns
+func (r *assetRepository) PatchAsset(ctx context.Context, asset *models.Asset, columns []string, tx *gorm.DB) (bool, error) {
+	return r.updateAsset(ctx, asset, columns, tx)
+}
+
+func (r *assetRepository) updateAsset(ctx context.Context, asset *models.Asset, columns []string, tx *gorm.DB) (bool, error) {
 	if tx == nil {
-		tx = r.db
+		tx = r.db.WithContext(ctx)
 	}
 
-	if err := tx.Save(asset).Error; err != nil {
+	version := asset.Version
+	asset.Version = version + 1
+	query := tx.Model(asset).Where("version = ?", version)
+	if columns == nil {
+		query = query.Select("*")
+	} else {
+		query = query.Select(append(columns[:len(columns):len(columns)], "updated_at", "version"))
+	}
+	// the status only changes through TransitionAsset
+	result := query.Omit("id", "status", "created_at", "deleted_at").Updates(asset)
+	if result.Error != nil || result.RowsAffected == 0 {
+		asset.Version = version
+		return false, result.Error
+	}
+
+	return true, nil
+}
+
+// DeleteAsset moves the asset to the trash only while it is still at asset.Version. It
+// reports false when another request updated the asset first.
+func (r *assetRepository) DeleteAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	result := tx.Where("version = ?", asset.Version).Delete(asset)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *assetRepository) GetDeletedAssetByAttribute(ctx context.Context, whereClause interface{}) (*models.Asset, error) {
+	var asset models.Asset
+
+	if err := r.db.WithContext(ctx).Unscoped().Where(whereClause).Where("deleted_at is NOT NULL").Order("deleted_at desc").First(&asset).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
 		return nil, err
 	}
 
-	return asset, nil
+	return &asset, nil
+}
+
+func (r *assetRepository) GetDeletedAssets(ctx context.Context, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+	var assets []*models.Asset
+	var total int64
+
+	query := r.db.WithContext(ctx).Unscoped().Where("deleted_at is NOT NULL").Order("deleted_at desc")
+
+	if err := query.Model(&models.Asset{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Limit(pagination.Limit).Offset(pagination.Offset).Find(&assets).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return assets, total, nil
+}
+
+func (r *assetRepository) RestoreAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	asset.DeletedAt = gorm.DeletedAt{}
+	asset.UpdatedAt = time.Now().UTC()
+	if err := tx.Unscoped().Model(asset).UpdateColumns(map[string]interface{}{
+		"deleted_at": nil,
+		"updated_at": asset.UpdatedAt,
+		"version":    gorm.Expr("version + 1"),
+	}).Error; err != nil {
+		return err
+	}
+	asset.Version++
+
+	return nil
+}
+
+// assetDependents are the rows owned by an asset, removed with it when it is purged. The
+// audit trail is kept, the files of attachments are left to the caller.
+var assetDependents = []interface{}{
+	&models.Attachment{},
+	&models.AssetTransition{},
+	&models.Assignment{},
+	&models.MaintenanceRecord{},
+	&models.MaintenancePlan{},
+	&models.Warranty{},
 }
 
-func (r *assetRepository) DeleteAsset(asset *models.Asset, tx *gorm.DB) error {
+// PurgeAsset permanently deletes an asset together with its dependent rows
+func (r *assetRepository) PurgeAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	for _, v := range assetDependents {
+		if err := tx.Unscoped().Where("asset_id = ?", asset.Id).Delete(v).Error; err != nil {
+			return err
+		}
+	}
 
-	if err := r.db.Delete(&asset).Error; err != nil {
+	if err := tx.Unscoped().Delete(asset).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// GetDeletedAssetsBefore returns the assets moved to the trash before the given time
+func (r *assetRepository) GetDeletedAssetsBefore(ctx context.Context, before time.Time) ([]*models.Asset, error) {
+	var assets []*models.Asset
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at is NOT NULL AND deleted_at < ?", before).Order("deleted_at").Find(&assets).Error; err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// TransitionAsset moves an asset to asset.Status only while it is still in fromStatus. It
+// reports false when another request changed the status first.
+func (r *assetRepository) TransitionAsset(ctx context.Context, asset *models.Asset, fromStatus string, tx *gorm.DB) (bool, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	asset.UpdatedAt = time.Now().UTC()
+	result := tx.Model(&models.Asset{}).Where("id = ? AND status = ?", asset.Id, fromStatus).UpdateColumns(map[string]interface{}{
+		"status":     asset.Status,
+		"updated_at": asset.UpdatedAt,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	asset.Version++
+
+	return true, nil
+}
+
+func (r *assetRepository) CreateAssetTransition(ctx context.Context, transition *models.AssetTransition, tx *gorm.DB) (*models.AssetTransition, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(transition).Error; err != nil {
+		return nil, err
+	}
+
+	return transition, nil
+}
+
+func (r *assetRepository) GetAssetTransitions(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.AssetTransition, int64, error) {
+	var transitions []*models.AssetTransition
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.AssetTransition{}).Where("asset_id = ?", assetId)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("transitioned_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&transitions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return transitions, total, nil
+}
+
+// applyAssetFilter adds the listing filters to the query. Every value is passed as a
+// bound parameter.
+func applyAssetFilter(query *gorm.DB, filter *dto.AssetFilterDto) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if filter.Q != "" {
+		pattern := likePattern(filter.Q)
+		query = query.Where("(LOWER(name) LIKE ? ESCAPE '\\' OR LOWER(type) LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+	if filter.Name != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", likePattern(filter.Name))
+	}
+	if filter.Type != "" {
+		query = query.Where("LOWER(type) = ?", strings.ToLower(filter.Type))
+	}
+	if len(filter.CategoryIds) > 0 {
+		query = query.Where("category_id IN ?", filter.CategoryIds)
+	}
+	if len(filter.LocationIds) > 0 {
+		query = query.Where("location_id IN ?", filter.LocationIds)
+	}
+	if filter.Status != "" {
+		openAssignments := query.Session(&gorm.Session{NewDB: true}).Model(&models.Assignment{}).Select("asset_id").Where("checked_in_at IS NULL")
+		switch filter.Status {
+		case common.AssignmentStatusAvailable:
+			query = query.Where("id NOT IN (?)", openAssignments)
+		case common.AssignmentStatusCheckedOut:
+			query = query.Where("id IN (?)", openAssignments)
+		case common.AssignmentStatusOverdue:
+			query = query.Where("id IN (?)", openAssignments.Where("due_date < ?", filter.DueBefore))
+		}
+	}
+	if filter.ValueMin != nil {
+		query = query.Where("value >= ?", *filter.ValueMin)
+	}
+	if filter.ValueMax != nil {
+		query = query.Where("value <= ?", *filter.ValueMax)
+	}
+	if !filter.AcquisitionDateFromTime.IsZero() {
+		query = query.Where("acquisition_date >= ?", filter.AcquisitionDateFromTime)
+	}
+	if !filter.AcquisitionDateToTime.IsZero() {
+		query = query.Where("acquisition_date < ?", filter.AcquisitionDateToTime)
+	}
+	if !filter.CreatedFromTime.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFromTime)
+	}
+	if !filter.CreatedToTime.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedToTime)
+	}
+	if !filter.UpdatedFromTime.IsZero() {
+		query = query.Where("updated_at >= ?", filter.UpdatedFromTime)
+	}
+	if !filter.UpdatedToTime.IsZero() {
+		query = query.Where("updated_at < ?", filter.UpdatedToTime)
+	}
+
+	return query
+}
+
+// DefaultAssetSort is used when the listing has no sort fields
+var DefaultAssetSort = dto.SortField{Field: "created_at", Desc: true}
+
+// applyAssetSort orders the query by the sort keys, which must be whitelisted and end with
+// id so pages stay stable when the sort values are equal. reverse flips every direction
+// for paging backwards.
+func applyAssetSort(query *gorm.DB, keys []dto.SortField, reverse bool) *gorm.DB {
+	for _, key := range keys {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: key.Field}, Desc: key.Desc != reverse})
+	}
+	return query
+}
+
+// applyKeyset keeps the rows that sort after the cursor values, or before them when before
+// is set. For keys (a, b, id) it builds a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?)
+// with each comparison following the direction of its key.
+func applyKeyset(query *gorm.DB, keys []dto.SortField, values []interface{}, before bool) *gorm.DB {
+	var conditions []string
+	var args []interface{}
+
+	for i, key := range keys {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].Field+" = ?")
+			args = append(args, values[j])
+		}
+
+		operator := ">"
+		if key.Desc != before {
+			operator = "<"
+		}
+		parts = append(parts, key.Field+" "+operator+" ?")
+		args = append(args, values[i])
+
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
+
+// likePattern builds a case-insensitive substring pattern with the LIKE wildcards escaped
+func likePattern(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+	return "%" + replacer.Replace(strings.ToLower(value)) + "%"
+}
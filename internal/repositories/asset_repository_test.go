@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/models"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestDb(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "assets.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&models.Asset{}, &models.AssetTransition{}, &models.Assignment{}, &models.MaintenanceRecord{}, &models.MaintenancePlan{}, &models.Warranty{}, &models.Attachment{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newTestAsset(name string) *models.Asset {
+	return &models.Asset{Name: name, Type: "Computer", Status: common.AssetStatusInStock, Value: 1000, AcquisitionDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestAssetRepositoryVersionCheck(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAssetRepository(newTestDb(t))
+
+	asset, err := repo.CreateAsset(ctx, newTestAsset("Laptop"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), asset.Version)
+
+	// both requests read version 1
+	first, second := *asset, *asset
+	first.Name = "First"
+	second.Name = "Second"
+
+	updated, err := repo.UpdateAsset(ctx, &first, nil)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, int64(2), first.Version)
+
+	updated, err = repo.UpdateAsset(ctx, &second, nil)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.Equal(t, int64(1), second.Version)
+
+	deleted, err := repo.DeleteAsset(ctx, &second, nil)
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+
+	first.Status = common.AssetStatusDeployed
+	moved, err := repo.TransitionAsset(ctx, &first, common.AssetStatusInStock, nil)
+	assert.NoError(t, err)
+	assert.True(t, moved)
+	assert.Equal(t, int64(3), first.Version)
+
+	saved, err := repo.GetAssetByAttribute(ctx, map[string]interface{}{"id": asset.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, "First", saved.Name)
+	assert.Equal(t, common.AssetStatusDeployed, saved.Status)
+	assert.Equal(t, int64(3), saved.Version)
+
+	deleted, err = repo.DeleteAsset(ctx, saved, nil)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestAssetRepositoryPatchAsset(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAssetRepository(newTestDb(t))
+
+	asset, err := repo.CreateAsset(ctx, newTestAsset("Laptop"), nil)
+	assert.NoError(t, err)
+
+	// only the patched columns are written
+	asset.Name = "Patched"
+	asset.Value = 5000
+	updated, err := repo.PatchAsset(ctx, asset, []string{"name"}, nil)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, int64(2), asset.Version)
+
+	saved, err := repo.GetAssetByAttribute(ctx, map[string]interface{}{"id": asset.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, "Patched", saved.Name)
+	assert.Equal(t, float64(1000), saved.Value)
+	assert.Equal(t, int64(2), saved.Version)
+
+	// a patch of a stale version is rejected
+	stale := *saved
+	stale.Version = 1
+	stale.Name = "Stale"
+	updated, err = repo.PatchAsset(ctx, &stale, []string{"name"}, nil)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func TestAssetRepositoryPurgeAsset(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDb(t)
+	repo := NewAssetRepository(db)
+
+	asset, err := repo.CreateAsset(ctx, newTestAsset("Laptop"), nil)
+	assert.NoError(t, err)
+	other, err := repo.CreateAsset(ctx, newTestAsset("Monitor"), nil)
+	assert.NoError(t, err)
+	for _, v := range []*models.Asset{asset, other} {
+		assert.NoError(t, db.Create(&models.AssetTransition{AssetId: v.Id}).Error)
+		assert.NoError(t, db.Create(&models.Assignment{AssetId: v.Id}).Error)
+		assert.NoError(t, db.Create(&models.MaintenanceRecord{AssetId: v.Id}).Error)
+		assert.NoError(t, db.Create(&models.MaintenancePlan{AssetId: v.Id}).Error)
+		assert.NoError(t, db.Create(&models.Warranty{AssetId: v.Id}).Error)
+		assert.NoError(t, db.Create(&models.Attachment{AssetId: v.Id}).Error)
+	}
+
+	deleted, err := repo.DeleteAsset(ctx, asset, nil)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	assert.NoError(t, repo.PurgeAsset(ctx, asset, nil))
+
+	var count int64
+	assert.NoError(t, db.Unscoped().Model(&models.Asset{}).Where("id = ?", asset.Id).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+	for _, v := range assetDependents {
+		assert.NoError(t, db.Unscoped().Model(v).Where("asset_id = ?", asset.Id).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+		// the rows of other assets are kept
+		assert.NoError(t, db.Unscoped().Model(v).Where("asset_id = ?", other.Id).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	}
+}
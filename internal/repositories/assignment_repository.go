@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type AssignmentRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateAssignment(ctx context.Context, assignment *models.Assignment, tx *gorm.DB) (*models.Assignment, error)
+	GetOpenAssignment(ctx context.Context, assetId string) (*models.Assignment, error)
+	GetAssignments(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.Assignment, int64, error)
+	CloseAssignment(ctx context.Context, assignment *models.Assignment, tx *gorm.DB) (bool, error)
+}
+
+type assignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAssignmentRepository(db *gorm.DB) AssignmentRepositoryInterface {
+	return &assignmentRepository{db}
+}
+
+func (repo *assignmentRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *assignmentRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *assignmentRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+// CreateAssignment fails on the open assignment unique index when the asset is already
+// checked out
+func (r *assignmentRepository) CreateAssignment(ctx context.Context, assignment *models.Assignment, tx *gorm.DB) (*models.Assignment, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(assignment).Error; err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+func (r *assignmentRepository) GetOpenAssignment(ctx context.Context, assetId string) (*models.Assignment, error) {
+	var assignment models.Assignment
+
+	if err := r.db.WithContext(ctx).Where("asset_id = ? AND checked_in_at IS NULL", assetId).First(&assignment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+func (r *assignmentRepository) GetAssignments(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.Assignment, int64, error) {
+	var assignments []*models.Assignment
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Assignment{}).Where("asset_id = ?", assetId)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("checked_out_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&assignments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return assignments, total, nil
+}
+
+// CloseAssignment records the check-in of an open assignment. It reports false when the
+// assignment was already checked in by someone else.
+func (r *assignmentRepository) CloseAssignment(ctx context.Context, assignment *models.Assignment, tx *gorm.DB) (bool, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	result := tx.Model(assignment).Where("checked_in_at IS NULL").Updates(map[string]interface{}{
+		"checked_in_at":    assignment.CheckedInAt,
+		"checked_in_by":    assignment.CheckedInBy,
+		"return_condition": assignment.ReturnCondition,
+		"return_note":      assignment.ReturnNote,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
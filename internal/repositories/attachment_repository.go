@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"assets-api-go/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateAttachment(ctx context.Context, attachment *models.Attachment, tx *gorm.DB) (*models.Attachment, error)
+	GetAttachmentByAttribute(ctx context.Context, whereClause interface{}) (*models.Attachment, error)
+	GetAttachments(ctx context.Context, assetId string) ([]*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachment *models.Attachment, tx *gorm.DB) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepositoryInterface {
+	return &attachmentRepository{db}
+}
+
+func (repo *attachmentRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *attachmentRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *attachmentRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *attachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment, tx *gorm.DB) (*models.Attachment, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(attachment).Error; err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (r *attachmentRepository) GetAttachmentByAttribute(ctx context.Context, whereClause interface{}) (*models.Attachment, error) {
+	var attachment models.Attachment
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&attachment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) GetAttachments(ctx context.Context, assetId string) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+
+	if err := r.db.WithContext(ctx).Where("asset_id = ?", assetId).Order("created_at desc").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, attachment *models.Attachment, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Delete(attachment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryInterface interface {
+	CreateAuditLog(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error
+	GetAuditLogs(ctx context.Context, filter *dto.AuditFilterDto, pagination *dto.MetaPagination) ([]*models.AuditLog, int64, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepositoryInterface {
+	return &auditRepository{db}
+}
+
+func (r *auditRepository) CreateAuditLog(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(auditLog).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *auditRepository) GetAuditLogs(ctx context.Context, filter *dto.AuditFilterDto, pagination *dto.MetaPagination) ([]*models.AuditLog, int64, error) {
+	var auditLogs []*models.AuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityId != "" {
+		query = query.Where("entity_id = ?", filter.EntityId)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.RequestId != "" {
+		query = query.Where("request_id = ?", filter.RequestId)
+	}
+	if !filter.FromTime.IsZero() {
+		query = query.Where("created_at >= ?", filter.FromTime)
+	}
+	if !filter.ToTime.IsZero() {
+		query = query.Where("created_at < ?", filter.ToTime)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, total, nil
+}
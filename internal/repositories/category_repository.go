@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type CategoryRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateCategory(ctx context.Context, category *models.Category, tx *gorm.DB) (*models.Category, error)
+	GetCategoryByAttribute(ctx context.Context, whereClause interface{}) (*models.Category, error)
+	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
+	GetCategoryByCode(ctx context.Context, code string) (*models.Category, error)
+	GetCategories(ctx context.Context, filter *dto.CategoryFilterDto, pagination *dto.MetaPagination) ([]*models.Category, int64, error)
+	GetAllCategories(ctx context.Context) ([]*models.Category, error)
+	GetDescendantIds(ctx context.Context, id string) ([]string, error)
+	CountChildren(ctx context.Context, id string) (int64, error)
+	CountAssets(ctx context.Context, id string) (int64, error)
+	UpdateCategory(ctx context.Context, category *models.Category, tx *gorm.DB) (*models.Category, error)
+	UpdateAssetsType(ctx context.Context, categoryId string, name string, tx *gorm.DB) error
+	DeleteCategory(ctx context.Context, category *models.Category, tx *gorm.DB) error
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepositoryInterface {
+	return &categoryRepository{db}
+}
+
+func (repo *categoryRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *categoryRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *categoryRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *categoryRepository) CreateCategory(ctx context.Context, category *models.Category, tx *gorm.DB) (*models.Category, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(category).Error; err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepository) GetCategoryByAttribute(ctx context.Context, whereClause interface{}) (*models.Category, error) {
+	var category models.Category
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetCategoryByName matches the name case-insensitively
+func (r *categoryRepository) GetCategoryByName(ctx context.Context, name string) (*models.Category, error) {
+	var category models.Category
+
+	if err := r.db.WithContext(ctx).Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name))).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetCategoryByCode matches the code case-insensitively
+func (r *categoryRepository) GetCategoryByCode(ctx context.Context, code string) (*models.Category, error) {
+	var category models.Category
+
+	if err := r.db.WithContext(ctx).Where("LOWER(code) = ?", strings.ToLower(strings.TrimSpace(code))).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context, filter *dto.CategoryFilterDto, pagination *dto.MetaPagination) ([]*models.Category, int64, error) {
+	var categories []*models.Category
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Category{})
+	if filter.Q != "" {
+		pattern := likePattern(filter.Q)
+		query = query.Where("(LOWER(name) LIKE ? ESCAPE '\\' OR LOWER(code) LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+	if filter.ParentId == "root" {
+		query = query.Where("parent_id IS NULL")
+	} else if filter.ParentId != "" {
+		query = query.Where("parent_id = ?", filter.ParentId)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("name asc").Order("id asc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&categories).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return categories, total, nil
+}
+
+func (r *categoryRepository) GetAllCategories(ctx context.Context) ([]*models.Category, error) {
+	var categories []*models.Category
+
+	if err := r.db.WithContext(ctx).Order("name asc").Order("id asc").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) GetDescendantIds(ctx context.Context, id string) ([]string, error) {
+	return descendantIds(r.db.WithContext(ctx).Model(&models.Category{}), id)
+}
+
+// descendantIds returns the ids of every row below id in a parent_id tree, walking the
+// tree one level per query
+func descendantIds(query *gorm.DB, id string) ([]string, error) {
+	var descendants []string
+
+	level := []string{id}
+	seen := map[string]bool{id: true}
+	for len(level) > 0 {
+		var children []string
+		if err := query.Session(&gorm.Session{}).Where("parent_id IN ?", level).Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+
+		level = nil
+		for _, child := range children {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			level = append(level, child)
+			descendants = append(descendants, child)
+		}
+	}
+
+	return descendants, nil
+}
+
+func (r *categoryRepository) CountChildren(ctx context.Context, id string) (int64, error) {
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Category{}).Where("parent_id = ?", id).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// CountAssets counts the assets of the category, including the ones in the trash
+func (r *categoryRepository) CountAssets(ctx context.Context, id string) (int64, error) {
+	var total int64
+
+	if err := r.db.WithContext(ctx).Unscoped().Model(&models.Asset{}).Where("category_id = ?", id).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *categoryRepository) UpdateCategory(ctx context.Context, category *models.Category, tx *gorm.DB) (*models.Category, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(category).Error; err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// UpdateAssetsType keeps the type of the category assets, including the ones in the trash,
+// in sync with the category name
+func (r *categoryRepository) UpdateAssetsType(ctx context.Context, categoryId string, name string, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Unscoped().Model(&models.Asset{}).Where("category_id = ?", categoryId).UpdateColumns(map[string]interface{}{
+		"type":    name,
+		"version": gorm.Expr("version + 1"),
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) DeleteCategory(ctx context.Context, category *models.Category, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Delete(category).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
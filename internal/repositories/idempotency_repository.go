@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"assets-api-go/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepositoryInterface interface {
+	CreateIdempotencyKey(ctx context.Context, idempotencyKey *models.IdempotencyKey) error
+	GetIdempotencyKeyByAttribute(ctx context.Context, whereClause interface{}) (*models.IdempotencyKey, error)
+	SaveIdempotencyResponse(ctx context.Context, idempotencyKey *models.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, idempotencyKey *models.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepositoryInterface {
+	return &idempotencyRepository{db}
+}
+
+// CreateIdempotencyKey fails on the unique index when another request already used the key
+func (r *idempotencyRepository) CreateIdempotencyKey(ctx context.Context, idempotencyKey *models.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Create(idempotencyKey).Error
+}
+
+func (r *idempotencyRepository) GetIdempotencyKeyByAttribute(ctx context.Context, whereClause interface{}) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&idempotencyKey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+// SaveIdempotencyResponse stores the response of the request that holds the key
+func (r *idempotencyRepository) SaveIdempotencyResponse(ctx context.Context, idempotencyKey *models.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Model(idempotencyKey).UpdateColumns(map[string]interface{}{
+		"status_code": idempotencyKey.StatusCode,
+		"header":      idempotencyKey.Header,
+		"body":        idempotencyKey.Body,
+	}).Error
+}
+
+func (r *idempotencyRepository) DeleteIdempotencyKey(ctx context.Context, idempotencyKey *models.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Delete(idempotencyKey).Error
+}
+
+func (r *idempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
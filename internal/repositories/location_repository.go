@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type LocationRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateLocation(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error)
+	GetLocationByAttribute(ctx context.Context, whereClause interface{}) (*models.Location, error)
+	GetLocations(ctx context.Context, filter *dto.LocationFilterDto, pagination *dto.MetaPagination) ([]*models.Location, int64, error)
+	GetAllLocations(ctx context.Context) ([]*models.Location, error)
+	GetDescendantIds(ctx context.Context, id string) ([]string, error)
+	CountChildren(ctx context.Context, id string) (int64, error)
+	CountAssets(ctx context.Context, id string) (int64, error)
+	UpdateLocation(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error)
+	DeleteLocation(ctx context.Context, location *models.Location, tx *gorm.DB) error
+}
+
+type locationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) LocationRepositoryInterface {
+	return &locationRepository{db}
+}
+
+func (repo *locationRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *locationRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *locationRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *locationRepository) CreateLocation(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(location).Error; err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (r *locationRepository) GetLocationByAttribute(ctx context.Context, whereClause interface{}) (*models.Location, error) {
+	var location models.Location
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&location).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+func (r *locationRepository) GetLocations(ctx context.Context, filter *dto.LocationFilterDto, pagination *dto.MetaPagination) ([]*models.Location, int64, error) {
+	var locations []*models.Location
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Location{})
+	if filter.Q != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", likePattern(filter.Q))
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.ParentId == "root" {
+		query = query.Where("parent_id IS NULL")
+	} else if filter.ParentId != "" {
+		query = query.Where("parent_id = ?", filter.ParentId)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("name asc").Order("id asc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&locations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return locations, total, nil
+}
+
+func (r *locationRepository) GetAllLocations(ctx context.Context) ([]*models.Location, error) {
+	var locations []*models.Location
+
+	if err := r.db.WithContext(ctx).Order("name asc").Order("id asc").Find(&locations).Error; err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (r *locationRepository) GetDescendantIds(ctx context.Context, id string) ([]string, error) {
+	return descendantIds(r.db.WithContext(ctx).Model(&models.Location{}), id)
+}
+
+func (r *locationRepository) CountChildren(ctx context.Context, id string) (int64, error) {
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Location{}).Where("parent_id = ?", id).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// CountAssets counts the assets at the location, including the ones in the trash
+func (r *locationRepository) CountAssets(ctx context.Context, id string) (int64, error) {
+	var total int64
+
+	if err := r.db.WithContext(ctx).Unscoped().Model(&models.Asset{}).Where("location_id = ?", id).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *locationRepository) UpdateLocation(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(location).Error; err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (r *locationRepository) DeleteLocation(ctx context.Context, location *models.Location, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Delete(location).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
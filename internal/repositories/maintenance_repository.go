@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MaintenanceRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateMaintenanceRecord(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) (*models.MaintenanceRecord, error)
+	GetMaintenanceRecords(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.MaintenanceRecord, int64, error)
+	CreateMaintenancePlan(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error)
+	GetMaintenancePlanByAttribute(ctx context.Context, whereClause interface{}) (*models.MaintenancePlan, error)
+	GetMaintenancePlans(ctx context.Context, assetId string) ([]*models.MaintenancePlan, error)
+	UpdateMaintenancePlan(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error)
+	DeleteMaintenancePlan(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) error
+	GetDueMaintenance(ctx context.Context, dueBefore time.Time, pagination *dto.MetaPagination) ([]*models.MaintenanceDue, int64, error)
+}
+
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepositoryInterface {
+	return &maintenanceRepository{db}
+}
+
+func (repo *maintenanceRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *maintenanceRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *maintenanceRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *maintenanceRepository) CreateMaintenanceRecord(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) (*models.MaintenanceRecord, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(record).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (r *maintenanceRepository) GetMaintenanceRecords(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.MaintenanceRecord, int64, error) {
+	var records []*models.MaintenanceRecord
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.MaintenanceRecord{}).Where("asset_id = ?", assetId)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("performed_at desc").Order("created_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+func (r *maintenanceRepository) CreateMaintenancePlan(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(plan).Error; err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (r *maintenanceRepository) GetMaintenancePlanByAttribute(ctx context.Context, whereClause interface{}) (*models.MaintenancePlan, error) {
+	var plan models.MaintenancePlan
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&plan).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+func (r *maintenanceRepository) GetMaintenancePlans(ctx context.Context, assetId string) ([]*models.MaintenancePlan, error) {
+	var plans []*models.MaintenancePlan
+
+	if err := r.db.WithContext(ctx).Where("asset_id = ?", assetId).Order("created_at").Find(&plans).Error; err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (r *maintenanceRepository) UpdateMaintenancePlan(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(plan).Error; err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (r *maintenanceRepository) DeleteMaintenancePlan(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Delete(plan).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetDueMaintenance lists the calendar plans due on or before dueBefore and the usage plans
+// whose asset reached the due units, for assets that are still in service. Usage plans come
+// first as they have no due date, then the earliest due date.
+func (r *maintenanceRepository) GetDueMaintenance(ctx context.Context, dueBefore time.Time, pagination *dto.MetaPagination) ([]*models.MaintenanceDue, int64, error) {
+	var dues []*models.MaintenanceDue
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.MaintenancePlan{}).
+		Joins("JOIN assets ON assets.id = maintenance_plans.asset_id AND assets.deleted_at IS NULL").
+		Where("assets.status NOT IN ?", []string{common.AssetStatusRetired, common.AssetStatusDisposed, common.AssetStatusLost}).
+		Where("(maintenance_plans.kind = ? AND maintenance_plans.next_due_date <= ?) OR (maintenance_plans.kind = ? AND assets.units_used >= maintenance_plans.next_due_units)",
+			common.MaintenancePlanKindCalendar, dueBefore, common.MaintenancePlanKindUsage)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Select("maintenance_plans.*, assets.name AS asset_name, assets.units_used AS asset_units_used").
+		Order("CASE WHEN maintenance_plans.next_due_date IS NULL THEN 0 ELSE 1 END").
+		Order("maintenance_plans.next_due_date").
+		Order("maintenance_plans.id").
+		Limit(pagination.Limit).Offset(pagination.Offset).
+		Scan(&dues).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return dues, total, nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"assets-api-go/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepositoryInterface interface {
+	CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent, tx *gorm.DB) error
+	GetOutboxEventByAttribute(ctx context.Context, whereClause interface{}) (*models.OutboxEvent, error)
+	GetPendingOutboxEvents(ctx context.Context, limit int) ([]*models.OutboxEvent, error)
+	MarkOutboxEventDispatched(ctx context.Context, id string, dispatchedAt time.Time, tx *gorm.DB) (bool, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepositoryInterface {
+	return &outboxRepository{db}
+}
+
+func (r *outboxRepository) CreateOutboxEvent(ctx context.Context, event *models.OutboxEvent, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(event).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) GetOutboxEventByAttribute(ctx context.Context, whereClause interface{}) (*models.OutboxEvent, error) {
+	var event models.OutboxEvent
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&event).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *outboxRepository) GetPendingOutboxEvents(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	var events []*models.OutboxEvent
+
+	if err := r.db.WithContext(ctx).Where("dispatched_at IS NULL").Order("occurred_at asc").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// MarkOutboxEventDispatched marks an event as dispatched. It reports false when the event was
+// already dispatched, so two dispatchers never fan out the same event twice.
+func (r *outboxRepository) MarkOutboxEventDispatched(ctx context.Context, id string, dispatchedAt time.Time, tx *gorm.DB) (bool, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	result := tx.Model(&models.OutboxEvent{}).
+		Where("id = ? AND dispatched_at IS NULL", id).
+		UpdateColumn("dispatched_at", dispatchedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
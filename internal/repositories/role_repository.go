@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateRoleAssignment(ctx context.Context, assignment *models.RoleAssignment, tx *gorm.DB) (*models.RoleAssignment, error)
+	GetRoleAssignmentByAttribute(ctx context.Context, whereClause interface{}) (*models.RoleAssignment, error)
+	GetRoleAssignments(ctx context.Context, subject string, pagination *dto.MetaPagination) ([]*models.RoleAssignment, int64, error)
+	GetSubjectRoles(ctx context.Context, subject string) ([]string, error)
+	DeleteRoleAssignment(ctx context.Context, assignment *models.RoleAssignment, tx *gorm.DB) error
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepositoryInterface {
+	return &roleRepository{db}
+}
+
+func (repo *roleRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *roleRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *roleRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *roleRepository) CreateRoleAssignment(ctx context.Context, assignment *models.RoleAssignment, tx *gorm.DB) (*models.RoleAssignment, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(assignment).Error; err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+func (r *roleRepository) GetRoleAssignmentByAttribute(ctx context.Context, whereClause interface{}) (*models.RoleAssignment, error) {
+	var assignment models.RoleAssignment
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&assignment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+// GetRoleAssignments lists the role assignments by subject, all subjects when subject is empty
+func (r *roleRepository) GetRoleAssignments(ctx context.Context, subject string, pagination *dto.MetaPagination) ([]*models.RoleAssignment, int64, error) {
+	var assignments []*models.RoleAssignment
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.RoleAssignment{})
+	if subject != "" {
+		query = query.Where("subject = ?", subject)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("subject").Order("role").Limit(pagination.Limit).Offset(pagination.Offset).Find(&assignments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return assignments, total, nil
+}
+
+func (r *roleRepository) GetSubjectRoles(ctx context.Context, subject string) ([]string, error) {
+	var roles []string
+
+	if err := r.db.WithContext(ctx).Model(&models.RoleAssignment{}).Where("subject = ?", subject).Order("role").Pluck("role", &roles).Error; err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) DeleteRoleAssignment(ctx context.Context, assignment *models.RoleAssignment, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Delete(assignment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
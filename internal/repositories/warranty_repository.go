@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WarrantyRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateWarranty(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error)
+	GetWarrantyByAttribute(ctx context.Context, whereClause interface{}) (*models.Warranty, error)
+	GetWarranties(ctx context.Context, assetId string) ([]*models.Warranty, error)
+	UpdateWarranty(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error)
+	DeleteWarranty(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) error
+	GetExpiringWarranties(ctx context.Context, from, before time.Time, pagination *dto.MetaPagination) ([]*models.WarrantyExpiry, int64, error)
+	GetUnnotifiedExpiringWarranties(ctx context.Context, from, before time.Time) ([]*models.WarrantyExpiry, error)
+	MarkWarrantyNotified(ctx context.Context, id string, notifiedAt time.Time) error
+}
+
+type warrantyRepository struct {
+	db *gorm.DB
+}
+
+func NewWarrantyRepository(db *gorm.DB) WarrantyRepositoryInterface {
+	return &warrantyRepository{db}
+}
+
+func (repo *warrantyRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *warrantyRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *warrantyRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *warrantyRepository) CreateWarranty(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(warranty).Error; err != nil {
+		return nil, err
+	}
+
+	return warranty, nil
+}
+
+func (r *warrantyRepository) GetWarrantyByAttribute(ctx context.Context, whereClause interface{}) (*models.Warranty, error) {
+	var warranty models.Warranty
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&warranty).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &warranty, nil
+}
+
+func (r *warrantyRepository) GetWarranties(ctx context.Context, assetId string) ([]*models.Warranty, error) {
+	var warranties []*models.Warranty
+
+	if err := r.db.WithContext(ctx).Where("asset_id = ?", assetId).Order("end_date desc").Find(&warranties).Error; err != nil {
+		return nil, err
+	}
+
+	return warranties, nil
+}
+
+func (r *warrantyRepository) UpdateWarranty(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(warranty).Error; err != nil {
+		return nil, err
+	}
+
+	return warranty, nil
+}
+
+func (r *warrantyRepository) DeleteWarranty(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Delete(warranty).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetExpiringWarranties lists the warranties of assets not in the trash ending between from and
+// before, soonest first
+func (r *warrantyRepository) GetExpiringWarranties(ctx context.Context, from, before time.Time, pagination *dto.MetaPagination) ([]*models.WarrantyExpiry, int64, error) {
+	var warranties []*models.WarrantyExpiry
+	var total int64
+
+	query := r.expiringQuery(ctx, from, before)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Select("warranties.*, assets.name AS asset_name").
+		Order("warranties.end_date").Order("warranties.id").
+		Limit(pagination.Limit).Offset(pagination.Offset).
+		Scan(&warranties).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return warranties, total, nil
+}
+
+// GetUnnotifiedExpiringWarranties is GetExpiringWarranties without paging, limited to the
+// warranties whose expiry event was not emitted yet
+func (r *warrantyRepository) GetUnnotifiedExpiringWarranties(ctx context.Context, from, before time.Time) ([]*models.WarrantyExpiry, error) {
+	var warranties []*models.WarrantyExpiry
+
+	if err := r.expiringQuery(ctx, from, before).Where("warranties.expiry_notified_at IS NULL").
+		Select("warranties.*, assets.name AS asset_name").
+		Order("warranties.end_date").
+		Scan(&warranties).Error; err != nil {
+		return nil, err
+	}
+
+	return warranties, nil
+}
+
+func (r *warrantyRepository) MarkWarrantyNotified(ctx context.Context, id string, notifiedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Warranty{}).Where("id = ?", id).UpdateColumn("expiry_notified_at", notifiedAt).Error
+}
+
+func (r *warrantyRepository) expiringQuery(ctx context.Context, from, before time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.Warranty{}).
+		Joins("JOIN assets ON assets.id = warranties.asset_id AND assets.deleted_at IS NULL").
+		Where("warranties.end_date >= ? AND warranties.end_date <= ?", from, before)
+}
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoryInterface interface {
+	StartTransaction(ctx context.Context) *gorm.DB
+	CommitTransaction(*gorm.DB) error
+	RollbackTransaction(*gorm.DB) error
+	CreateWebhook(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) (*models.Webhook, error)
+	GetWebhookByAttribute(ctx context.Context, whereClause interface{}) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context, pagination *dto.MetaPagination) ([]*models.Webhook, int64, error)
+	GetActiveWebhooks(ctx context.Context) ([]*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) error
+	CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx *gorm.DB) (*models.WebhookDelivery, error)
+	GetWebhookDeliveryByAttribute(ctx context.Context, whereClause interface{}) (*models.WebhookDelivery, error)
+	GetWebhookDeliveries(ctx context.Context, webhookId string, pagination *dto.MetaPagination) ([]*models.WebhookDelivery, int64, error)
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	ClaimWebhookDelivery(ctx context.Context, id string, now time.Time, leaseUntil time.Time) (bool, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx *gorm.DB) (*models.WebhookDelivery, error)
+	CreateWebhookAttempt(ctx context.Context, attempt *models.WebhookAttempt, tx *gorm.DB) (*models.WebhookAttempt, error)
+	GetWebhookAttempts(ctx context.Context, deliveryId string) ([]*models.WebhookAttempt, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepositoryInterface {
+	return &webhookRepository{db}
+}
+
+func (repo *webhookRepository) StartTransaction(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Begin()
+}
+
+func (repo *webhookRepository) CommitTransaction(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+func (repo *webhookRepository) RollbackTransaction(tx *gorm.DB) error {
+	return tx.Rollback().Error
+}
+
+func (r *webhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) (*models.Webhook, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookRepository) GetWebhookByAttribute(ctx context.Context, whereClause interface{}) (*models.Webhook, error) {
+	var webhook models.Webhook
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&webhook).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *webhookRepository) GetWebhooks(ctx context.Context, pagination *dto.MetaPagination) ([]*models.Webhook, int64, error) {
+	var webhooks []*models.Webhook
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Webhook{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&webhooks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, total, nil
+}
+
+func (r *webhookRepository) GetActiveWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Order("created_at asc").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) UpdateWebhook(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) (*models.Webhook, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook, its deliveries that are still pending are given up
+func (r *webhookRepository) DeleteWebhook(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Model(&models.WebhookDelivery{}).
+		Where("webhook_id = ? AND status = ?", webhook.Id, common.WebhookDeliveryPending).
+		Updates(map[string]interface{}{"status": common.WebhookDeliveryFailed, "next_attempt_at": nil, "last_error": "Webhook was deleted"}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Delete(webhook).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) CreateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx *gorm.DB) (*models.WebhookDelivery, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookRepository) GetWebhookDeliveryByAttribute(ctx context.Context, whereClause interface{}) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	if err := r.db.WithContext(ctx).Where(whereClause).First(&delivery).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (r *webhookRepository) GetWebhookDeliveries(ctx context.Context, webhookId string, pagination *dto.MetaPagination) ([]*models.WebhookDelivery, int64, error) {
+	var deliveries []*models.WebhookDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookId)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at desc").Limit(pagination.Limit).Offset(pagination.Offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *webhookRepository) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", common.WebhookDeliveryPending, now).
+		Order("next_attempt_at asc").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimWebhookDelivery takes a due delivery by moving its next attempt to leaseUntil. It reports
+// false when another worker claimed it first. A worker that stops before recording the attempt
+// leaves the delivery to be retried once the lease ends.
+func (r *webhookRepository) ClaimWebhookDelivery(ctx context.Context, id string, now time.Time, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, common.WebhookDeliveryPending, now).
+		UpdateColumn("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *webhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx *gorm.DB) (*models.WebhookDelivery, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Save(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookRepository) CreateWebhookAttempt(ctx context.Context, attempt *models.WebhookAttempt, tx *gorm.DB) (*models.WebhookAttempt, error) {
+	if tx == nil {
+		tx = r.db.WithContext(ctx)
+	}
+
+	if err := tx.Create(attempt).Error; err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+func (r *webhookRepository) GetWebhookAttempts(ctx context.Context, deliveryId string) ([]*models.WebhookAttempt, error) {
+	var attempts []*models.WebhookAttempt
+
+	if err := r.db.WithContext(ctx).Where("delivery_id = ?", deliveryId).Order("attempted_at asc").Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
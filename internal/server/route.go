@@ -2,9 +2,15 @@ package server
 
 import (
 	"assets-api-go/docs"
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/config"
+	"assets-api-go/internal/events"
 	"assets-api-go/internal/handlers"
+	"assets-api-go/internal/jobs"
+	"assets-api-go/internal/middlewares"
 	"assets-api-go/internal/repositories"
 	"assets-api-go/internal/services"
+	"assets-api-go/internal/storage"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -13,10 +19,64 @@ import (
 	"gorm.io/gorm"
 )
 
-func Build(route *gin.Engine, db *gorm.DB) {
+func Build(route *gin.Engine, db *gorm.DB, env *config.EnviConfig) {
 	assetRepo := repositories.NewAssetRepository(db)
-	assetServie := services.NewAssetService(assetRepo)
+	auditRepo := repositories.NewAuditRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	locationRepo := repositories.NewLocationRepository(db)
+	assignmentRepo := repositories.NewAssignmentRepository(db)
+	maintenanceRepo := repositories.NewMaintenanceRepository(db)
+	warrantyRepo := repositories.NewWarrantyRepository(db)
+	attachmentRepo := repositories.NewAttachmentRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	apiKeyRepo := repositories.NewApiKeyRepository(db)
+	outboxRepo := repositories.NewOutboxRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	eventSink := events.NewSink(env.EventSink, env.EventWebhookUrl)
+	fileStorage := storage.NewStorage(env.Storage)
+	assetServie := services.NewAssetService(assetRepo, auditRepo, categoryRepo, locationRepo, outboxRepo, attachmentRepo, fileStorage, env.AssetTransitions)
+	assetImportService := services.NewAssetImportService(assetRepo, auditRepo, categoryRepo, locationRepo, outboxRepo, env.AssetImportBatchSize)
+	assetBatchService := services.NewAssetBatchService(assetRepo, auditRepo, categoryRepo, locationRepo, outboxRepo, env.AssetBatchMaxItems)
+	assetExportService := services.NewAssetExportService(assetRepo, categoryRepo, locationRepo)
+	auditService := services.NewAuditService(auditRepo)
+	categoryService := services.NewCategoryService(categoryRepo, auditRepo)
+	locationService := services.NewLocationService(locationRepo, assetRepo, auditRepo)
+	assignmentService := services.NewAssignmentService(assignmentRepo, assetRepo, auditRepo)
+	maintenanceService := services.NewMaintenanceService(maintenanceRepo, assetRepo, auditRepo)
+	warrantyService := services.NewWarrantyService(warrantyRepo, assetRepo, auditRepo, eventSink)
+	roleService := services.NewRoleService(roleRepo, auditRepo, env.RbacAdminSubjects, env.RbacDefaultRole)
+	apiKeyService := services.NewApiKeyService(apiKeyRepo, auditRepo)
+	webhookService := services.NewWebhookService(webhookRepo, outboxRepo, auditRepo, events.NewDeliverer(), env.WebhookMaxAttempts, env.WebhookRetryBase)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, env.IdempotencyKeyTtl)
+	attachmentService := services.NewAttachmentService(attachmentRepo, assetRepo, auditRepo, fileStorage, env.AttachmentMaxSize, env.AttachmentAllowedTypes)
 	assetHandler := handlers.NewAssetHandler(assetServie)
+	assetImportHandler := handlers.NewAssetImportHandler(assetImportService)
+	assetBatchHandler := handlers.NewAssetBatchHandler(assetBatchService)
+	assetExportHandler := handlers.NewAssetExportHandler(assetExportService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	locationHandler := handlers.NewLocationHandler(locationService)
+	assignmentHandler := handlers.NewAssignmentHandler(assignmentService)
+	maintenanceHandler := handlers.NewMaintenanceHandler(maintenanceService)
+	warrantyHandler := handlers.NewWarrantyHandler(warrantyService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService, env.AttachmentMaxSize)
+	roleHandler := handlers.NewRoleHandler(roleService)
+	apiKeyHandler := handlers.NewApiKeyHandler(apiKeyService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+	// background jobs
+	jobs.StartTrashPurge(assetServie, env.TrashRetentionDays)
+	jobs.StartWarrantyExpiryCheck(warrantyService, env.WarrantyNoticeDays)
+	jobs.StartWebhookDispatch(webhookService, env.WebhookDispatchInterval)
+	jobs.StartIdempotencyCleanup(idempotencyService)
+
+	// roles of the authenticated caller, checked by RequirePermission on each route
+	if env.Auth.Enabled {
+		route.Use(middlewares.LoadRoles(roleService))
+	}
+	// retries of mutations with an Idempotency-Key get the response of the first request
+	route.Use(middlewares.Idempotency(idempotencyService, handlers.MaxRequestBodySize(env.AttachmentMaxSize)))
 
 	path := "api/v1"
 	// Swagger
@@ -24,9 +84,83 @@ func Build(route *gin.Engine, db *gorm.DB) {
 	docs.SwaggerInfo.BasePath = path
 	route.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 
-	route.POST(path+"/assets", assetHandler.CreateAsset)
-	route.GET(path+"/assets", assetHandler.GetAssets)
-	route.GET(path+"/assets/:id", assetHandler.GetAssetById)
-	route.PUT(path+"/assets/:id", assetHandler.UpdateAsset)
-	route.DELETE(path+"/assets/:id", assetHandler.DeleteAsset)
+	route.POST(path+"/assets", middlewares.RequirePermission(common.PermissionCreate), assetHandler.CreateAsset)
+	route.GET(path+"/assets", middlewares.RequirePermission(common.PermissionRead), assetHandler.GetAssets)
+	route.POST(path+"/assets:action", middlewares.Actions(map[string]gin.HandlersChain{
+		":batchCreate": {middlewares.RequirePermission(common.PermissionCreate), assetBatchHandler.BatchCreateAssets},
+		":batchUpdate": {middlewares.RequirePermission(common.PermissionUpdate), assetBatchHandler.BatchUpdateAssets},
+		":batchDelete": {middlewares.RequirePermission(common.PermissionDelete), assetBatchHandler.BatchDeleteAssets},
+	}))
+	route.GET(path+"/assets/trash", middlewares.RequirePermission(common.PermissionRead), assetHandler.GetDeletedAssets)
+	route.POST(path+"/assets/import", middlewares.RequirePermission(common.PermissionImport), assetImportHandler.ImportAssets)
+	route.GET(path+"/assets/export", middlewares.RequirePermission(common.PermissionExport), assetExportHandler.ExportAssets)
+	route.GET(path+"/assets/:id", middlewares.RequirePermission(common.PermissionRead), assetHandler.GetAssetById)
+	route.PUT(path+"/assets/:id", middlewares.RequirePermission(common.PermissionUpdate), assetHandler.UpdateAsset)
+	route.PATCH(path+"/assets/:id", middlewares.RequirePermission(common.PermissionUpdate), assetHandler.PatchAsset)
+	route.DELETE(path+"/assets/:id", middlewares.RequirePermission(common.PermissionDelete), assetHandler.DeleteAsset)
+	route.GET(path+"/assets/:id/depreciation-schedule", middlewares.RequirePermission(common.PermissionRead), assetHandler.GetDepreciationSchedule)
+	route.POST(path+"/assets/:id/restore", middlewares.RequirePermission(common.PermissionDelete), assetHandler.RestoreAsset)
+	route.DELETE(path+"/assets/:id/purge", middlewares.RequirePermission(common.PermissionDelete), assetHandler.PurgeAsset)
+	route.GET(path+"/assets/:id/history", middlewares.RequirePermission(common.PermissionRead), auditHandler.GetAssetHistory)
+	route.POST(path+"/assets/:id/checkout", middlewares.RequirePermission(common.PermissionUpdate), assignmentHandler.CheckoutAsset)
+	route.POST(path+"/assets/:id/checkin", middlewares.RequirePermission(common.PermissionUpdate), assignmentHandler.CheckinAsset)
+	route.GET(path+"/assets/:id/assignments", middlewares.RequirePermission(common.PermissionRead), assignmentHandler.GetAssignments)
+	route.POST(path+"/assets/:id/transitions", middlewares.RequirePermission(common.PermissionUpdate), assetHandler.TransitionAsset)
+	route.GET(path+"/assets/:id/transitions", middlewares.RequirePermission(common.PermissionRead), assetHandler.GetAssetTransitions)
+	route.POST(path+"/assets/:id/maintenance", middlewares.RequirePermission(common.PermissionCreate), maintenanceHandler.CreateMaintenanceRecord)
+	route.GET(path+"/assets/:id/maintenance", middlewares.RequirePermission(common.PermissionRead), maintenanceHandler.GetMaintenanceRecords)
+	route.POST(path+"/assets/:id/maintenance-plans", middlewares.RequirePermission(common.PermissionCreate), maintenanceHandler.CreateMaintenancePlan)
+	route.GET(path+"/assets/:id/maintenance-plans", middlewares.RequirePermission(common.PermissionRead), maintenanceHandler.GetMaintenancePlans)
+	route.POST(path+"/assets/:id/warranties", middlewares.RequirePermission(common.PermissionCreate), warrantyHandler.CreateWarranty)
+	route.GET(path+"/assets/:id/warranties", middlewares.RequirePermission(common.PermissionRead), warrantyHandler.GetWarranties)
+	route.POST(path+"/assets/:id/attachments", middlewares.RequirePermission(common.PermissionCreate), attachmentHandler.UploadAttachment)
+	route.GET(path+"/assets/:id/attachments", middlewares.RequirePermission(common.PermissionRead), attachmentHandler.GetAttachments)
+
+	route.POST(path+"/categories", middlewares.RequirePermission(common.PermissionCreate), categoryHandler.CreateCategory)
+	route.GET(path+"/categories", middlewares.RequirePermission(common.PermissionRead), categoryHandler.GetCategories)
+	route.GET(path+"/categories/tree", middlewares.RequirePermission(common.PermissionRead), categoryHandler.GetCategoryTree)
+	route.GET(path+"/categories/:id", middlewares.RequirePermission(common.PermissionRead), categoryHandler.GetCategoryById)
+	route.PUT(path+"/categories/:id", middlewares.RequirePermission(common.PermissionUpdate), categoryHandler.UpdateCategory)
+	route.DELETE(path+"/categories/:id", middlewares.RequirePermission(common.PermissionDelete), categoryHandler.DeleteCategory)
+
+	route.POST(path+"/locations", middlewares.RequirePermission(common.PermissionCreate), locationHandler.CreateLocation)
+	route.GET(path+"/locations", middlewares.RequirePermission(common.PermissionRead), locationHandler.GetLocations)
+	route.GET(path+"/locations/tree", middlewares.RequirePermission(common.PermissionRead), locationHandler.GetLocationTree)
+	route.GET(path+"/locations/:id", middlewares.RequirePermission(common.PermissionRead), locationHandler.GetLocationById)
+	route.PUT(path+"/locations/:id", middlewares.RequirePermission(common.PermissionUpdate), locationHandler.UpdateLocation)
+	route.DELETE(path+"/locations/:id", middlewares.RequirePermission(common.PermissionDelete), locationHandler.DeleteLocation)
+	route.GET(path+"/locations/:id/assets", middlewares.RequirePermission(common.PermissionRead), locationHandler.GetLocationAssets)
+
+	route.DELETE(path+"/maintenance-plans/:id", middlewares.RequirePermission(common.PermissionDelete), maintenanceHandler.DeleteMaintenancePlan)
+	route.GET(path+"/maintenance/due", middlewares.RequirePermission(common.PermissionRead), maintenanceHandler.GetDueMaintenance)
+
+	route.GET(path+"/warranties/expiring", middlewares.RequirePermission(common.PermissionRead), warrantyHandler.GetExpiringWarranties)
+	route.GET(path+"/warranties/:id", middlewares.RequirePermission(common.PermissionRead), warrantyHandler.GetWarrantyById)
+	route.PUT(path+"/warranties/:id", middlewares.RequirePermission(common.PermissionUpdate), warrantyHandler.UpdateWarranty)
+	route.DELETE(path+"/warranties/:id", middlewares.RequirePermission(common.PermissionDelete), warrantyHandler.DeleteWarranty)
+
+	route.GET(path+"/attachments/:id/download", middlewares.RequirePermission(common.PermissionRead), attachmentHandler.DownloadAttachment)
+	route.GET(path+"/attachments/:id/thumbnail", middlewares.RequirePermission(common.PermissionRead), attachmentHandler.DownloadThumbnail)
+	route.DELETE(path+"/attachments/:id", middlewares.RequirePermission(common.PermissionDelete), attachmentHandler.DeleteAttachment)
+
+	route.GET(path+"/audit", middlewares.RequirePermission(common.PermissionRead), auditHandler.GetAuditLogs)
+
+	route.GET(path+"/roles", middlewares.RequirePermission(common.PermissionRead), roleHandler.GetRoles)
+	route.GET(path+"/roles/assignments", middlewares.RequirePermission(common.PermissionManageRoles), roleHandler.GetRoleAssignments)
+	route.POST(path+"/roles/assignments", middlewares.RequirePermission(common.PermissionManageRoles), roleHandler.AssignRole)
+	route.DELETE(path+"/roles/assignments/:id", middlewares.RequirePermission(common.PermissionManageRoles), roleHandler.RevokeRole)
+
+	route.POST(path+"/api-keys", middlewares.RequirePermission(common.PermissionManageApiKeys), apiKeyHandler.CreateApiKey)
+	route.GET(path+"/api-keys", middlewares.RequirePermission(common.PermissionManageApiKeys), apiKeyHandler.GetApiKeys)
+	route.DELETE(path+"/api-keys/:id", middlewares.RequirePermission(common.PermissionManageApiKeys), apiKeyHandler.RevokeApiKey)
+	route.POST(path+"/api-keys/:id/rotate", middlewares.RequirePermission(common.PermissionManageApiKeys), apiKeyHandler.RotateApiKey)
+
+	route.POST(path+"/webhooks", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.CreateWebhook)
+	route.GET(path+"/webhooks", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.GetWebhooks)
+	route.GET(path+"/webhooks/deliveries/:id", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.GetWebhookDeliveryById)
+	route.POST(path+"/webhooks/deliveries/:id/redeliver", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.RedeliverWebhookDelivery)
+	route.GET(path+"/webhooks/:id", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.GetWebhookById)
+	route.PUT(path+"/webhooks/:id", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.UpdateWebhook)
+	route.DELETE(path+"/webhooks/:id", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.DeleteWebhook)
+	route.GET(path+"/webhooks/:id/deliveries", middlewares.RequirePermission(common.PermissionManageWebhooks), webhookHandler.GetWebhookDeliveries)
 }
@@ -1,6 +1,11 @@
 package server
 
 import (
+	"assets-api-go/internal/auth"
+	"assets-api-go/internal/config"
+	"assets-api-go/internal/middlewares"
+	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,9 +21,15 @@ func (r *RestApi) Serve(address string) error {
 	return r.router.Run(address)
 }
 
-func NewRestApi(db *gorm.DB) *RestApi {
+func NewRestApi(db *gorm.DB, env *config.EnviConfig) *RestApi {
 
 	router := gin.Default()
+	router.Use(middlewares.RequestContext())
+	if env.Auth.Enabled {
+		apiKeyService := services.NewApiKeyService(repositories.NewApiKeyRepository(db), repositories.NewAuditRepository(db))
+		router.Use(middlewares.Authenticate(auth.NewVerifier(env.Auth), apiKeyService, env.Auth.PublicPaths))
+	}
+	router.Use(middlewares.ResolveTenant(env.TenancyEnabled, env.TenantHeader, env.Auth.PublicPaths))
 	api := &RestApi{
 		router,
 	}
@@ -26,7 +37,7 @@ func NewRestApi(db *gorm.DB) *RestApi {
 	// health check
 	router.GET("/", HealthCheck)
 
-	Build(router, db)
+	Build(router, db, env)
 	return api
 }
 
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/tenancy"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ApiKeyServiceInterface interface {
+	CreateApiKey(ctx context.Context, input *dto.ApiKeyInputDto) (code int, response *dto.BaseResponse)
+	GetApiKeys(ctx context.Context, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	RevokeApiKey(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	RotateApiKey(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	AuthenticateApiKey(ctx context.Context, key string) (*common.Principal, error)
+}
+
+// ErrInvalidApiKey is returned for unknown, revoked and expired keys alike
+var ErrInvalidApiKey = errors.New("invalid API key")
+
+const (
+	// apiKeyPrefix starts every key so leaked keys are easy to recognise, e.g. by secret scanners
+	apiKeyPrefix = "ak"
+	// apiKeyTouchInterval is how often the last used time of a busy key is written
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeyService struct {
+	apiKeyRepo repositories.ApiKeyRepositoryInterface
+	auditRepo  repositories.AuditRepositoryInterface
+	now        func() time.Time
+}
+
+func NewApiKeyService(apiKeyRepo repositories.ApiKeyRepositoryInterface, auditRepo repositories.AuditRepositoryInterface) ApiKeyServiceInterface {
+	return &apiKeyService{apiKeyRepo: apiKeyRepo, auditRepo: auditRepo, now: time.Now}
+}
+
+// CreateApiKey stores a new key and returns it, the key cannot be read again afterwards
+func (s *apiKeyService) CreateApiKey(ctx context.Context, input *dto.ApiKeyInputDto) (code int, response *dto.BaseResponse) {
+	apiKey := &models.ApiKey{
+		Name:      strings.TrimSpace(input.Name),
+		CreatedBy: common.ActorFromContext(ctx),
+	}
+	if apiKey.Name == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Name is required",
+		}
+	}
+
+	scopes, err := validateApiKeyScopes(input.Scopes)
+	if err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+	apiKey.Scopes = strings.Join(scopes, ",")
+
+	if input.ExpiresAt != "" {
+		expiresAt, err := parseFilterTime(input.ExpiresAt, true)
+		if err != nil {
+			log.Println("[apiKeyService][CreateApiKey] error parsing expires_at :", err)
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Invalid expires_at format",
+			}
+		}
+		if !expiresAt.After(s.now()) {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "expires_at must be in the future",
+			}
+		}
+		expiresAt = expiresAt.UTC()
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	key, err := newApiKeySecret(apiKey)
+	if err != nil {
+		log.Println("[apiKeyService][CreateApiKey] error generate key :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	tx := s.apiKeyRepo.StartTransaction(ctx)
+	apiKey, err = s.apiKeyRepo.CreateApiKey(ctx, apiKey, tx)
+	if err != nil {
+		log.Println("[apiKeyService][CreateApiKey] error create api key :", err)
+		err = s.apiKeyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[apiKeyService][CreateApiKey] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, apiKey.Id, nil, apiKeyAuditFields(apiKey))
+	if err != nil {
+		log.Println("[apiKeyService][CreateApiKey] error record audit :", err)
+		err = s.apiKeyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[apiKeyService][CreateApiKey] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.apiKeyRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[apiKeyService][CreateApiKey] error commit transaction :", err)
+		err = s.apiKeyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[apiKeyService][CreateApiKey] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    &dto.ApiKeySecretOutputDto{ApiKeyOutputDto: *apiKeyOutput(apiKey), Key: key},
+	}
+}
+
+func (s *apiKeyService) GetApiKeys(ctx context.Context, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	apiKeys, count, err := s.apiKeyRepo.GetApiKeys(ctx, pagination)
+	if err != nil {
+		log.Println("[apiKeyService][GetApiKeys] error get api keys :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	apiKeysRes := []*dto.ApiKeyOutputDto{}
+	for _, v := range apiKeys {
+		apiKeysRes = append(apiKeysRes, apiKeyOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = apiKeysRes
+	return http.StatusOK, pagination
+}
+
+// RevokeApiKey disables a key for good, it stays listed with its revocation time
+func (s *apiKeyService) RevokeApiKey(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	apiKey, code, response := s.getActiveApiKey(ctx, id, "RevokeApiKey")
+	if response != nil {
+		return code, response
+	}
+
+	before := apiKeyAuditFields(apiKey)
+	revokedAt := s.now().UTC()
+	apiKey.RevokedAt = &revokedAt
+
+	code, response = s.saveApiKey(ctx, apiKey, common.AuditActionDelete, before, "RevokeApiKey")
+	if response != nil {
+		return code, response
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// RotateApiKey replaces the key of an API key, keeping its name, scopes and expiry. The
+// previous key stops working at once.
+func (s *apiKeyService) RotateApiKey(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	apiKey, code, response := s.getActiveApiKey(ctx, id, "RotateApiKey")
+	if response != nil {
+		return code, response
+	}
+
+	before := apiKeyAuditFields(apiKey)
+	key, err := newApiKeySecret(apiKey)
+	if err != nil {
+		log.Println("[apiKeyService][RotateApiKey] error generate key :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+	apiKey.LastUsedAt = nil
+
+	code, response = s.saveApiKey(ctx, apiKey, common.AuditActionUpdate, before, "RotateApiKey")
+	if response != nil {
+		return code, response
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    &dto.ApiKeySecretOutputDto{ApiKeyOutputDto: *apiKeyOutput(apiKey), Key: key},
+	}
+}
+
+// AuthenticateApiKey returns the principal of a valid key and records its use. It runs before
+// the tenant is known, the key belongs to the tenant it was created in.
+func (s *apiKeyService) AuthenticateApiKey(ctx context.Context, key string) (*common.Principal, error) {
+	// the key names the tenant, so it is looked up across all of them
+	ctx = tenancy.WithoutTenant(ctx)
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix {
+		return nil, ErrInvalidApiKey
+	}
+
+	apiKey, err := s.apiKeyRepo.GetApiKeyByAttribute(ctx, map[string]interface{}{
+		"prefix": parts[1],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	if apiKey == nil || subtle.ConstantTimeCompare([]byte(hashApiKey(key)), []byte(apiKey.KeyHash)) != 1 {
+		return nil, ErrInvalidApiKey
+	}
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)) {
+		return nil, ErrInvalidApiKey
+	}
+
+	if err = s.apiKeyRepo.TouchApiKey(ctx, apiKey.Id, now, apiKeyTouchInterval); err != nil {
+		log.Println("[apiKeyService][AuthenticateApiKey] error update last used :", err)
+	}
+
+	return &common.Principal{
+		Subject:  "api-key:" + apiKey.Prefix,
+		Name:     apiKey.Name,
+		Scopes:   strings.Split(apiKey.Scopes, ","),
+		ApiKeyId: apiKey.Id,
+		TenantId: apiKey.TenantId,
+	}, nil
+}
+
+func (s *apiKeyService) getActiveApiKey(ctx context.Context, id string, method string) (apiKey *models.ApiKey, code int, response *dto.BaseResponse) {
+	apiKey, err := s.apiKeyRepo.GetApiKeyByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Printf("[apiKeyService][%s] error get api key : %v\n", method, err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if apiKey == nil {
+		return nil, http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "API key not found",
+		}
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "API key is revoked",
+		}
+	}
+	return apiKey, 0, nil
+}
+
+func (s *apiKeyService) saveApiKey(ctx context.Context, apiKey *models.ApiKey, action string, before map[string]interface{}, method string) (code int, response *dto.BaseResponse) {
+	tx := s.apiKeyRepo.StartTransaction(ctx)
+	_, err := s.apiKeyRepo.UpdateApiKey(ctx, apiKey, tx)
+	if err == nil {
+		err = s.recordAudit(ctx, tx, action, apiKey.Id, before, apiKeyAuditFields(apiKey))
+	}
+	if err == nil {
+		err = s.apiKeyRepo.CommitTransaction(tx)
+	}
+	if err != nil {
+		log.Printf("[apiKeyService][%s] error update api key : %v\n", method, err)
+		err = s.apiKeyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Printf("[apiKeyService][%s] error rollback transaction : %v\n", method, err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+	return 0, nil
+}
+
+func (s *apiKeyService) recordAudit(ctx context.Context, tx *gorm.DB, action string, apiKeyId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityApiKey, apiKeyId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// newApiKeySecret gives the API key a new random key of the form ak_<prefix>_<secret> and
+// sets its prefix and hash
+func newApiKeySecret(apiKey *models.ApiKey) (string, error) {
+	prefix := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	apiKey.Prefix = hex.EncodeToString(prefix)
+	key := apiKeyPrefix + "_" + apiKey.Prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.KeyHash = hashApiKey(key)
+	return key, nil
+}
+
+// hashApiKey is a plain SHA-256, the 256 bit random secret needs no slow password hash
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func validateApiKeyScopes(scopes []string) ([]string, error) {
+	valid := []string{}
+	seen := map[string]bool{}
+	for _, v := range scopes {
+		v = strings.TrimSpace(v)
+		if _, ok := common.ScopePermissions[v]; !ok {
+			return nil, fmt.Errorf("Invalid scope %s, allowed scopes are %s", v, strings.Join(common.Scopes, ", "))
+		}
+		if !seen[v] {
+			seen[v] = true
+			valid = append(valid, v)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, errors.New("At least one scope is required")
+	}
+	return valid, nil
+}
+
+func apiKeyAuditFields(apiKey *models.ApiKey) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       apiKey.Name,
+		"prefix":     apiKey.Prefix,
+		"scopes":     apiKey.Scopes,
+		"expires_at": formatOptionalTime(apiKey.ExpiresAt),
+		"revoked_at": formatOptionalTime(apiKey.RevokedAt),
+	}
+}
+
+func apiKeyOutput(apiKey *models.ApiKey) *dto.ApiKeyOutputDto {
+	return &dto.ApiKeyOutputDto{
+		Id:         apiKey.Id,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     strings.Split(apiKey.Scopes, ","),
+		ExpiresAt:  formatOptionalTime(apiKey.ExpiresAt),
+		LastUsedAt: formatOptionalTime(apiKey.LastUsedAt),
+		RevokedAt:  formatOptionalTime(apiKey.RevokedAt),
+		CreatedBy:  apiKey.CreatedBy,
+		CreatedAt:  apiKey.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
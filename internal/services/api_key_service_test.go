@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateApiKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *dto.ApiKeyInputDto
+		mockSetup     func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Success",
+			input: &dto.ApiKeyInputDto{Name: " billing ", Scopes: []string{common.ScopeAssetsRead, common.ScopeAssetsRead}, ExpiresAt: "2999-01-31"},
+			mockSetup: func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockApiKeyRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockApiKeyRepo.EXPECT().CreateApiKey(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, apiKey *models.ApiKey, tx *gorm.DB) (*models.ApiKey, error) {
+					assert.Equal(t, "billing", apiKey.Name)
+					assert.Equal(t, common.ScopeAssetsRead, apiKey.Scopes)
+					assert.Equal(t, "admin-1", apiKey.CreatedBy)
+					assert.Equal(t, time.Date(2999, 2, 1, 0, 0, 0, 0, time.UTC), *apiKey.ExpiresAt)
+					assert.Len(t, apiKey.Prefix, 12)
+					assert.Len(t, apiKey.KeyHash, 64)
+					apiKey.Id = "api-key-id"
+					return apiKey, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityApiKey, auditLog.EntityType)
+					assert.NotContains(t, auditLog.Changes, "key_hash")
+					return nil
+				})
+				mockApiKeyRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Blank name",
+			input: &dto.ApiKeyInputDto{Name: " ", Scopes: []string{common.ScopeAssetsRead}},
+			mockSetup: func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Name is required",
+		},
+		{
+			name:  "Unknown scope",
+			input: &dto.ApiKeyInputDto{Name: "billing", Scopes: []string{"assets:admin"}},
+			mockSetup: func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid scope assets:admin, allowed scopes are assets:read, assets:write, assets:value",
+		},
+		{
+			name:  "No scope",
+			input: &dto.ApiKeyInputDto{Name: "billing"},
+			mockSetup: func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "At least one scope is required",
+		},
+		{
+			name:  "Expiry in the past",
+			input: &dto.ApiKeyInputDto{Name: "billing", Scopes: []string{common.ScopeAssetsRead}, ExpiresAt: "2020-01-01"},
+			mockSetup: func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "expires_at must be in the future",
+		},
+		{
+			name:  "Error create api key",
+			input: &dto.ApiKeyInputDto{Name: "billing", Scopes: []string{common.ScopeAssetsWrite}},
+			mockSetup: func(mockApiKeyRepo *repositories.MockApiKeyRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockApiKeyRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockApiKeyRepo.EXPECT().CreateApiKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				mockApiKeyRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApiKeyRepo := repositories.NewMockApiKeyRepositoryInterface(ctrl)
+			mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+			tt.mockSetup(mockApiKeyRepo, mockAuditRepo)
+			service := NewApiKeyService(mockApiKeyRepo, mockAuditRepo)
+
+			code, response := service.CreateApiKey(common.WithActor(context.Background(), "admin-1"), tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedError, response.ErrorDescription)
+			if tt.expectedCode == http.StatusCreated {
+				output := response.Data.(*dto.ApiKeySecretOutputDto)
+				assert.Equal(t, "api-key-id", output.Id)
+				assert.Equal(t, []string{common.ScopeAssetsRead}, output.Scopes)
+				assert.True(t, strings.HasPrefix(output.Key, "ak_"+output.Prefix+"_"))
+			}
+		})
+	}
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	apiKey := &models.ApiKey{Id: "api-key-id", Name: "billing", Scopes: common.ScopeAssetsRead + "," + common.ScopeAssetsWrite}
+	key, err := newApiKeySecret(apiKey)
+	assert.NoError(t, err)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		key           string
+		stored        *models.ApiKey
+		expectedError error
+	}{
+		{name: "Valid key", key: key, stored: apiKey},
+		{name: "Malformed key", key: "not-a-key", expectedError: ErrInvalidApiKey},
+		{name: "Unknown prefix", key: key, stored: nil, expectedError: ErrInvalidApiKey},
+		{name: "Wrong secret", key: key + "x", stored: apiKey, expectedError: ErrInvalidApiKey},
+		{name: "Revoked key", key: key, stored: &models.ApiKey{Id: apiKey.Id, Prefix: apiKey.Prefix, KeyHash: apiKey.KeyHash, RevokedAt: &past}, expectedError: ErrInvalidApiKey},
+		{name: "Expired key", key: key, stored: &models.ApiKey{Id: apiKey.Id, Prefix: apiKey.Prefix, KeyHash: apiKey.KeyHash, ExpiresAt: &past}, expectedError: ErrInvalidApiKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApiKeyRepo := repositories.NewMockApiKeyRepositoryInterface(ctrl)
+			if strings.HasPrefix(tt.key, "ak_") {
+				mockApiKeyRepo.EXPECT().GetApiKeyByAttribute(gomock.Any(), map[string]interface{}{"prefix": apiKey.Prefix}).Return(tt.stored, nil)
+			}
+			if tt.expectedError == nil {
+				mockApiKeyRepo.EXPECT().TouchApiKey(gomock.Any(), apiKey.Id, gomock.Any(), apiKeyTouchInterval).Return(nil)
+			}
+			service := NewApiKeyService(mockApiKeyRepo, repositories.NewMockAuditRepositoryInterface(ctrl))
+
+			principal, err := service.AuthenticateApiKey(context.Background(), tt.key)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, &common.Principal{
+					Subject:  "api-key:" + apiKey.Prefix,
+					Name:     "billing",
+					Scopes:   []string{common.ScopeAssetsRead, common.ScopeAssetsWrite},
+					ApiKeyId: "api-key-id",
+				}, principal)
+				assert.True(t, common.HasPermission(common.WithPrincipal(context.Background(), principal), common.PermissionDelete))
+				assert.False(t, common.HasPermission(common.WithPrincipal(context.Background(), principal), common.PermissionValue))
+			}
+		})
+	}
+}
+
+func TestRotateApiKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := repositories.NewMockApiKeyRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewApiKeyService(mockApiKeyRepo, mockAuditRepo)
+
+	revokedAt := time.Now()
+	mockApiKeyRepo.EXPECT().GetApiKeyByAttribute(gomock.Any(), map[string]interface{}{"id": "revoked-id"}).Return(&models.ApiKey{Id: "revoked-id", RevokedAt: &revokedAt}, nil)
+	code, response := service.RotateApiKey(context.Background(), "revoked-id")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "API key is revoked", response.ErrorDescription)
+
+	apiKey := &models.ApiKey{Id: "api-key-id", Name: "billing", Scopes: common.ScopeAssetsRead}
+	oldKey, _ := newApiKeySecret(apiKey)
+	oldHash := apiKey.KeyHash
+	mockApiKeyRepo.EXPECT().GetApiKeyByAttribute(gomock.Any(), map[string]interface{}{"id": "api-key-id"}).Return(apiKey, nil)
+	mockApiKeyRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+	mockApiKeyRepo.EXPECT().UpdateApiKey(gomock.Any(), apiKey, gomock.Any()).Return(apiKey, nil)
+	mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+		assert.Equal(t, common.AuditActionUpdate, auditLog.Action)
+		return nil
+	})
+	mockApiKeyRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+	code, response = service.RotateApiKey(context.Background(), "api-key-id")
+	assert.Equal(t, http.StatusOK, code)
+	output := response.Data.(*dto.ApiKeySecretOutputDto)
+	assert.NotEqual(t, oldKey, output.Key)
+	assert.NotEqual(t, oldHash, apiKey.KeyHash)
+	assert.Equal(t, hashApiKey(output.Key), apiKey.KeyHash)
+}
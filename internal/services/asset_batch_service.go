@@ -0,0 +1,482 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AssetBatchServiceInterface interface {
+	BatchCreateAssets(ctx context.Context, input *dto.AssetBatchCreateInputDto) (code int, response *dto.BaseResponse)
+	BatchUpdateAssets(ctx context.Context, input *dto.AssetBatchUpdateInputDto) (code int, response *dto.BaseResponse)
+	BatchDeleteAssets(ctx context.Context, input *dto.AssetBatchDeleteInputDto) (code int, response *dto.BaseResponse)
+}
+
+type assetBatchService struct {
+	*assetService
+	// maxItems is the largest number of items a batch accepts
+	maxItems int
+}
+
+// assetBatchItem is a validated item of an atomic batch, written by an assetBatchWrite
+type assetBatchItem struct {
+	result  *dto.AssetBatchItemResultDto
+	asset   *models.Asset
+	before  map[string]interface{}
+	version int64
+}
+
+// assetBatchWrite writes an item of an atomic batch in tx
+type assetBatchWrite func(tx *gorm.DB, item *assetBatchItem) (code int, response *dto.BaseResponse)
+
+func NewAssetBatchService(assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, categoryRepo repositories.CategoryRepositoryInterface, locationRepo repositories.LocationRepositoryInterface, outboxRepo repositories.OutboxRepositoryInterface, maxItems int) AssetBatchServiceInterface {
+	return &assetBatchService{
+		assetService: &assetService{assetRepo: assetRepo, auditRepo: auditRepo, categoryRepo: categoryRepo, locationRepo: locationRepo, outboxRepo: outboxRepo},
+		maxItems:     maxItems,
+	}
+}
+
+// BatchCreateAssets creates several assets. Each item is validated like CreateAsset, in atomic
+// mode nothing is written unless all of them are valid and they are written in one transaction.
+func (s *assetBatchService) BatchCreateAssets(ctx context.Context, input *dto.AssetBatchCreateInputDto) (code int, response *dto.BaseResponse) {
+	result, code, response := s.newBatchResult(input.Mode, len(input.Items))
+	if response != nil {
+		return code, response
+	}
+
+	if result.Mode == common.AssetBatchModeBestEffort {
+		for i, v := range input.Items {
+			if v == nil {
+				setBatchItemResult(result.Items[i], http.StatusBadRequest, emptyBatchItemResponse)
+				continue
+			}
+			code, response := s.CreateAsset(ctx, v)
+			setBatchItemResult(result.Items[i], code, response)
+		}
+		return batchResponse(result, http.StatusCreated)
+	}
+
+	items := []*assetBatchItem{}
+	// name and type of the valid items, so a batch cannot hold the same asset twice
+	seen := map[string]int{}
+	for i, v := range input.Items {
+		if v == nil {
+			setBatchItemResult(result.Items[i], http.StatusBadRequest, emptyBatchItemResponse)
+			continue
+		}
+
+		asset, code, response := s.newAsset(ctx, v, "BatchCreateAssets")
+		if code == http.StatusInternalServerError {
+			return code, response
+		}
+		if response != nil {
+			setBatchItemResult(result.Items[i], code, response)
+			continue
+		}
+
+		key := asset.Name + "\x00" + asset.Type
+		if first, ok := seen[key]; ok {
+			setBatchItemResult(result.Items[i], http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: fmt.Sprintf("Asset already exist in item %d", first),
+			})
+			continue
+		}
+		seen[key] = i
+		items = append(items, &assetBatchItem{result: result.Items[i], asset: asset})
+	}
+
+	return s.writeAtomic(ctx, result, items, http.StatusCreated, "BatchCreateAssets", func(tx *gorm.DB, item *assetBatchItem) (code int, response *dto.BaseResponse) {
+		created, err := s.assetRepo.CreateAsset(ctx, item.asset, tx)
+		if err == nil {
+			item.asset = created
+			err = s.recordAudit(ctx, tx, common.AuditActionCreate, created.Id, nil, assetAuditFields(created))
+		}
+		if err == nil {
+			err = s.publishEvent(ctx, tx, common.EventAssetCreated, created, nil, assetAuditFields(created))
+		}
+		if err != nil {
+			log.Println("[assetBatchService][BatchCreateAssets] error create asset :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+		return 0, nil
+	})
+}
+
+// BatchUpdateAssets updates several assets. Each item is validated like UpdateAsset, in atomic
+// mode nothing is written unless all of them are valid and they are written in one transaction.
+func (s *assetBatchService) BatchUpdateAssets(ctx context.Context, input *dto.AssetBatchUpdateInputDto) (code int, response *dto.BaseResponse) {
+	result, code, response := s.newBatchResult(input.Mode, len(input.Items))
+	if response != nil {
+		return code, response
+	}
+
+	if result.Mode == common.AssetBatchModeBestEffort {
+		for i, v := range input.Items {
+			if v == nil {
+				setBatchItemResult(result.Items[i], http.StatusBadRequest, emptyBatchItemResponse)
+				continue
+			}
+			result.Items[i].Id = v.Id
+			code, response := s.UpdateAsset(ctx, v.Id, &v.AssetInputDto, batchIfMatch(v.Version))
+			setBatchItemResult(result.Items[i], code, response)
+		}
+		return batchResponse(result, http.StatusOK)
+	}
+
+	items := []*assetBatchItem{}
+	// ids of the valid items, each asset is validated against the state before the batch
+	seen := map[string]int{}
+	for i, v := range input.Items {
+		if v == nil {
+			setBatchItemResult(result.Items[i], http.StatusBadRequest, emptyBatchItemResponse)
+			continue
+		}
+		result.Items[i].Id = v.Id
+
+		asset, code, response := s.getBatchAsset(ctx, v.Id, v.Version, "BatchUpdateAssets")
+		if code == http.StatusInternalServerError {
+			return code, response
+		}
+		if response != nil {
+			setBatchItemResult(result.Items[i], code, response)
+			continue
+		}
+
+		if first, ok := seen[asset.Id]; ok {
+			setBatchItemResult(result.Items[i], http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: fmt.Sprintf("Asset is already updated by item %d", first),
+			})
+			continue
+		}
+
+		before := assetAuditFields(asset)
+		code, response = s.applyAssetInput(ctx, asset, &v.AssetInputDto, "BatchUpdateAssets")
+		if code == http.StatusInternalServerError {
+			return code, response
+		}
+		if response != nil {
+			setBatchItemResult(result.Items[i], code, response)
+			continue
+		}
+		seen[asset.Id] = i
+		items = append(items, &assetBatchItem{result: result.Items[i], asset: asset, before: before, version: v.Version})
+	}
+
+	return s.writeAtomic(ctx, result, items, http.StatusOK, "BatchUpdateAssets", func(tx *gorm.DB, item *assetBatchItem) (code int, response *dto.BaseResponse) {
+		updated, err := s.assetRepo.UpdateAsset(ctx, item.asset, tx)
+		if err == nil && !updated {
+			return batchConflict(item.version)
+		}
+		if err == nil {
+			err = s.recordAudit(ctx, tx, common.AuditActionUpdate, item.asset.Id, item.before, assetAuditFields(item.asset))
+		}
+		if err == nil {
+			err = s.publishEvent(ctx, tx, common.EventAssetUpdated, item.asset, item.before, assetAuditFields(item.asset))
+		}
+		if err != nil {
+			log.Println("[assetBatchService][BatchUpdateAssets] error update asset :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+		return 0, nil
+	})
+}
+
+// BatchDeleteAssets moves several assets to the trash, in atomic mode all of them or none
+func (s *assetBatchService) BatchDeleteAssets(ctx context.Context, input *dto.AssetBatchDeleteInputDto) (code int, response *dto.BaseResponse) {
+	result, code, response := s.newBatchResult(input.Mode, len(input.Items))
+	if response != nil {
+		return code, response
+	}
+
+	if result.Mode == common.AssetBatchModeBestEffort {
+		for i, v := range input.Items {
+			if v == nil {
+				setBatchItemResult(result.Items[i], http.StatusBadRequest, emptyBatchItemResponse)
+				continue
+			}
+			result.Items[i].Id = v.Id
+			code, response := s.DeleteAsset(ctx, v.Id, batchIfMatch(v.Version))
+			setBatchItemResult(result.Items[i], code, response)
+		}
+		return batchResponse(result, http.StatusOK)
+	}
+
+	items := []*assetBatchItem{}
+	seen := map[string]int{}
+	for i, v := range input.Items {
+		if v == nil {
+			setBatchItemResult(result.Items[i], http.StatusBadRequest, emptyBatchItemResponse)
+			continue
+		}
+		result.Items[i].Id = v.Id
+
+		asset, code, response := s.getBatchAsset(ctx, v.Id, v.Version, "BatchDeleteAssets")
+		if code == http.StatusInternalServerError {
+			return code, response
+		}
+		if response != nil {
+			setBatchItemResult(result.Items[i], code, response)
+			continue
+		}
+
+		if first, ok := seen[asset.Id]; ok {
+			setBatchItemResult(result.Items[i], http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: fmt.Sprintf("Asset is already deleted by item %d", first),
+			})
+			continue
+		}
+		seen[asset.Id] = i
+		items = append(items, &assetBatchItem{result: result.Items[i], asset: asset, version: v.Version})
+	}
+
+	return s.writeAtomic(ctx, result, items, http.StatusOK, "BatchDeleteAssets", func(tx *gorm.DB, item *assetBatchItem) (code int, response *dto.BaseResponse) {
+		deleted, err := s.assetRepo.DeleteAsset(ctx, item.asset, tx)
+		if err == nil && !deleted {
+			return batchConflict(item.version)
+		}
+		before := map[string]interface{}{"deleted_at": nil}
+		after := map[string]interface{}{"deleted_at": time.Now().UTC().Format("2006-01-02 15:04:05")}
+		if err == nil {
+			err = s.recordAudit(ctx, tx, common.AuditActionDelete, item.asset.Id, before, after)
+		}
+		if err == nil {
+			err = s.publishEvent(ctx, tx, common.EventAssetDeleted, item.asset, before, after)
+		}
+		if err != nil {
+			log.Println("[assetBatchService][BatchDeleteAssets] error delete asset :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+		// deleted assets are not returned
+		item.asset = nil
+		return 0, nil
+	})
+}
+
+// newBatchResult checks the mode, atomic when empty, and the number of items of a batch
+func (s *assetBatchService) newBatchResult(mode string, count int) (result *dto.AssetBatchResultDto, code int, response *dto.BaseResponse) {
+	if mode == "" {
+		mode = common.AssetBatchModeAtomic
+	}
+	if mode != common.AssetBatchModeAtomic && mode != common.AssetBatchModeBestEffort {
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid mode, allowed modes are " + strings.Join(common.AssetBatchModes, ", "),
+		}
+	}
+
+	if count == 0 {
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Items are required",
+		}
+	}
+	if count > s.maxItems {
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: fmt.Sprintf("A batch must have at most %d items", s.maxItems),
+		}
+	}
+
+	result = &dto.AssetBatchResultDto{Mode: mode, Total: count, Items: make([]*dto.AssetBatchItemResultDto, count)}
+	for i := range result.Items {
+		result.Items[i] = &dto.AssetBatchItemResultDto{Index: i}
+	}
+	return result, 0, nil
+}
+
+// getBatchAsset returns the asset of an update or delete item, of the given version unless it is 0
+func (s *assetBatchService) getBatchAsset(ctx context.Context, id string, version int64, method string) (asset *models.Asset, code int, response *dto.BaseResponse) {
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[assetBatchService]["+method+"] error get existing asset :", err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return nil, http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	if version != 0 && version != asset.Version {
+		return nil, http.StatusPreconditionFailed, &dto.BaseResponse{
+			Error:            common.PreconditionFailed,
+			ErrorDescription: "Asset was changed since it was read",
+		}
+	}
+
+	return asset, 0, nil
+}
+
+// writeAtomic writes the items of an atomic batch in one transaction. Nothing is written when an
+// item is invalid or fails to be written, the items that did not fail themselves then get 424.
+func (s *assetBatchService) writeAtomic(ctx context.Context, result *dto.AssetBatchResultDto, items []*assetBatchItem, successCode int, method string, write assetBatchWrite) (code int, response *dto.BaseResponse) {
+	if len(items) < result.Total {
+		return abortBatch(result, http.StatusBadRequest, common.BadRequest)
+	}
+
+	tx := s.assetRepo.StartTransaction(ctx)
+	for _, item := range items {
+		code, response := write(tx, item)
+		if response != nil {
+			err := s.assetRepo.RollbackTransaction(tx)
+			if err != nil {
+				log.Println("[assetBatchService]["+method+"] error rollback transaction :", err)
+			}
+			setBatchItemResult(item.result, code, response)
+			return abortBatch(result, code, response.Error)
+		}
+	}
+
+	err := s.assetRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[assetBatchService]["+method+"] error commit transaction :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetBatchService]["+method+"] error rollback transaction :", err)
+		}
+		return abortBatch(result, http.StatusInternalServerError, common.InternalServerError)
+	}
+
+	for _, item := range items {
+		item.result.Status = successCode
+		if asset := item.asset; asset != nil {
+			item.result.Id = asset.Id
+			item.result.Data = &dto.AssetOutputDto{
+				Id:              asset.Id,
+				Name:            asset.Name,
+				Type:            asset.Type,
+				CategoryId:      asset.CategoryId,
+				LocationId:      asset.LocationId,
+				Status:          asset.Status,
+				Value:           asset.Value,
+				AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02 15:04:05"),
+				CreatedAt:       asset.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt:       asset.UpdatedAt.Format("2006-01-02 15:04:05"),
+				Version:         asset.Version,
+			}
+		}
+	}
+	return batchResponse(result, successCode)
+}
+
+// abortBatch reports an atomic batch of which nothing was written
+func abortBatch(result *dto.AssetBatchResultDto, code int, errorCode string) (int, *dto.BaseResponse) {
+	failed := 0
+	for _, v := range result.Items {
+		if v.Status != 0 {
+			failed++
+			continue
+		}
+		if code == http.StatusInternalServerError {
+			v.Status, v.Error = http.StatusInternalServerError, "Something went wrong"
+			continue
+		}
+		v.Status, v.Error = http.StatusFailedDependency, "Not written because another item of the batch failed"
+	}
+	result.Succeeded, result.Failed = 0, result.Total
+
+	if code == http.StatusInternalServerError {
+		return code, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong, nothing was written",
+			Data:             result,
+		}
+	}
+	return code, &dto.BaseResponse{
+		Error:            errorCode,
+		ErrorDescription: fmt.Sprintf("%d of %d items failed, nothing was written", failed, result.Total),
+		Data:             result,
+	}
+}
+
+// batchResponse counts the outcomes of a written batch, a best effort batch with failed items
+// returns 207
+func batchResponse(result *dto.AssetBatchResultDto, successCode int) (int, *dto.BaseResponse) {
+	result.Succeeded, result.Failed = 0, 0
+	for _, v := range result.Items {
+		if v.Status < http.StatusBadRequest {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	code := successCode
+	if result.Failed > 0 {
+		code = http.StatusMultiStatus
+	}
+	return code, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    result,
+	}
+}
+
+// setBatchItemResult copies the outcome of an item, as returned for a single request, to its result
+func setBatchItemResult(result *dto.AssetBatchItemResultDto, code int, response *dto.BaseResponse) {
+	result.Status = code
+	if response.Error != "" {
+		result.Error = response.ErrorDescription
+		result.Errors = response.Errors
+		return
+	}
+	if data, ok := response.Data.(*dto.AssetOutputDto); ok {
+		result.Id = data.Id
+		result.Data = data
+	}
+}
+
+// emptyBatchItemResponse is the outcome of a null item
+var emptyBatchItemResponse = &dto.BaseResponse{
+	Error:            common.BadRequest,
+	ErrorDescription: "Item is empty",
+}
+
+// batchIfMatch is the If-Match of an item version, none when it is 0
+func batchIfMatch(version int64) string {
+	if version == 0 {
+		return ""
+	}
+	return common.ETag(version)
+}
+
+// batchConflict reports an item whose asset another request changed after it was validated
+func batchConflict(version int64) (int, *dto.BaseResponse) {
+	if version != 0 {
+		return http.StatusPreconditionFailed, &dto.BaseResponse{
+			Error:            common.PreconditionFailed,
+			ErrorDescription: "Asset was changed since it was read",
+		}
+	}
+	return http.StatusConflict, &dto.BaseResponse{
+		Error:            common.Conflict,
+		ErrorDescription: "Asset was changed by another request",
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/validation"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBatchCreateAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetBatchService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 3)
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Electronics").Return(&models.Category{Id: "category-id", Name: "Electronics"}, nil).AnyTimes()
+
+	created := func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+		asset.Id = asset.Name + "-id"
+		asset.Version = 1
+		return asset, nil
+	}
+	items := func() []*dto.AssetInputDto {
+		return []*dto.AssetInputDto{
+			{Name: "Laptop", Type: "Electronics", Value: 1200, AcquisitionDate: "2024-02-01"},
+			{Name: "Monitor", Type: "Electronics", Value: 300, AcquisitionDate: "2024-02-01"},
+		}
+	}
+
+	t.Run("Success - Atomic", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(created).Times(2)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Items: items()})
+		assert.Equal(t, http.StatusCreated, code)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, common.AssetBatchModeAtomic, result.Mode)
+		assert.Equal(t, 2, result.Succeeded)
+		assert.Equal(t, http.StatusCreated, result.Items[1].Status)
+		assert.Equal(t, "Monitor-id", result.Items[1].Id)
+		assert.Equal(t, int64(1), result.Items[1].Data.Version)
+	})
+
+	t.Run("Error - Invalid item writes nothing", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+		input := append(items(), &dto.AssetInputDto{Name: "Dock", Type: "Electronics", Value: 90, AcquisitionDate: "01/03/2024"})
+		code, response := service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Items: input})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "1 of 3 items failed, nothing was written", response.ErrorDescription)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, 3, result.Failed)
+		assert.Equal(t, http.StatusFailedDependency, result.Items[0].Status)
+		assert.Equal(t, http.StatusBadRequest, result.Items[2].Status)
+		assert.Equal(t, []dto.FieldErrorDto{{Field: "acquisition_date", Code: validation.CodeDateFormat, Message: "acquisition_date must be a date in the format YYYY-MM-DD"}}, result.Items[2].Errors)
+	})
+
+	t.Run("Error - Same asset twice", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+		input := []*dto.AssetInputDto{items()[0], items()[0]}
+		code, response := service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Items: input})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Asset already exist in item 0", response.Data.(*dto.AssetBatchResultDto).Items[1].Error)
+	})
+
+	t.Run("Error - Failed write rolls back", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		gomock.InOrder(
+			mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(created),
+			mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error")),
+		)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Items: items()})
+		assert.Equal(t, http.StatusInternalServerError, code)
+		assert.Equal(t, "Something went wrong, nothing was written", response.ErrorDescription)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, http.StatusInternalServerError, result.Items[0].Status)
+		assert.Empty(t, result.Items[0].Id)
+	})
+
+	t.Run("Success - Best effort with a failed item", func(t *testing.T) {
+		gomock.InOrder(
+			mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil),
+			mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(&models.Asset{Id: "existing-id"}, nil),
+		)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(created)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Mode: common.AssetBatchModeBestEffort, Items: items()})
+		assert.Equal(t, http.StatusMultiStatus, code)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, "Laptop-id", result.Items[0].Id)
+		assert.Equal(t, &dto.AssetBatchItemResultDto{Index: 1, Status: http.StatusBadRequest, Error: "Asset already exist"}, result.Items[1])
+	})
+
+	t.Run("Error - Invalid batch", func(t *testing.T) {
+		code, response := service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Mode: "all", Items: items()})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Invalid mode, allowed modes are atomic, best_effort", response.ErrorDescription)
+
+		code, response = service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Items are required", response.ErrorDescription)
+
+		code, response = service.BatchCreateAssets(context.Background(), &dto.AssetBatchCreateInputDto{Items: append(items(), items()...)})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "A batch must have at most 3 items", response.ErrorDescription)
+	})
+}
+
+func TestBatchUpdateAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetBatchService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 10)
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Electronics").Return(&models.Category{Id: "category-id", Name: "Electronics"}, nil).AnyTimes()
+
+	existing := func(ctx context.Context, whereClause interface{}) (*models.Asset, error) {
+		id := whereClause.(map[string]interface{})["id"].(string)
+		if id == "missing-id" {
+			return nil, nil
+		}
+		return &models.Asset{Id: id, Name: id, Type: "Electronics", Status: common.AssetStatusInStock, Value: 100, AcquisitionDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Version: 2}, nil
+	}
+	item := func(id string, version int64) *dto.AssetBatchUpdateItemDto {
+		return &dto.AssetBatchUpdateItemDto{Id: id, Version: version, AssetInputDto: dto.AssetInputDto{Name: "Renamed " + id, Type: "Electronics", Value: 100, AcquisitionDate: "2024-01-01"}}
+	}
+
+	t.Run("Success - Atomic", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
+			asset.Version++
+			return true, nil
+		}).Times(2)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchUpdateAssets(context.Background(), &dto.AssetBatchUpdateInputDto{Items: []*dto.AssetBatchUpdateItemDto{item("a", 2), item("b", 0)}})
+		assert.Equal(t, http.StatusOK, code)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, 2, result.Succeeded)
+		assert.Equal(t, "Renamed b", result.Items[1].Data.Name)
+		assert.Equal(t, int64(3), result.Items[1].Data.Version)
+	})
+
+	t.Run("Error - Stale, missing and repeated items", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(4)
+
+		code, response := service.BatchUpdateAssets(context.Background(), &dto.AssetBatchUpdateInputDto{Items: []*dto.AssetBatchUpdateItemDto{item("a", 0), item("b", 1), item("missing-id", 0), item("a", 0), nil}})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "4 of 5 items failed, nothing was written", response.ErrorDescription)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, http.StatusFailedDependency, result.Items[0].Status)
+		assert.Equal(t, http.StatusPreconditionFailed, result.Items[1].Status)
+		assert.Equal(t, http.StatusNotFound, result.Items[2].Status)
+		assert.Equal(t, "Asset is already updated by item 0", result.Items[3].Error)
+		assert.Equal(t, "Item is empty", result.Items[4].Error)
+	})
+
+	t.Run("Error - Changed while written", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		gomock.InOrder(
+			mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
+			mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil),
+		)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchUpdateAssets(context.Background(), &dto.AssetBatchUpdateInputDto{Items: []*dto.AssetBatchUpdateItemDto{item("a", 0), item("b", 2)}})
+		assert.Equal(t, http.StatusPreconditionFailed, code)
+		assert.Equal(t, common.PreconditionFailed, response.Error)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, http.StatusFailedDependency, result.Items[0].Status)
+		assert.Equal(t, "Asset was changed since it was read", result.Items[1].Error)
+	})
+
+	t.Run("Success - Best effort", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchUpdateAssets(context.Background(), &dto.AssetBatchUpdateInputDto{Mode: common.AssetBatchModeBestEffort, Items: []*dto.AssetBatchUpdateItemDto{item("a", 2), item("missing-id", 0)}})
+		assert.Equal(t, http.StatusMultiStatus, code)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, http.StatusOK, result.Items[0].Status)
+		assert.Equal(t, &dto.AssetBatchItemResultDto{Index: 1, Id: "missing-id", Status: http.StatusNotFound, Error: "Asset not found"}, result.Items[1])
+	})
+}
+
+func TestBatchDeleteAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetBatchService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 10)
+
+	existing := func(ctx context.Context, whereClause interface{}) (*models.Asset, error) {
+		return &models.Asset{Id: whereClause.(map[string]interface{})["id"].(string), Version: 1}, nil
+	}
+	items := []*dto.AssetBatchDeleteItemDto{{Id: "a"}, {Id: "b", Version: 1}}
+
+	t.Run("Success - Atomic", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().DeleteAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchDeleteAssets(context.Background(), &dto.AssetBatchDeleteInputDto{Items: items})
+		assert.Equal(t, http.StatusOK, code)
+		result := response.Data.(*dto.AssetBatchResultDto)
+		assert.Equal(t, &dto.AssetBatchItemResultDto{Index: 1, Id: "b", Status: http.StatusOK}, result.Items[1])
+	})
+
+	t.Run("Error - Changed while deleted", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().DeleteAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+		mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.BatchDeleteAssets(context.Background(), &dto.AssetBatchDeleteInputDto{Items: items})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "1 of 2 items failed, nothing was written", response.ErrorDescription)
+	})
+
+	t.Run("Error - Get asset fails", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+		code, response := service.BatchDeleteAssets(context.Background(), &dto.AssetBatchDeleteInputDto{Items: items})
+		assert.Equal(t, http.StatusInternalServerError, code)
+		assert.Equal(t, "Something went wrong", response.ErrorDescription)
+	})
+
+	t.Run("Success - Best effort", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).DoAndReturn(existing).Times(2)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{}).Times(2)
+		mockRepo.EXPECT().DeleteAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil).Times(2)
+
+		code, response := service.BatchDeleteAssets(context.Background(), &dto.AssetBatchDeleteInputDto{Mode: common.AssetBatchModeBestEffort, Items: items})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 2, response.Data.(*dto.AssetBatchResultDto).Succeeded)
+	})
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/spreadsheet"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+type AssetExportServiceInterface interface {
+	ExportAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, format string) (code int, export *dto.AssetExportDto, response *dto.BaseResponse)
+}
+
+// assetExportColumns is the header row of the CSV and XLSX exports
+var assetExportColumns = []interface{}{"id", "name", "type", "category_id", "location_id", "status", "value", "acquisition_date", "depreciation_method", "accumulated_depreciation", "book_value", "created_at", "updated_at"}
+
+var assetExportContentTypes = map[string]string{
+	common.AssetExportCSV:    "text/csv; charset=utf-8",
+	common.AssetExportXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	common.AssetExportNDJSON: "application/x-ndjson",
+}
+
+type assetExportService struct {
+	*assetService
+}
+
+func NewAssetExportService(assetRepo repositories.AssetRepositoryInterface, categoryRepo repositories.CategoryRepositoryInterface, locationRepo repositories.LocationRepositoryInterface) AssetExportServiceInterface {
+	return &assetExportService{
+		assetService: &assetService{assetRepo: assetRepo, categoryRepo: categoryRepo, locationRepo: locationRepo},
+	}
+}
+
+// ExportAssets checks the filters and sort like GetAssets and returns an export of every
+// matching asset. The assets are read from the database while the export is written, so
+// errors after the first byte can only be logged.
+func (s *assetExportService) ExportAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, format string) (code int, export *dto.AssetExportDto, response *dto.BaseResponse) {
+	contentType, ok := assetExportContentTypes[format]
+	if !ok {
+		return http.StatusBadRequest, nil, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: fmt.Sprintf("Invalid format, must be one of %s, %s, %s", common.AssetExportCSV, common.AssetExportXLSX, common.AssetExportNDJSON),
+		}
+	}
+
+	if err := parseAssetFilter(filter); err != nil {
+		log.Println("[assetExportService][ExportAssets] error parsing filter :", err)
+		return http.StatusBadRequest, nil, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if err := pagination.ParseSort(assetSortFields); err != nil {
+		return http.StatusBadRequest, nil, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if err := s.expandAssetFilter(ctx, filter); err != nil {
+		log.Println("[assetExportService][ExportAssets] error expand filter :", err)
+		return http.StatusInternalServerError, nil, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	now := time.Now().UTC()
+	export = &dto.AssetExportDto{
+		FileName:    fmt.Sprintf("assets-%s.%s", now.Format("2006-01-02"), format),
+		ContentType: contentType,
+		Write: func(w io.Writer) error {
+			return s.writeExport(ctx, w, filter, pagination, format, now)
+		},
+	}
+	return http.StatusOK, export, nil
+}
+
+func (s *assetExportService) writeExport(ctx context.Context, w io.Writer, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, format string, now time.Time) error {
+	if format == common.AssetExportNDJSON {
+		buf := bufio.NewWriter(w)
+		encoder := json.NewEncoder(buf)
+		err := s.assetRepo.StreamAssets(ctx, filter, pagination, func(asset *models.Asset) error {
+			output, err := assetExportOutput(asset, now)
+			if err != nil {
+				return err
+			}
+			return encoder.Encode(output)
+		})
+		if err != nil {
+			return err
+		}
+		return buf.Flush()
+	}
+
+	var writer spreadsheet.RowWriter
+	if format == common.AssetExportXLSX {
+		var err error
+		if writer, err = spreadsheet.NewXLSXWriter(w, "Assets"); err != nil {
+			return err
+		}
+	} else {
+		writer = spreadsheet.NewCSVWriter(w)
+	}
+
+	if err := writer.WriteRow(assetExportColumns); err != nil {
+		return err
+	}
+	err := s.assetRepo.StreamAssets(ctx, filter, pagination, func(asset *models.Asset) error {
+		output, err := assetExportOutput(asset, now)
+		if err != nil {
+			return err
+		}
+		return writer.WriteRow(assetExportRow(output))
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// assetExportOutput is the asset as returned by GetAssetById, with the depreciation as of now
+func assetExportOutput(asset *models.Asset, now time.Time) (*dto.AssetOutputDto, error) {
+	output := &dto.AssetOutputDto{
+		Id:              asset.Id,
+		Name:            asset.Name,
+		Type:            asset.Type,
+		CategoryId:      asset.CategoryId,
+		LocationId:      asset.LocationId,
+		Status:          asset.Status,
+		Value:           asset.Value,
+		AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02"),
+		CreatedAt:       asset.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       asset.UpdatedAt.UTC().Format(time.RFC3339),
+		Version:         asset.Version,
+	}
+
+	if asset.DepreciationMethod != "" {
+		var err error
+		if output.Depreciation, err = currentDepreciation(asset, now); err != nil {
+			return nil, fmt.Errorf("calculate depreciation of asset %s: %w", asset.Id, err)
+		}
+	}
+	return output, nil
+}
+
+func assetExportRow(output *dto.AssetOutputDto) []interface{} {
+	row := []interface{}{output.Id, output.Name, output.Type, nil, nil, output.Status, output.Value, output.AcquisitionDate, nil, nil, nil, output.CreatedAt, output.UpdatedAt}
+	if output.CategoryId != nil {
+		row[3] = *output.CategoryId
+	}
+	if output.LocationId != nil {
+		row[4] = *output.LocationId
+	}
+	if output.Depreciation != nil {
+		row[8] = output.Depreciation.Method
+		row[9] = output.Depreciation.AccumulatedDepreciation
+		row[10] = output.Depreciation.BookValue
+	}
+	return row
+}
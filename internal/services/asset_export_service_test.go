@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAssets(t *testing.T) {
+	categoryId := "category-id"
+	createdAt := time.Date(2024, 2, 1, 8, 30, 0, 0, time.UTC)
+	assets := []*models.Asset{
+		{Id: "laptop-id", Name: "Laptop, 14\"", Type: "Electronics", CategoryId: &categoryId, Status: "in_stock", Value: 1200, AcquisitionDate: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{Id: "formula-id", Name: "=SUM(A1)", Type: "Electronics", Status: "deployed", Value: 300, AcquisitionDate: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+	stream := func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, fn func(asset *models.Asset) error) error {
+		for _, v := range assets {
+			if err := fn(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		filter      *dto.AssetFilterDto
+		pagination  *dto.MetaPagination
+		format      string
+		setupMocks  func(mockRepo *repositories.MockAssetRepositoryInterface, mockCategoryRepo *repositories.MockCategoryRepositoryInterface)
+		expectCode  int
+		expectError string
+		expectFile  string
+		expectBody  string
+	}{
+		{
+			name:       "CSV of the category subtree",
+			filter:     &dto.AssetFilterDto{CategoryId: categoryId},
+			pagination: &dto.MetaPagination{SortBy: "name"},
+			format:     "csv",
+			setupMocks: func(mockRepo *repositories.MockAssetRepositoryInterface, mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockCategoryRepo.EXPECT().GetDescendantIds(gomock.Any(), categoryId).Return([]string{"child-id"}, nil)
+				mockRepo.EXPECT().StreamAssets(gomock.Any(), &dto.AssetFilterDto{CategoryId: categoryId, CategoryIds: []string{categoryId, "child-id"}}, gomock.Any(), gomock.Any()).DoAndReturn(stream)
+			},
+			expectCode: http.StatusOK,
+			expectFile: ".csv",
+			expectBody: "id,name,type,category_id,location_id,status,value,acquisition_date,depreciation_method,accumulated_depreciation,book_value,created_at,updated_at\n" +
+				"laptop-id,\"Laptop, 14\"\"\",Electronics,category-id,,in_stock,1200,2024-01-15,,,,2024-02-01T08:30:00Z,2024-02-01T08:30:00Z\n" +
+				"formula-id,'=SUM(A1),Electronics,,,deployed,300,2023-05-01,,,,2024-02-01T08:30:00Z,2024-02-01T08:30:00Z\n",
+		},
+		{
+			name:       "NDJSON",
+			filter:     &dto.AssetFilterDto{},
+			pagination: &dto.MetaPagination{},
+			format:     "ndjson",
+			setupMocks: func(mockRepo *repositories.MockAssetRepositoryInterface, mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockRepo.EXPECT().StreamAssets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(stream)
+			},
+			expectCode: http.StatusOK,
+			expectFile: ".ndjson",
+			expectBody: `{"id":"laptop-id","name":"Laptop, 14\"","type":"Electronics","category_id":"category-id","location_id":null,"status":"in_stock","value":1200,"acquisition_date":"2024-01-15","version":1,"created_at":"2024-02-01T08:30:00Z","updated_at":"2024-02-01T08:30:00Z"}` + "\n" +
+				`{"id":"formula-id","name":"=SUM(A1)","type":"Electronics","category_id":null,"location_id":null,"status":"deployed","value":300,"acquisition_date":"2023-05-01","version":1,"created_at":"2024-02-01T08:30:00Z","updated_at":"2024-02-01T08:30:00Z"}` + "\n",
+		},
+		{
+			name:       "Unknown format",
+			filter:     &dto.AssetFilterDto{},
+			pagination: &dto.MetaPagination{},
+			format:     "pdf",
+			setupMocks: func(mockRepo *repositories.MockAssetRepositoryInterface, mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+			},
+			expectCode:  http.StatusBadRequest,
+			expectError: "Invalid format, must be one of csv, xlsx, ndjson",
+		},
+		{
+			name:       "Invalid sort field",
+			filter:     &dto.AssetFilterDto{},
+			pagination: &dto.MetaPagination{SortBy: "secret"},
+			format:     "csv",
+			setupMocks: func(mockRepo *repositories.MockAssetRepositoryInterface, mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+			},
+			expectCode:  http.StatusBadRequest,
+			expectError: "Invalid sort field secret, allowed fields are id, name, type, value, acquisition_date, created_at, updated_at",
+		},
+		{
+			name:       "Error get category descendants",
+			filter:     &dto.AssetFilterDto{CategoryId: categoryId},
+			pagination: &dto.MetaPagination{},
+			format:     "csv",
+			setupMocks: func(mockRepo *repositories.MockAssetRepositoryInterface, mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockCategoryRepo.EXPECT().GetDescendantIds(gomock.Any(), categoryId).Return(nil, errors.New("db error"))
+			},
+			expectCode:  http.StatusInternalServerError,
+			expectError: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+			mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+			mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+			tt.setupMocks(mockRepo, mockCategoryRepo)
+			service := NewAssetExportService(mockRepo, mockCategoryRepo, mockLocationRepo)
+
+			code, export, response := service.ExportAssets(context.Background(), tt.filter, tt.pagination, tt.format)
+			assert.Equal(t, tt.expectCode, code)
+			if tt.expectError != "" {
+				assert.Nil(t, export)
+				assert.Equal(t, tt.expectError, response.ErrorDescription)
+				return
+			}
+
+			assert.Nil(t, response)
+			assert.True(t, strings.HasPrefix(export.FileName, "assets-"))
+			assert.True(t, strings.HasSuffix(export.FileName, tt.expectFile))
+			var buf bytes.Buffer
+			assert.NoError(t, export.Write(&buf))
+			assert.Equal(t, tt.expectBody, buf.String())
+		})
+	}
+}
+
+func TestExportAssetsStreamError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	service := NewAssetExportService(mockRepo, repositories.NewMockCategoryRepositoryInterface(ctrl), repositories.NewMockLocationRepositoryInterface(ctrl))
+	mockRepo.EXPECT().StreamAssets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	code, export, response := service.ExportAssets(context.Background(), &dto.AssetFilterDto{}, &dto.MetaPagination{}, "xlsx")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Nil(t, response)
+
+	var buf bytes.Buffer
+	assert.EqualError(t, export.Write(&buf), "connection reset")
+}
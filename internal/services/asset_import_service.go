@@ -0,0 +1,336 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/spreadsheet"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type AssetImportServiceInterface interface {
+	ImportAssets(ctx context.Context, input *dto.AssetImportInputDto) (code int, response *dto.BaseResponse)
+}
+
+// assetImportMaxRows is the largest number of data rows a single import accepts
+const assetImportMaxRows = 10000
+
+// assetImportFields are the asset input fields a column can be mapped to
+var assetImportFields = []string{"name", "type", "category_id", "location_id", "status", "value", "acquisition_date", "depreciation_method", "depreciation_convention", "useful_life_years", "salvage_value", "declining_factor", "total_units", "units_used"}
+
+// excelEpoch is day zero of the Excel date serial numbers
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// excelMaxSerial is the serial number of 9999-12-31, the last date Excel holds
+const excelMaxSerial = 2958465
+
+type assetImportService struct {
+	*assetService
+	// batchSize is the number of assets written per transaction, 0 writes all in one
+	batchSize int
+}
+
+func NewAssetImportService(assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, categoryRepo repositories.CategoryRepositoryInterface, locationRepo repositories.LocationRepositoryInterface, outboxRepo repositories.OutboxRepositoryInterface, batchSize int) AssetImportServiceInterface {
+	return &assetImportService{
+		assetService: &assetService{assetRepo: assetRepo, auditRepo: auditRepo, categoryRepo: categoryRepo, locationRepo: locationRepo, outboxRepo: outboxRepo},
+		batchSize:    batchSize,
+	}
+}
+
+// ImportAssets creates the assets of a CSV or XLSX file. Every row is validated like
+// CreateAsset first, and nothing is written when a row is invalid or on a dry run.
+func (s *assetImportService) ImportAssets(ctx context.Context, input *dto.AssetImportInputDto) (code int, response *dto.BaseResponse) {
+	isXlsx := spreadsheet.IsXLSX(input.Content)
+	var records [][]string
+	var err error
+	if isXlsx {
+		records, err = spreadsheet.ReadXLSX(input.Content)
+	} else if strings.EqualFold(filepath.Ext(input.FileName), ".xlsx") {
+		err = errors.New("not a zip archive")
+	} else {
+		records, err = spreadsheet.ReadCSV(input.Content)
+	}
+	if err != nil {
+		log.Println("[assetImportService][ImportAssets] error read file :", err)
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "File must be a valid CSV or XLSX file",
+		}
+	}
+
+	if len(records) < 2 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "File must have a header row and at least one asset row",
+		}
+	}
+	if len(records)-1 > assetImportMaxRows {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: fmt.Sprintf("File must have at most %d asset rows", assetImportMaxRows),
+		}
+	}
+
+	columns, err := assetImportColumns(records[0], input.Mapping)
+	if err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	result := &dto.AssetImportResultDto{DryRun: input.DryRun, Rows: []*dto.AssetImportRowDto{}}
+	assets := []*models.Asset{}
+	assetRows := []*dto.AssetImportRowDto{}
+	// name and type of the valid rows, so a file cannot hold the same asset twice
+	seen := map[string]int{}
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		row := &dto.AssetImportRowDto{Row: i + 2}
+		result.Rows = append(result.Rows, row)
+
+		assetInput, err := assetImportInput(record, columns, isXlsx)
+		row.Name = assetInput.Name
+		if err != nil {
+			row.Status, row.Error = common.AssetImportRowInvalid, err.Error()
+			continue
+		}
+
+		asset, code, response := s.newAsset(ctx, assetInput, "ImportAssets")
+		if code == http.StatusInternalServerError {
+			return code, response
+		}
+		if response != nil {
+			row.Status, row.Error = common.AssetImportRowInvalid, response.ErrorDescription
+			continue
+		}
+
+		key := asset.Name + "\x00" + asset.Type
+		if first, ok := seen[key]; ok {
+			row.Status, row.Error = common.AssetImportRowInvalid, fmt.Sprintf("Asset already exist in row %d", first)
+			continue
+		}
+		seen[key] = row.Row
+
+		row.Status = common.AssetImportRowValid
+		assets = append(assets, asset)
+		assetRows = append(assetRows, row)
+	}
+
+	result.Total = len(result.Rows)
+	result.Valid = len(assets)
+	result.Invalid = result.Total - result.Valid
+
+	if input.DryRun {
+		return http.StatusOK, &dto.BaseResponse{
+			Message: common.Success,
+			Data:    result,
+		}
+	}
+
+	if result.Invalid > 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: fmt.Sprintf("%d of %d rows are invalid, nothing was imported", result.Invalid, result.Total),
+			Data:             result,
+		}
+	}
+
+	batchSize := s.batchSize
+	if batchSize <= 0 {
+		batchSize = len(assets)
+	}
+	for start := 0; start < len(assets); start += batchSize {
+		end := min(start+batchSize, len(assets))
+		if err = s.importBatch(ctx, assets[start:end]); err != nil {
+			log.Println("[assetImportService][ImportAssets] error import batch :", err)
+			for _, row := range assetRows[start:] {
+				row.Status = common.AssetImportRowFailed
+			}
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: fmt.Sprintf("Something went wrong, %d of %d rows were imported", result.Imported, result.Total),
+				Data:             result,
+			}
+		}
+
+		for i, row := range assetRows[start:end] {
+			row.Status = common.AssetImportRowImported
+			row.AssetId = assets[start+i].Id
+		}
+		result.Imported += end - start
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    result,
+	}
+}
+
+// importBatch creates assets with their audit logs and events in one transaction, the created assets
+// replace the given ones
+func (s *assetImportService) importBatch(ctx context.Context, assets []*models.Asset) error {
+	tx := s.assetRepo.StartTransaction(ctx)
+	for i, v := range assets {
+		created, err := s.assetRepo.CreateAsset(ctx, v, tx)
+		if err == nil {
+			assets[i] = created
+			err = s.recordAudit(ctx, tx, common.AuditActionCreate, created.Id, nil, assetAuditFields(created))
+		}
+		if err == nil {
+			err = s.publishEvent(ctx, tx, common.EventAssetCreated, created, nil, assetAuditFields(created))
+		}
+		if err != nil {
+			if rollbackErr := s.assetRepo.RollbackTransaction(tx); rollbackErr != nil {
+				log.Println("[assetImportService][importBatch] error rollback transaction :", rollbackErr)
+			}
+			return err
+		}
+	}
+
+	err := s.assetRepo.CommitTransaction(tx)
+	if err != nil {
+		if rollbackErr := s.assetRepo.RollbackTransaction(tx); rollbackErr != nil {
+			log.Println("[assetImportService][importBatch] error rollback transaction :", rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// assetImportColumns returns the column index of every field found in the header. A mapped
+// column must exist, the name column is required.
+func assetImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	headerIndex := map[string]int{}
+	for i, v := range header {
+		if _, ok := headerIndex[normalizeHeader(v)]; !ok {
+			headerIndex[normalizeHeader(v)] = i
+		}
+	}
+
+	known := map[string]bool{}
+	for _, v := range assetImportFields {
+		known[v] = true
+	}
+	mapped := make([]string, 0, len(mapping))
+	for field := range mapping {
+		mapped = append(mapped, field)
+	}
+	sort.Strings(mapped)
+	for _, field := range mapped {
+		if !known[field] {
+			return nil, fmt.Errorf("Unknown mapping field %s, allowed fields are %s", field, strings.Join(assetImportFields, ", "))
+		}
+	}
+
+	columns := map[string]int{}
+	for _, field := range assetImportFields {
+		if column, ok := mapping[field]; ok {
+			index, found := headerIndex[normalizeHeader(column)]
+			if !found {
+				return nil, fmt.Errorf("Column %s mapped to %s not found", column, field)
+			}
+			columns[field] = index
+			continue
+		}
+		if index, found := headerIndex[field]; found {
+			columns[field] = index
+		}
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("A name column is required")
+	}
+	return columns, nil
+}
+
+// assetImportInput reads the asset input of a row. XLSX dates stored as serial numbers are
+// turned into 2006-01-02 dates.
+func assetImportInput(record []string, columns map[string]int, isXlsx bool) (*dto.AssetInputDto, error) {
+	value := func(field string) string {
+		index, ok := columns[field]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	input := &dto.AssetInputDto{
+		Name:                   value("name"),
+		Type:                   value("type"),
+		CategoryId:             value("category_id"),
+		LocationId:             value("location_id"),
+		Status:                 value("status"),
+		AcquisitionDate:        value("acquisition_date"),
+		DepreciationMethod:     value("depreciation_method"),
+		DepreciationConvention: value("depreciation_convention"),
+	}
+	if input.Name == "" {
+		return input, errors.New("Name is required")
+	}
+
+	if isXlsx {
+		// a serial outside the dates Excel holds is left to the date validation
+		if serial, err := strconv.ParseFloat(input.AcquisitionDate, 64); err == nil && serial >= 0 && serial <= excelMaxSerial {
+			input.AcquisitionDate = excelEpoch.AddDate(0, 0, int(math.Floor(serial))).Format("2006-01-02")
+		}
+	}
+
+	numbers := map[string]*float64{
+		"value":            &input.Value,
+		"salvage_value":    &input.SalvageValue,
+		"declining_factor": &input.DecliningFactor,
+		"total_units":      &input.TotalUnits,
+		"units_used":       &input.UnitsUsed,
+	}
+	for _, field := range assetImportFields {
+		target, ok := numbers[field]
+		if !ok || value(field) == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value(field), 64)
+		if err != nil || math.IsInf(parsed, 0) || math.IsNaN(parsed) {
+			return input, fmt.Errorf("Invalid %s, must be a number", field)
+		}
+		*target = parsed
+	}
+
+	if usefulLife := value("useful_life_years"); usefulLife != "" {
+		parsed, err := strconv.Atoi(usefulLife)
+		if err != nil {
+			return input, errors.New("Invalid useful_life_years, must be a whole number")
+		}
+		input.UsefulLifeYears = parsed
+	}
+
+	return input, nil
+}
+
+// normalizeHeader makes "Acquisition Date" match the acquisition_date field
+func normalizeHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(header)
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
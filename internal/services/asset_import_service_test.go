@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestImportAssetsDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetImportService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 0)
+
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Electronics").Return(&models.Category{Id: "category-id", Name: "Electronics"}, nil).AnyTimes()
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Furniture").Return(nil, nil).AnyTimes()
+	mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "Laptop", "type": "Electronics"}).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "Existing", "type": "Electronics"}).Return(&models.Asset{Id: "existing-id"}, nil)
+
+	content := "Asset Name,type,Value,Bought On\n" +
+		"Laptop,Electronics,1200,2024-02-01\n" +
+		",,,\n" +
+		"Monitor,Electronics,abc,2024-02-01\n" +
+		"Existing,Electronics,5,2024-01-01\n" +
+		"Laptop,Electronics,1,2024-01-01\n" +
+		"Desk,Furniture,100,2024-01-01\n" +
+		"Chair,Electronics,50,01/02/2024\n"
+
+	code, response := service.ImportAssets(context.Background(), &dto.AssetImportInputDto{
+		FileName: "assets.csv",
+		Content:  []byte(content),
+		Mapping:  map[string]string{"name": "Asset Name", "acquisition_date": "Bought On"},
+		DryRun:   true,
+	})
+	assert.Equal(t, http.StatusOK, code)
+
+	result := response.Data.(*dto.AssetImportResultDto)
+	assert.Equal(t, 6, result.Total)
+	assert.Equal(t, 1, result.Valid)
+	assert.Equal(t, 5, result.Invalid)
+	assert.Equal(t, []*dto.AssetImportRowDto{
+		{Row: 2, Name: "Laptop", Status: common.AssetImportRowValid},
+		{Row: 4, Name: "Monitor", Status: common.AssetImportRowInvalid, Error: "Invalid value, must be a number"},
+		{Row: 5, Name: "Existing", Status: common.AssetImportRowInvalid, Error: "Asset already exist"},
+		{Row: 6, Name: "Laptop", Status: common.AssetImportRowInvalid, Error: "Asset already exist in row 2"},
+		{Row: 7, Name: "Desk", Status: common.AssetImportRowInvalid, Error: "Category not found"},
+		{Row: 8, Name: "Chair", Status: common.AssetImportRowInvalid, Error: "acquisition_date must be a date in the format YYYY-MM-DD"},
+	}, result.Rows)
+}
+
+func TestImportAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Electronics").Return(&models.Category{Id: "category-id", Name: "Electronics"}, nil).AnyTimes()
+
+	content := []byte("name,type,value,acquisition_date\nLaptop,Electronics,1200,2024-02-01\nMonitor,Electronics,300,2024-02-01\nDock,Electronics,90,2024-03-01\n")
+	created := func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+		asset.Id = asset.Name + "-id"
+		return asset, nil
+	}
+
+	t.Run("Success - Imported in batches", func(t *testing.T) {
+		service := NewAssetImportService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 2)
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{}).Times(2)
+		mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(created).Times(3)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil).Times(2)
+
+		code, response := service.ImportAssets(context.Background(), &dto.AssetImportInputDto{FileName: "assets.csv", Content: content})
+		assert.Equal(t, http.StatusCreated, code)
+		result := response.Data.(*dto.AssetImportResultDto)
+		assert.Equal(t, 3, result.Imported)
+		assert.Equal(t, "Dock-id", result.Rows[2].AssetId)
+		assert.Equal(t, common.AssetImportRowImported, result.Rows[2].Status)
+	})
+
+	t.Run("Error - Failed batch stops the import", func(t *testing.T) {
+		service := NewAssetImportService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 2)
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{}).Times(2)
+		gomock.InOrder(
+			mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(created).Times(2),
+			mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error")),
+		)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+
+		code, response := service.ImportAssets(context.Background(), &dto.AssetImportInputDto{FileName: "assets.csv", Content: content})
+		assert.Equal(t, http.StatusInternalServerError, code)
+		assert.Equal(t, "Something went wrong, 2 of 3 rows were imported", response.ErrorDescription)
+		result := response.Data.(*dto.AssetImportResultDto)
+		assert.Equal(t, common.AssetImportRowImported, result.Rows[1].Status)
+		assert.Equal(t, common.AssetImportRowFailed, result.Rows[2].Status)
+	})
+
+	t.Run("Error - Invalid row writes nothing", func(t *testing.T) {
+		service := NewAssetImportService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 0)
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		code, response := service.ImportAssets(context.Background(), &dto.AssetImportInputDto{
+			FileName: "assets.csv",
+			Content:  []byte("name,type,value,acquisition_date\nLaptop,Electronics,1200,2024-02-01\nMonitor,Electronics,300,\n"),
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "1 of 2 rows are invalid, nothing was imported", response.ErrorDescription)
+	})
+
+	t.Run("Error - Mapped column missing", func(t *testing.T) {
+		service := NewAssetImportService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 0)
+
+		code, response := service.ImportAssets(context.Background(), &dto.AssetImportInputDto{FileName: "assets.csv", Content: content, Mapping: map[string]string{"value": "Cost"}})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Column Cost mapped to value not found", response.ErrorDescription)
+	})
+
+	t.Run("Error - Not a workbook", func(t *testing.T) {
+		service := NewAssetImportService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, 0)
+
+		code, response := service.ImportAssets(context.Background(), &dto.AssetImportInputDto{FileName: "assets.xlsx", Content: content})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "File must be a valid CSV or XLSX file", response.ErrorDescription)
+	})
+}
+
+func TestAssetImportInputExcelDate(t *testing.T) {
+	input, err := assetImportInput([]string{"Printer", "45323"}, map[string]int{"name": 0, "acquisition_date": 1}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-01", input.AcquisitionDate)
+}
+
+func TestAssetImportInputOutOfRange(t *testing.T) {
+	columns := map[string]int{"name": 0, "acquisition_date": 1, "value": 2, "salvage_value": 3, "declining_factor": 4, "total_units": 5, "units_used": 6}
+
+	for _, field := range []string{"value", "salvage_value", "declining_factor", "total_units", "units_used"} {
+		for _, number := range []string{"Inf", "+Infinity", "-inf", "NaN", "1e400"} {
+			t.Run(field+" "+number, func(t *testing.T) {
+				record := []string{"Printer", "2024-02-01", "", "", "", "", ""}
+				record[columns[field]] = number
+				_, err := assetImportInput(record, columns, false)
+				assert.EqualError(t, err, "Invalid "+field+", must be a number")
+			})
+		}
+	}
+
+	t.Run("Excel date serial out of range", func(t *testing.T) {
+		for _, serial := range []string{"1e300", "-1", "2958466"} {
+			input, err := assetImportInput([]string{"Printer", serial}, columns, true)
+			assert.NoError(t, err)
+			assert.Equal(t, serial, input.AcquisitionDate)
+		}
+	})
+}
@@ -2,68 +2,104 @@ package services
 
 import (
 	"assets-api-go/internal/common"
+	"assets-api-go/internal/depreciation"
 	"assets-api-go/internal/dto"
+	"assets-api-go/internal/jsonpatch"
 	"assets-api-go/internal/models"
 	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/storage"
+	"assets-api-go/internal/validation"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type AssetServiceInterface interface {
-	CreateAsset(input *dto.AssetInputDto) (code int, response *dto.BaseResponse)
-	GetAssetById(id string) (code int, response *dto.BaseResponse)
-	GetAssets(pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
-	UpdateAsset(id string, input *dto.AssetInputDto) (code int, response *dto.BaseResponse)
-	DeleteAsset(id string) (code int, response *dto.BaseResponse)
+	CreateAsset(ctx context.Context, input *dto.AssetInputDto) (code int, response *dto.BaseResponse)
+	GetAssetById(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	UpdateAsset(ctx context.Context, id string, input *dto.AssetInputDto, ifMatch string) (code int, response *dto.BaseResponse)
+	PatchAsset(ctx context.Context, id string, contentType string, patch []byte, ifMatch string) (code int, response *dto.BaseResponse)
+	DeleteAsset(ctx context.Context, id string, ifMatch string) (code int, response *dto.BaseResponse)
+	GetDepreciationSchedule(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetDeletedAssets(ctx context.Context, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	RestoreAsset(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	PurgeAsset(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	PurgeExpiredAssets(ctx context.Context, retentionDays int) (purged int64, err error)
+	TransitionAsset(ctx context.Context, id string, input *dto.AssetTransitionInputDto) (code int, response *dto.BaseResponse)
+	GetAssetTransitions(ctx context.Context, id string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
 }
 
+// assetSortFields are the columns the asset listing can be sorted by
+var assetSortFields = []string{"id", "name", "type", "value", "acquisition_date", "created_at", "updated_at"}
+
+var (
+	errCategoryRequired = errors.New("category_id or type is required")
+	errCategoryNotFound = errors.New("Category not found")
+	errLocationNotFound = errors.New("Location not found")
+)
+
 type assetService struct {
-	assetRepo repositories.AssetRepositoryInterface
+	assetRepo    repositories.AssetRepositoryInterface
+	auditRepo    repositories.AuditRepositoryInterface
+	categoryRepo repositories.CategoryRepositoryInterface
+	locationRepo repositories.LocationRepositoryInterface
+	outboxRepo   repositories.OutboxRepositoryInterface
+	// attachmentRepo and storage remove the attachments of a purged asset
+	attachmentRepo repositories.AttachmentRepositoryInterface
+	storage        storage.Storage
+	// transitions maps a lifecycle status to the statuses an asset can move to from it
+	transitions map[string][]string
 }
 
-func NewAssetService(assetRepo repositories.AssetRepositoryInterface) AssetServiceInterface {
-	return &assetService{assetRepo: assetRepo}
+func NewAssetService(assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, categoryRepo repositories.CategoryRepositoryInterface, locationRepo repositories.LocationRepositoryInterface, outboxRepo repositories.OutboxRepositoryInterface, attachmentRepo repositories.AttachmentRepositoryInterface, fileStorage storage.Storage, transitions map[string][]string) AssetServiceInterface {
+	return &assetService{assetRepo: assetRepo, auditRepo: auditRepo, categoryRepo: categoryRepo, locationRepo: locationRepo, outboxRepo: outboxRepo, attachmentRepo: attachmentRepo, storage: fileStorage, transitions: transitions}
 }
 
-func (s *assetService) CreateAsset(input *dto.AssetInputDto) (code int, response *dto.BaseResponse) {
-	// get asset by name and type
-	asset, err := s.assetRepo.GetAssetByAttribute(map[string]interface{}{
-		"name": input.Name,
-		"type": input.Type,
-	})
+func (s *assetService) CreateAsset(ctx context.Context, input *dto.AssetInputDto) (code int, response *dto.BaseResponse) {
+	asset, code, response := s.newAsset(ctx, input, "CreateAsset")
+	if response != nil {
+		return code, response
+	}
+
+	tx := s.assetRepo.StartTransaction(ctx)
+	asset, err := s.assetRepo.CreateAsset(ctx, asset, tx)
 	if err != nil {
-		log.Println("[assetService][CreateAsset] error get existing asset :", err)
+		log.Println("[assetService][CreateAsset] error create asset :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][CreateAsset] error rollback transaction :", err)
+		}
 		return http.StatusInternalServerError, &dto.BaseResponse{
 			Error:            common.InternalServerError,
 			ErrorDescription: "Something went wrong",
 		}
 	}
 
-	if asset != nil {
-		return http.StatusBadRequest, &dto.BaseResponse{
-			Error:            common.BadRequest,
-			ErrorDescription: "Asset already exist",
-		}
-	}
-
-	acqusitionDate, err := time.Parse("2006-01-02", input.AcquisitionDate)
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, asset.Id, nil, assetAuditFields(asset))
 	if err != nil {
-		log.Println("[assetService][CreateAsset] error parsing date :", err)
-		return http.StatusBadRequest, &dto.BaseResponse{
-			Error:            common.BadRequest,
-			ErrorDescription: "Invalid acqusition date format",
+		log.Println("[assetService][CreateAsset] error record audit :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][CreateAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
 		}
 	}
-	tx := s.assetRepo.StartTransaction()
-	asset, err = s.assetRepo.CreateAsset(&models.Asset{
-		Name:            input.Name,
-		Type:            input.Type,
-		Value:           input.Value,
-		AcquisitionDate: acqusitionDate,
-	}, tx)
+
+	err = s.publishEvent(ctx, tx, common.EventAssetCreated, asset, nil, assetAuditFields(asset))
 	if err != nil {
-		log.Println("[assetService][CreateAsset] error create asset :", err)
+		log.Println("[assetService][CreateAsset] error publish event :", err)
 		err = s.assetRepo.RollbackTransaction(tx)
 		if err != nil {
 			log.Println("[assetService][CreateAsset] error rollback transaction :", err)
@@ -91,10 +127,14 @@ func (s *assetService) CreateAsset(input *dto.AssetInputDto) (code int, response
 		Id:              asset.Id,
 		Name:            asset.Name,
 		Type:            asset.Type,
+		CategoryId:      asset.CategoryId,
+		LocationId:      asset.LocationId,
+		Status:          asset.Status,
 		Value:           asset.Value,
 		AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02 15:04:05"),
 		CreatedAt:       asset.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:       asset.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Version:         asset.Version,
 	}
 
 	return http.StatusCreated, &dto.BaseResponse{
@@ -103,9 +143,9 @@ func (s *assetService) CreateAsset(input *dto.AssetInputDto) (code int, response
 	}
 }
 
-func (s *assetService) GetAssetById(id string) (code int, response *dto.BaseResponse) {
+func (s *assetService) GetAssetById(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
 	// get asset by name and type
-	asset, err := s.assetRepo.GetAssetByAttribute(map[string]interface{}{
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
 		"id": id,
 	})
 	if err != nil {
@@ -127,10 +167,25 @@ func (s *assetService) GetAssetById(id string) (code int, response *dto.BaseResp
 		Id:              asset.Id,
 		Name:            asset.Name,
 		Type:            asset.Type,
+		CategoryId:      asset.CategoryId,
+		LocationId:      asset.LocationId,
+		Status:          asset.Status,
 		Value:           asset.Value,
 		AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02 15:04:05"),
 		CreatedAt:       asset.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:       asset.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Version:         asset.Version,
+	}
+
+	if asset.DepreciationMethod != "" {
+		resData.Depreciation, err = currentDepreciation(asset, time.Now().UTC())
+		if err != nil {
+			log.Println("[assetService][GetAssetById] error calculate depreciation :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
 	}
 
 	return http.StatusOK, &dto.BaseResponse{
@@ -139,9 +194,42 @@ func (s *assetService) GetAssetById(id string) (code int, response *dto.BaseResp
 	}
 }
 
-func (s *assetService) GetAssets(pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+func (s *assetService) GetAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+
+	if err := parseAssetFilter(filter); err != nil {
+		log.Println("[assetService][GetAssets] error parsing filter :", err)
+		return http.StatusBadRequest, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			},
+		}
+	}
+
+	if err := s.expandAssetFilter(ctx, filter); err != nil {
+		log.Println("[assetService][GetAssets] error expand filter :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	if err := pagination.ParseSort(assetSortFields); err != nil {
+		return http.StatusBadRequest, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			},
+		}
+	}
 
-	assets, count, err := s.assetRepo.GetAssets(pagination)
+	if pagination.CursorMode {
+		return s.getAssetsByCursor(ctx, filter, pagination)
+	}
+
+	assets, count, err := s.assetRepo.GetAssets(ctx, filter, pagination)
 	if err != nil {
 		log.Println("[assetService][GetAssets] error get assets :", err)
 		return http.StatusInternalServerError, &dto.MetaPagination{
@@ -158,10 +246,14 @@ func (s *assetService) GetAssets(pagination *dto.MetaPagination) (code int, resp
 			Id:              v.Id,
 			Name:            v.Name,
 			Type:            v.Type,
+			CategoryId:      v.CategoryId,
+			LocationId:      v.LocationId,
+			Status:          v.Status,
 			Value:           v.Value,
 			AcquisitionDate: v.AcquisitionDate.Format("2006-01-02"),
 			CreatedAt:       v.CreatedAt.Format("2006-01-02"),
 			UpdatedAt:       v.UpdatedAt.Format("2006-01-02"),
+			Version:         v.Version,
 		})
 	}
 	pagination.Total = count
@@ -173,8 +265,8 @@ func (s *assetService) GetAssets(pagination *dto.MetaPagination) (code int, resp
 	return http.StatusOK, pagination
 }
 
-func (s *assetService) UpdateAsset(id string, input *dto.AssetInputDto) (code int, response *dto.BaseResponse) {
-	asset, err := s.assetRepo.GetAssetByAttribute(map[string]interface{}{
+func (s *assetService) UpdateAsset(ctx context.Context, id string, input *dto.AssetInputDto, ifMatch string) (code int, response *dto.BaseResponse) {
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
 		"id": id,
 	})
 	if err != nil {
@@ -192,27 +284,158 @@ func (s *assetService) UpdateAsset(id string, input *dto.AssetInputDto) (code in
 		}
 	}
 
+	if ifMatch != "" && !common.MatchesETag(ifMatch, asset.Version) {
+		return http.StatusPreconditionFailed, &dto.BaseResponse{
+			Error:            common.PreconditionFailed,
+			ErrorDescription: "Asset was changed since it was read",
+		}
+	}
+
+	before := assetAuditFields(asset)
+	if code, response := s.applyAssetInput(ctx, asset, input, "UpdateAsset"); response != nil {
+		return code, response
+	}
+
+	return s.saveAsset(ctx, asset, before, nil, ifMatch, "UpdateAsset")
+}
+
+// applyAssetInput validates an update of the asset and copies it to the asset
+func (s *assetService) applyAssetInput(ctx context.Context, asset *models.Asset, input *dto.AssetInputDto, method string) (code int, response *dto.BaseResponse) {
+	if errs := validation.Struct(input); errs != nil {
+		return http.StatusBadRequest, invalidInput(errs)
+	}
+
+	if input.Status != "" && input.Status != asset.Status {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Status can only be changed through the transitions endpoint",
+		}
+	}
+
+	if input.Value != asset.Value && !common.HasPermission(ctx, common.PermissionValue) {
+		return http.StatusForbidden, &dto.BaseResponse{
+			Error:            common.Forbidden,
+			ErrorDescription: "Changing the value requires the finance or admin role",
+		}
+	}
+
 	acqusitionDate, err := time.Parse("2006-01-02", input.AcquisitionDate)
 	if err != nil {
-		log.Println("[assetService][UpdateAsset] error parsing date :", err)
+		log.Println("[assetService]["+method+"] error parsing date :", err)
 		return http.StatusBadRequest, &dto.BaseResponse{
 			Error:            common.BadRequest,
 			ErrorDescription: "Invalid acqusition date format",
 		}
 	}
 
+	category, err := s.resolveCategory(ctx, input)
+	if err != nil {
+		if err == errCategoryRequired || err == errCategoryNotFound {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			}
+		}
+		log.Println("[assetService]["+method+"] error get category :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	locationId, err := s.resolveLocation(ctx, input)
+	if err != nil {
+		if err == errLocationNotFound {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			}
+		}
+		log.Println("[assetService]["+method+"] error get location :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
 	asset.Name = input.Name
-	asset.Type = input.Type
+	asset.Type = category.Name
+	asset.CategoryId = &category.Id
+	asset.LocationId = locationId
 	asset.Value = input.Value
 	asset.AcquisitionDate = acqusitionDate
+	applyCategoryDefaults(input, category)
+	if err = applyDepreciationSettings(asset, input); err != nil {
+		log.Println("[assetService]["+method+"] error validate depreciation :", err)
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid depreciation settings: " + err.Error(),
+		}
+	}
+
+	return 0, nil
+}
+
+// saveAsset writes the updated asset, only the given columns when columns is not nil, with
+// its audit log and event
+func (s *assetService) saveAsset(ctx context.Context, asset *models.Asset, before map[string]interface{}, columns []string, ifMatch string, method string) (code int, response *dto.BaseResponse) {
+	tx := s.assetRepo.StartTransaction(ctx)
+	var updated bool
+	var err error
+	if columns == nil {
+		updated, err = s.assetRepo.UpdateAsset(ctx, asset, tx)
+	} else {
+		updated, err = s.assetRepo.PatchAsset(ctx, asset, columns, tx)
+	}
+	if err != nil {
+		log.Println("[assetService]["+method+"] error update asset :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService]["+method+"] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// another request updated the asset after it was read
+	if !updated {
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService]["+method+"] error rollback transaction :", err)
+		}
+		if ifMatch != "" {
+			return http.StatusPreconditionFailed, &dto.BaseResponse{
+				Error:            common.PreconditionFailed,
+				ErrorDescription: "Asset was changed since it was read",
+			}
+		}
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Asset was changed by another request",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionUpdate, asset.Id, before, assetAuditFields(asset))
+	if err != nil {
+		log.Println("[assetService]["+method+"] error record audit :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService]["+method+"] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
 
-	tx := s.assetRepo.StartTransaction()
-	asset, err = s.assetRepo.UpdateAsset(asset, tx)
+	err = s.publishEvent(ctx, tx, common.EventAssetUpdated, asset, before, assetAuditFields(asset))
 	if err != nil {
-		log.Println("[assetService][UpdateAsset] error update asset :", err)
+		log.Println("[assetService]["+method+"] error publish event :", err)
 		err = s.assetRepo.RollbackTransaction(tx)
 		if err != nil {
-			log.Println("[assetService][UpdateAsset] error rollback transaction :", err)
+			log.Println("[assetService]["+method+"] error rollback transaction :", err)
 		}
 		return http.StatusInternalServerError, &dto.BaseResponse{
 			Error:            common.InternalServerError,
@@ -222,10 +445,10 @@ func (s *assetService) UpdateAsset(id string, input *dto.AssetInputDto) (code in
 
 	err = s.assetRepo.CommitTransaction(tx)
 	if err != nil {
-		log.Println("[assetService][UpdateAsset] error commit transaction :", err)
+		log.Println("[assetService]["+method+"] error commit transaction :", err)
 		err = s.assetRepo.RollbackTransaction(tx)
 		if err != nil {
-			log.Println("[assetService][UpdateAsset] error rollback transaction :", err)
+			log.Println("[assetService]["+method+"] error rollback transaction :", err)
 		}
 		return http.StatusInternalServerError, &dto.BaseResponse{
 			Error:            common.InternalServerError,
@@ -237,10 +460,14 @@ func (s *assetService) UpdateAsset(id string, input *dto.AssetInputDto) (code in
 		Id:              asset.Id,
 		Name:            asset.Name,
 		Type:            asset.Type,
+		CategoryId:      asset.CategoryId,
+		LocationId:      asset.LocationId,
+		Status:          asset.Status,
 		Value:           asset.Value,
 		AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02 15:04:05"),
 		CreatedAt:       asset.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:       asset.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Version:         asset.Version,
 	}
 
 	return http.StatusOK, &dto.BaseResponse{
@@ -249,13 +476,121 @@ func (s *assetService) UpdateAsset(id string, input *dto.AssetInputDto) (code in
 	}
 }
 
-func (s *assetService) DeleteAsset(id string) (code int, response *dto.BaseResponse) {
+// PatchAsset applies a JSON Merge Patch or a JSON Patch to the editable fields of an asset,
+// as named in AssetInputDto, and validates the result like UpdateAsset. Only the changed
+// columns are written. Patching type to another category name clears category_id unless
+// the patch also changes it.
+func (s *assetService) PatchAsset(ctx context.Context, id string, contentType string, patch []byte, ifMatch string) (code int, response *dto.BaseResponse) {
+	if contentType != jsonpatch.MergePatchType && contentType != jsonpatch.JSONPatchType {
+		return http.StatusUnsupportedMediaType, &dto.BaseResponse{
+			Error:            common.UnsupportedMedia,
+			ErrorDescription: "Content type must be " + jsonpatch.MergePatchType + " or " + jsonpatch.JSONPatchType,
+		}
+	}
 
-	asset, err := s.assetRepo.GetAssetByAttribute(map[string]interface{}{
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
 		"id": id,
 	})
 	if err != nil {
-		log.Println("[assetService][UpdateAsset] error get existing asset :", err)
+		log.Println("[assetService][PatchAsset] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	if ifMatch != "" && !common.MatchesETag(ifMatch, asset.Version) {
+		return http.StatusPreconditionFailed, &dto.BaseResponse{
+			Error:            common.PreconditionFailed,
+			ErrorDescription: "Asset was changed since it was read",
+		}
+	}
+
+	before := assetAuditFields(asset)
+	document, err := json.Marshal(before)
+	if err != nil {
+		log.Println("[assetService][PatchAsset] error marshal asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if contentType == jsonpatch.MergePatchType {
+		document, err = jsonpatch.MergePatch(document, patch)
+	} else {
+		document, err = jsonpatch.Apply(document, patch)
+	}
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return http.StatusConflict, &dto.BaseResponse{
+				Error:            common.Conflict,
+				ErrorDescription: "Invalid patch: " + err.Error(),
+			}
+		}
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid patch: " + err.Error(),
+		}
+	}
+
+	input := new(dto.AssetInputDto)
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid patched asset: " + err.Error(),
+		}
+	}
+	if input.Type != asset.Type && asset.CategoryId != nil && input.CategoryId == *asset.CategoryId {
+		input.CategoryId = ""
+	}
+
+	if code, response := s.applyAssetInput(ctx, asset, input, "PatchAsset"); response != nil {
+		return code, response
+	}
+
+	columns := []string{}
+	for _, v := range diffFields(before, assetAuditFields(asset)) {
+		columns = append(columns, v.Field)
+	}
+	if len(columns) == 0 {
+		return http.StatusOK, &dto.BaseResponse{
+			Message: common.Success,
+			Data: &dto.AssetOutputDto{
+				Id:              asset.Id,
+				Name:            asset.Name,
+				Type:            asset.Type,
+				CategoryId:      asset.CategoryId,
+				LocationId:      asset.LocationId,
+				Status:          asset.Status,
+				Value:           asset.Value,
+				AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02 15:04:05"),
+				CreatedAt:       asset.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt:       asset.UpdatedAt.Format("2006-01-02 15:04:05"),
+				Version:         asset.Version,
+			},
+		}
+	}
+
+	return s.saveAsset(ctx, asset, before, columns, ifMatch, "PatchAsset")
+}
+
+func (s *assetService) DeleteAsset(ctx context.Context, id string, ifMatch string) (code int, response *dto.BaseResponse) {
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[assetService][DeleteAsset] error get existing asset :", err)
 		return http.StatusInternalServerError, &dto.BaseResponse{
 			Error:            common.InternalServerError,
 			ErrorDescription: "Something went wrong",
@@ -269,8 +604,15 @@ func (s *assetService) DeleteAsset(id string) (code int, response *dto.BaseRespo
 		}
 	}
 
-	tx := s.assetRepo.StartTransaction()
-	err = s.assetRepo.DeleteAsset(asset, tx)
+	if ifMatch != "" && !common.MatchesETag(ifMatch, asset.Version) {
+		return http.StatusPreconditionFailed, &dto.BaseResponse{
+			Error:            common.PreconditionFailed,
+			ErrorDescription: "Asset was changed since it was read",
+		}
+	}
+
+	tx := s.assetRepo.StartTransaction(ctx)
+	deleted, err := s.assetRepo.DeleteAsset(ctx, asset, tx)
 	if err != nil {
 		log.Println("[assetService][DeleteAsset] error delete asset :", err)
 		err = s.assetRepo.RollbackTransaction(tx)
@@ -282,6 +624,52 @@ func (s *assetService) DeleteAsset(id string) (code int, response *dto.BaseRespo
 			ErrorDescription: "Something went wrong",
 		}
 	}
+
+	// another request updated the asset after it was read
+	if !deleted {
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][DeleteAsset] error rollback transaction :", err)
+		}
+		if ifMatch != "" {
+			return http.StatusPreconditionFailed, &dto.BaseResponse{
+				Error:            common.PreconditionFailed,
+				ErrorDescription: "Asset was changed since it was read",
+			}
+		}
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Asset was changed by another request",
+		}
+	}
+	before := map[string]interface{}{"deleted_at": nil}
+	after := map[string]interface{}{"deleted_at": time.Now().UTC().Format("2006-01-02 15:04:05")}
+	err = s.recordAudit(ctx, tx, common.AuditActionDelete, asset.Id, before, after)
+	if err != nil {
+		log.Println("[assetService][DeleteAsset] error record audit :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][DeleteAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.publishEvent(ctx, tx, common.EventAssetDeleted, asset, before, after)
+	if err != nil {
+		log.Println("[assetService][DeleteAsset] error publish event :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][DeleteAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
 	err = s.assetRepo.CommitTransaction(tx)
 	if err != nil {
 		log.Println("[assetService][DeleteAsset] error commit transaction :", err)
@@ -299,3 +687,1021 @@ func (s *assetService) DeleteAsset(id string) (code int, response *dto.BaseRespo
 		Message: common.Success,
 	}
 }
+
+func (s *assetService) GetDepreciationSchedule(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[assetService][GetDepreciationSchedule] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	if asset.DepreciationMethod == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Asset has no depreciation settings",
+		}
+	}
+
+	periods, err := depreciation.Schedule(depreciationParams(asset))
+	if err != nil {
+		log.Println("[assetService][GetDepreciationSchedule] error calculate schedule :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	resData := &dto.DepreciationScheduleDto{
+		AssetId:      asset.Id,
+		Cost:         asset.Value,
+		SalvageValue: asset.SalvageValue,
+		Method:       asset.DepreciationMethod,
+		Convention:   asset.DepreciationConvention,
+		Periods:      []*dto.DepreciationPeriodDto{},
+	}
+	for _, v := range periods {
+		resData.Periods = append(resData.Periods, &dto.DepreciationPeriodDto{
+			Period:                  v.Period,
+			StartDate:               v.StartDate.Format("2006-01-02"),
+			EndDate:                 v.EndDate.Format("2006-01-02"),
+			Depreciation:            v.Amount,
+			AccumulatedDepreciation: v.Accumulated,
+			BookValue:               v.BookValue,
+		})
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    resData,
+	}
+}
+
+func (s *assetService) GetDeletedAssets(ctx context.Context, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+
+	assets, count, err := s.assetRepo.GetDeletedAssets(ctx, pagination)
+	if err != nil {
+		log.Println("[assetService][GetDeletedAssets] error get deleted assets :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	assetsRes := []*dto.AssetOutputDto{}
+	for _, v := range assets {
+		assetsRes = append(assetsRes, &dto.AssetOutputDto{
+			Id:              v.Id,
+			Name:            v.Name,
+			Type:            v.Type,
+			CategoryId:      v.CategoryId,
+			LocationId:      v.LocationId,
+			Status:          v.Status,
+			Value:           v.Value,
+			AcquisitionDate: v.AcquisitionDate.Format("2006-01-02"),
+			CreatedAt:       v.CreatedAt.Format("2006-01-02"),
+			UpdatedAt:       v.UpdatedAt.Format("2006-01-02"),
+			Version:         v.Version,
+			DeletedAt:       v.DeletedAt.Time.Format("2006-01-02 15:04:05"),
+		})
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = assetsRes
+	return http.StatusOK, pagination
+}
+
+func (s *assetService) RestoreAsset(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	asset, err := s.assetRepo.GetDeletedAssetByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[assetService][RestoreAsset] error get deleted asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found in trash",
+		}
+	}
+
+	// restoring must not create a duplicate of an active asset
+	existing, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"name": asset.Name,
+		"type": asset.Type,
+	})
+	if err != nil {
+		log.Println("[assetService][RestoreAsset] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if existing != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Asset already exist",
+		}
+	}
+
+	deletedAt := asset.DeletedAt.Time.Format("2006-01-02 15:04:05")
+	tx := s.assetRepo.StartTransaction(ctx)
+	err = s.assetRepo.RestoreAsset(ctx, asset, tx)
+	if err != nil {
+		log.Println("[assetService][RestoreAsset] error restore asset :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][RestoreAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionRestore, asset.Id,
+		map[string]interface{}{"deleted_at": deletedAt},
+		map[string]interface{}{"deleted_at": nil})
+	if err != nil {
+		log.Println("[assetService][RestoreAsset] error record audit :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][RestoreAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.publishEvent(ctx, tx, common.EventAssetRestored, asset,
+		map[string]interface{}{"deleted_at": deletedAt},
+		map[string]interface{}{"deleted_at": nil})
+	if err != nil {
+		log.Println("[assetService][RestoreAsset] error publish event :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][RestoreAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.assetRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[assetService][RestoreAsset] error commit transaction :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][RestoreAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	resData := &dto.AssetOutputDto{
+		Id:              asset.Id,
+		Name:            asset.Name,
+		Type:            asset.Type,
+		CategoryId:      asset.CategoryId,
+		LocationId:      asset.LocationId,
+		Status:          asset.Status,
+		Value:           asset.Value,
+		AcquisitionDate: asset.AcquisitionDate.Format("2006-01-02 15:04:05"),
+		CreatedAt:       asset.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:       asset.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Version:         asset.Version,
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    resData,
+	}
+}
+
+func (s *assetService) PurgeAsset(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	asset, err := s.assetRepo.GetDeletedAssetByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[assetService][PurgeAsset] error get deleted asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found in trash",
+		}
+	}
+
+	if err = s.purgeAsset(ctx, asset); err != nil {
+		log.Println("[assetService][PurgeAsset] error purge asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// PurgeExpiredAssets permanently removes assets that stayed in the trash longer than the
+// retention period, each the same way as PurgeAsset in the tenant of the asset
+func (s *assetService) PurgeExpiredAssets(ctx context.Context, retentionDays int) (purged int64, err error) {
+	before := time.Now().UTC().AddDate(0, 0, -retentionDays)
+	assets, err := s.assetRepo.GetDeletedAssetsBefore(ctx, before)
+	if err != nil {
+		log.Println("[assetService][PurgeExpiredAssets] error get expired assets :", err)
+		return 0, err
+	}
+
+	for _, v := range assets {
+		if err = s.purgeAsset(common.WithTenant(ctx, v.TenantId), v); err != nil {
+			log.Println("[assetService][PurgeExpiredAssets] error purge asset :", err)
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purgeAsset permanently deletes a trashed asset with its dependent rows and attachments,
+// recording the purge in the audit trail and the outbox in the same transaction. The stored
+// attachment files are removed once the records are gone, a file left behind by a storage
+// error is only logged.
+func (s *assetService) purgeAsset(ctx context.Context, asset *models.Asset) error {
+	attachments, err := s.attachmentRepo.GetAttachments(ctx, asset.Id)
+	if err != nil {
+		return err
+	}
+
+	tx := s.assetRepo.StartTransaction(ctx)
+	err = s.assetRepo.PurgeAsset(ctx, asset, tx)
+	if err == nil {
+		err = s.recordAudit(ctx, tx, common.AuditActionPurge, asset.Id, assetAuditFields(asset), nil)
+	}
+	if err == nil {
+		err = s.publishEvent(ctx, tx, common.EventAssetPurged, asset, assetAuditFields(asset), nil)
+	}
+	if err == nil {
+		err = s.assetRepo.CommitTransaction(tx)
+	}
+	if err != nil {
+		if rollbackErr := s.assetRepo.RollbackTransaction(tx); rollbackErr != nil {
+			log.Println("[assetService][purgeAsset] error rollback transaction :", rollbackErr)
+		}
+		return err
+	}
+
+	for _, v := range attachments {
+		for _, key := range []string{v.StorageKey, v.ThumbnailKey} {
+			if key == "" {
+				continue
+			}
+			if err := s.storage.Delete(ctx, key); err != nil {
+				log.Printf("[assetService][purgeAsset] error delete file %s : %v", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// TransitionAsset moves an asset to another lifecycle status along the transition graph
+func (s *assetService) TransitionAsset(ctx context.Context, id string, input *dto.AssetTransitionInputDto) (code int, response *dto.BaseResponse) {
+	input.Status = strings.ToLower(strings.TrimSpace(input.Status))
+	if !isAssetStatus(input.Status) {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid status, allowed statuses are " + strings.Join(common.AssetStatuses, ", "),
+		}
+	}
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Reason is required",
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[assetService][TransitionAsset] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	fromStatus := asset.Status
+	if !s.canTransition(fromStatus, input.Status) {
+		return http.StatusConflict, s.invalidTransition(fromStatus, input.Status)
+	}
+
+	asset.Status = input.Status
+	transition := &models.AssetTransition{
+		AssetId:        asset.Id,
+		FromStatus:     fromStatus,
+		ToStatus:       input.Status,
+		Reason:         input.Reason,
+		TransitionedBy: common.ActorFromContext(ctx),
+	}
+
+	tx := s.assetRepo.StartTransaction(ctx)
+	moved, err := s.assetRepo.TransitionAsset(ctx, asset, fromStatus, tx)
+	if err != nil {
+		log.Println("[assetService][TransitionAsset] error transition asset :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][TransitionAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// another request changed the status after it was read
+	if !moved {
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][TransitionAsset] error rollback transaction :", err)
+		}
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Asset status was changed by another request",
+		}
+	}
+
+	transition, err = s.assetRepo.CreateAssetTransition(ctx, transition, tx)
+	if err != nil {
+		log.Println("[assetService][TransitionAsset] error create transition :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][TransitionAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionTransition, asset.Id,
+		map[string]interface{}{"status": fromStatus},
+		map[string]interface{}{"status": asset.Status})
+	if err != nil {
+		log.Println("[assetService][TransitionAsset] error record audit :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][TransitionAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.publishEvent(ctx, tx, common.EventAssetStatusChanged, asset,
+		map[string]interface{}{"status": fromStatus},
+		map[string]interface{}{"status": asset.Status})
+	if err != nil {
+		log.Println("[assetService][TransitionAsset] error publish event :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][TransitionAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.assetRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[assetService][TransitionAsset] error commit transaction :", err)
+		err = s.assetRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assetService][TransitionAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    assetTransitionOutput(transition),
+	}
+}
+
+// GetAssetTransitions returns the lifecycle transitions of an asset, newest first
+func (s *assetService) GetAssetTransitions(ctx context.Context, id string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	transitions, count, err := s.assetRepo.GetAssetTransitions(ctx, id, pagination)
+	if err != nil {
+		log.Println("[assetService][GetAssetTransitions] error get transitions :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	transitionsRes := []*dto.AssetTransitionOutputDto{}
+	for _, v := range transitions {
+		transitionsRes = append(transitionsRes, assetTransitionOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = transitionsRes
+	return http.StatusOK, pagination
+}
+
+func (s *assetService) canTransition(from, to string) bool {
+	for _, v := range s.transitions[from] {
+		if v == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *assetService) invalidTransition(from, to string) *dto.BaseResponse {
+	allowed := s.transitions[from]
+	if allowed == nil {
+		allowed = []string{}
+	}
+
+	description := fmt.Sprintf("Cannot move asset from %s to %s", from, to)
+	if len(allowed) == 0 {
+		description = fmt.Sprintf("Cannot move asset from %s, it is a final status", from)
+	}
+
+	return &dto.BaseResponse{
+		Error:            common.InvalidTransition,
+		ErrorDescription: description,
+		Data: &dto.InvalidTransitionDto{
+			CurrentStatus:   from,
+			RequestedStatus: to,
+			AllowedStatuses: allowed,
+		},
+	}
+}
+
+func (s *assetService) recordAudit(ctx context.Context, tx *gorm.DB, action string, assetId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityAsset, assetId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// publishEvent writes an asset event to the outbox in tx, the webhooks only receive it once tx
+// is committed
+func (s *assetService) publishEvent(ctx context.Context, tx *gorm.DB, eventType string, asset *models.Asset, before, after map[string]interface{}) error {
+	event, err := newOutboxEvent(ctx, eventType, &dto.AssetEventDto{
+		TenantId:  common.TenantFromContext(ctx),
+		AssetId:   asset.Id,
+		Actor:     common.ActorFromContext(ctx),
+		RequestId: common.RequestIdFromContext(ctx),
+		Asset:     assetAuditFields(asset),
+		Changes:   diffFields(before, after),
+	})
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.CreateOutboxEvent(ctx, event, tx)
+}
+
+// assetAuditFields returns the audited fields of an asset keyed by their json name
+func assetAuditFields(asset *models.Asset) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                    asset.Name,
+		"type":                    asset.Type,
+		"category_id":             asset.CategoryId,
+		"location_id":             asset.LocationId,
+		"status":                  asset.Status,
+		"value":                   asset.Value,
+		"acquisition_date":        asset.AcquisitionDate.Format("2006-01-02"),
+		"depreciation_method":     asset.DepreciationMethod,
+		"depreciation_convention": asset.DepreciationConvention,
+		"useful_life_years":       asset.UsefulLifeYears,
+		"salvage_value":           asset.SalvageValue,
+		"declining_factor":        asset.DecliningFactor,
+		"total_units":             asset.TotalUnits,
+		"units_used":              asset.UnitsUsed,
+	}
+}
+
+func (s *assetService) getAssetsByCursor(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	pagination.Page = 0
+	pagination.Offset = 0
+	if pagination.Cursor != "" {
+		cursor, err := parseAssetCursor(pagination.Cursor)
+		if err != nil {
+			log.Println("[assetService][GetAssets] error parsing cursor :", err)
+			return http.StatusBadRequest, &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: dto.ErrInvalidCursor.Error(),
+				},
+			}
+		}
+		pagination.DecodedCursor = cursor
+	}
+
+	assets, hasMore, err := s.assetRepo.GetAssetsByCursor(ctx, filter, pagination)
+	if err != nil {
+		log.Println("[assetService][GetAssets] error get assets by cursor :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	if pagination.IncludeTotal {
+		pagination.Total, err = s.assetRepo.CountAssets(ctx, filter)
+		if err != nil {
+			log.Println("[assetService][GetAssets] error count assets :", err)
+			return http.StatusInternalServerError, &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.InternalServerError,
+					ErrorDescription: "Something went wrong",
+				},
+			}
+		}
+	}
+
+	assetsRes := []*dto.AssetOutputDto{}
+	for _, v := range assets {
+		assetsRes = append(assetsRes, &dto.AssetOutputDto{
+			Id:              v.Id,
+			Name:            v.Name,
+			Type:            v.Type,
+			CategoryId:      v.CategoryId,
+			LocationId:      v.LocationId,
+			Status:          v.Status,
+			Value:           v.Value,
+			AcquisitionDate: v.AcquisitionDate.Format("2006-01-02"),
+			CreatedAt:       v.CreatedAt.Format("2006-01-02"),
+			UpdatedAt:       v.UpdatedAt.Format("2006-01-02"),
+			Version:         v.Version,
+		})
+	}
+
+	keys := pagination.SortKeys(repositories.DefaultAssetSort)
+	before := false
+	if pagination.DecodedCursor != nil {
+		keys = pagination.DecodedCursor.Sorts
+		before = pagination.DecodedCursor.Before
+	}
+	if len(assets) > 0 {
+		first, last := assets[0], assets[len(assets)-1]
+		// paging forward there is a previous page once we left the first one, paging
+		// backwards there is always a next page to return to
+		if hasMore || before {
+			pagination.NextCursor = dto.EncodeCursor(&dto.Cursor{Sorts: keys, Values: assetCursorValues(last, keys)})
+		}
+		if (before && hasMore) || (!before && pagination.DecodedCursor != nil) {
+			pagination.PrevCursor = dto.EncodeCursor(&dto.Cursor{Sorts: keys, Values: assetCursorValues(first, keys), Before: true})
+		}
+	}
+
+	pagination.Data = assetsRes
+	return http.StatusOK, pagination
+}
+
+// parseAssetCursor decodes a cursor and checks it only references sortable fields,
+// converting the encoded timestamps back to time values
+func parseAssetCursor(value string) (*dto.Cursor, error) {
+	cursor, err := dto.DecodeCursor(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Sorts[len(cursor.Sorts)-1].Field != "id" {
+		return nil, dto.ErrInvalidCursor
+	}
+
+	for i, key := range cursor.Sorts {
+		valid := false
+		for _, v := range assetSortFields {
+			if v == key.Field {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, dto.ErrInvalidCursor
+		}
+
+		switch key.Field {
+		case "acquisition_date", "created_at", "updated_at":
+			str, ok := cursor.Values[i].(string)
+			if !ok {
+				return nil, dto.ErrInvalidCursor
+			}
+			if cursor.Values[i], err = time.Parse(time.RFC3339Nano, str); err != nil {
+				return nil, dto.ErrInvalidCursor
+			}
+		case "value":
+			if _, ok := cursor.Values[i].(float64); !ok {
+				return nil, dto.ErrInvalidCursor
+			}
+		default:
+			if _, ok := cursor.Values[i].(string); !ok {
+				return nil, dto.ErrInvalidCursor
+			}
+		}
+	}
+
+	return cursor, nil
+}
+
+// assetCursorValues returns the asset value of every sort key
+func assetCursorValues(asset *models.Asset, keys []dto.SortField) []interface{} {
+	values := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		switch key.Field {
+		case "id":
+			values = append(values, asset.Id)
+		case "name":
+			values = append(values, asset.Name)
+		case "type":
+			values = append(values, asset.Type)
+		case "value":
+			values = append(values, asset.Value)
+		case "acquisition_date":
+			values = append(values, asset.AcquisitionDate.UTC().Format(time.RFC3339Nano))
+		case "created_at":
+			values = append(values, asset.CreatedAt.UTC().Format(time.RFC3339Nano))
+		case "updated_at":
+			values = append(values, asset.UpdatedAt.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	return values
+}
+
+// parseAssetFilter parses the date filters and checks the ranges
+func parseAssetFilter(filter *dto.AssetFilterDto) error {
+	dates := []struct {
+		name       string
+		value      string
+		target     *time.Time
+		upperBound bool
+	}{
+		{"acquisition_date_from", filter.AcquisitionDateFrom, &filter.AcquisitionDateFromTime, false},
+		{"acquisition_date_to", filter.AcquisitionDateTo, &filter.AcquisitionDateToTime, true},
+		{"created_from", filter.CreatedFrom, &filter.CreatedFromTime, false},
+		{"created_to", filter.CreatedTo, &filter.CreatedToTime, true},
+		{"updated_from", filter.UpdatedFrom, &filter.UpdatedFromTime, false},
+		{"updated_to", filter.UpdatedTo, &filter.UpdatedToTime, true},
+	}
+	for _, d := range dates {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := parseFilterTime(d.value, d.upperBound)
+		if err != nil {
+			return fmt.Errorf("Invalid %s date format", d.name)
+		}
+		*d.target = parsed
+	}
+
+	if filter.ValueMin != nil && filter.ValueMax != nil && *filter.ValueMin > *filter.ValueMax {
+		return errors.New("value_min must not be greater than value_max")
+	}
+
+	switch filter.Status {
+	case "", common.AssignmentStatusAvailable, common.AssignmentStatusCheckedOut:
+	case common.AssignmentStatusOverdue:
+		filter.DueBefore = today()
+	default:
+		return fmt.Errorf("Invalid status %s, allowed statuses are %s, %s, %s", filter.Status,
+			common.AssignmentStatusAvailable, common.AssignmentStatusCheckedOut, common.AssignmentStatusOverdue)
+	}
+
+	return nil
+}
+
+// expandAssetFilter widens the category and location filters to their whole subtree
+func (s *assetService) expandAssetFilter(ctx context.Context, filter *dto.AssetFilterDto) error {
+	if filter.CategoryId != "" {
+		descendants, err := s.categoryRepo.GetDescendantIds(ctx, filter.CategoryId)
+		if err != nil {
+			return fmt.Errorf("get category descendants: %w", err)
+		}
+		filter.CategoryIds = append([]string{filter.CategoryId}, descendants...)
+	}
+	if filter.LocationId != "" {
+		descendants, err := s.locationRepo.GetDescendantIds(ctx, filter.LocationId)
+		if err != nil {
+			return fmt.Errorf("get location descendants: %w", err)
+		}
+		filter.LocationIds = append([]string{filter.LocationId}, descendants...)
+	}
+	return nil
+}
+
+// newAsset validates the input of a new asset the way CreateAsset does and returns the asset
+// to insert, or the response to send back when the input is rejected. method names the caller
+// in the logs.
+func (s *assetService) newAsset(ctx context.Context, input *dto.AssetInputDto, method string) (asset *models.Asset, code int, response *dto.BaseResponse) {
+	if errs := validation.Struct(input); errs != nil {
+		return nil, http.StatusBadRequest, invalidInput(errs)
+	}
+
+	category, err := s.resolveCategory(ctx, input)
+	if err != nil {
+		if err == errCategoryRequired || err == errCategoryNotFound {
+			return nil, http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			}
+		}
+		log.Println("[assetService]["+method+"] error get category :", err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	locationId, err := s.resolveLocation(ctx, input)
+	if err != nil {
+		if err == errLocationNotFound {
+			return nil, http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			}
+		}
+		log.Println("[assetService]["+method+"] error get location :", err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// get asset by name and type
+	existing, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"name": input.Name,
+		"type": category.Name,
+	})
+	if err != nil {
+		log.Println("[assetService]["+method+"] error get existing asset :", err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if existing != nil {
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Asset already exist",
+		}
+	}
+
+	acqusitionDate, err := time.Parse("2006-01-02", input.AcquisitionDate)
+	if err != nil {
+		log.Println("[assetService]["+method+"] error parsing date :", err)
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid acqusition date format",
+		}
+	}
+
+	if input.Status == "" {
+		input.Status = common.AssetStatusInStock
+	}
+	if !isAssetStatus(input.Status) {
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid status, allowed statuses are " + strings.Join(common.AssetStatuses, ", "),
+		}
+	}
+
+	asset = &models.Asset{
+		Name:            input.Name,
+		Type:            category.Name,
+		CategoryId:      &category.Id,
+		LocationId:      locationId,
+		Status:          input.Status,
+		Value:           input.Value,
+		AcquisitionDate: acqusitionDate,
+	}
+	applyCategoryDefaults(input, category)
+	if err = applyDepreciationSettings(asset, input); err != nil {
+		log.Println("[assetService]["+method+"] error validate depreciation :", err)
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid depreciation settings: " + err.Error(),
+		}
+	}
+
+	return asset, 0, nil
+}
+
+// invalidInput is the 400 of an input failing the rules of its validate tags, listing every
+// invalid field
+func invalidInput(errs []dto.FieldErrorDto) *dto.BaseResponse {
+	return &dto.BaseResponse{
+		Error:            common.BadRequest,
+		ErrorDescription: validation.Message(errs),
+		Errors:           errs,
+	}
+}
+
+// resolveCategory returns the category referenced by category_id or, for clients that
+// still send a free-text type, the category with that name
+func (s *assetService) resolveCategory(ctx context.Context, input *dto.AssetInputDto) (*models.Category, error) {
+	var category *models.Category
+	var err error
+	switch {
+	case input.CategoryId != "":
+		category, err = s.categoryRepo.GetCategoryByAttribute(ctx, map[string]interface{}{
+			"id": input.CategoryId,
+		})
+	case strings.TrimSpace(input.Type) != "":
+		category, err = s.categoryRepo.GetCategoryByName(ctx, input.Type)
+	default:
+		return nil, errCategoryRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, errCategoryNotFound
+	}
+	return category, nil
+}
+
+// resolveLocation checks the location of the input exists. An empty location_id clears
+// the location.
+func (s *assetService) resolveLocation(ctx context.Context, input *dto.AssetInputDto) (*string, error) {
+	if input.LocationId == "" {
+		return nil, nil
+	}
+
+	location, err := s.locationRepo.GetLocationByAttribute(ctx, map[string]interface{}{
+		"id": input.LocationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		return nil, errLocationNotFound
+	}
+	return &location.Id, nil
+}
+
+// applyCategoryDefaults fills in the category depreciation settings when the input has none
+func applyCategoryDefaults(input *dto.AssetInputDto, category *models.Category) {
+	if input.DepreciationMethod != "" || category.DepreciationMethod == "" {
+		return
+	}
+
+	input.DepreciationMethod = category.DepreciationMethod
+	input.DepreciationConvention = category.DepreciationConvention
+	input.UsefulLifeYears = category.UsefulLifeYears
+	input.DecliningFactor = category.DecliningFactor
+}
+
+// isAssetStatus reports whether status is one of the lifecycle statuses
+func isAssetStatus(status string) bool {
+	for _, v := range common.AssetStatuses {
+		if v == status {
+			return true
+		}
+	}
+	return false
+}
+
+// assetTransitionOutput maps a status transition to its response DTO
+func assetTransitionOutput(transition *models.AssetTransition) *dto.AssetTransitionOutputDto {
+	return &dto.AssetTransitionOutputDto{
+		Id:             transition.Id,
+		AssetId:        transition.AssetId,
+		FromStatus:     transition.FromStatus,
+		ToStatus:       transition.ToStatus,
+		Reason:         transition.Reason,
+		TransitionedAt: transition.TransitionedAt.Format("2006-01-02 15:04:05"),
+		TransitionedBy: transition.TransitionedBy,
+	}
+}
+
+// applyDepreciationSettings validates the depreciation input and copies it to the asset.
+// An empty method clears the settings.
+func applyDepreciationSettings(asset *models.Asset, input *dto.AssetInputDto) error {
+	asset.DepreciationMethod = input.DepreciationMethod
+	asset.DepreciationConvention = input.DepreciationConvention
+	asset.UsefulLifeYears = input.UsefulLifeYears
+	asset.SalvageValue = input.SalvageValue
+	asset.DecliningFactor = input.DecliningFactor
+	asset.TotalUnits = input.TotalUnits
+	asset.UnitsUsed = input.UnitsUsed
+
+	if asset.DepreciationMethod == "" {
+		asset.DepreciationConvention = ""
+		asset.UsefulLifeYears = 0
+		asset.SalvageValue = 0
+		asset.DecliningFactor = 0
+		asset.TotalUnits = 0
+		asset.UnitsUsed = 0
+		return nil
+	}
+
+	if asset.DepreciationConvention == "" {
+		asset.DepreciationConvention = depreciation.FullMonth
+	}
+	if asset.DepreciationMethod == depreciation.DecliningBalance && asset.DecliningFactor == 0 {
+		asset.DecliningFactor = depreciation.DefaultDecliningFactor
+	}
+
+	return depreciation.Validate(depreciationParams(asset))
+}
+
+func depreciationParams(asset *models.Asset) depreciation.Params {
+	return depreciation.Params{
+		Cost:            asset.Value,
+		Salvage:         asset.SalvageValue,
+		UsefulLifeYears: asset.UsefulLifeYears,
+		Method:          asset.DepreciationMethod,
+		Convention:      asset.DepreciationConvention,
+		DecliningFactor: asset.DecliningFactor,
+		TotalUnits:      asset.TotalUnits,
+		UnitsUsed:       asset.UnitsUsed,
+		AcquisitionDate: asset.AcquisitionDate,
+	}
+}
+
+func currentDepreciation(asset *models.Asset, at time.Time) (*dto.AssetDepreciationDto, error) {
+	params := depreciationParams(asset)
+	accumulated, bookValue, err := depreciation.ValueAt(params, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AssetDepreciationDto{
+		Method:                  asset.DepreciationMethod,
+		Convention:              asset.DepreciationConvention,
+		UsefulLifeYears:         asset.UsefulLifeYears,
+		SalvageValue:            asset.SalvageValue,
+		DecliningFactor:         asset.DecliningFactor,
+		TotalUnits:              asset.TotalUnits,
+		UnitsUsed:               asset.UnitsUsed,
+		StartDate:               depreciation.StartDate(params).Format("2006-01-02"),
+		AccumulatedDepreciation: accumulated,
+		BookValue:               bookValue,
+		AsOf:                    at.Format("2006-01-02"),
+	}, nil
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
@@ -9,7 +11,9 @@ import (
 	"assets-api-go/internal/common"
 	"assets-api-go/internal/dto"
 	"assets-api-go/internal/models"
+	"assets-api-go/internal/validation"
 	"assets-api-go/mocks/repositories"
+	mockStorage "assets-api-go/mocks/storage"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +25,11 @@ func TestGetAssetById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
-	service := NewAssetService(mockRepo)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
 
 	testTime := time.Now()
 	testAsset := &models.Asset{
@@ -33,6 +41,19 @@ func TestGetAssetById(t *testing.T) {
 		CreatedAt:       testTime,
 		UpdatedAt:       testTime,
 	}
+	depreciatedAsset := &models.Asset{
+		Id:                     "depreciated-id",
+		Name:                   "Test Asset",
+		Type:                   "Test Type",
+		Value:                  1000,
+		AcquisitionDate:        time.Date(2000, 6, 20, 0, 0, 0, 0, time.UTC),
+		DepreciationMethod:     "straight_line",
+		DepreciationConvention: "full_month",
+		UsefulLifeYears:        3,
+		SalvageValue:           100,
+		CreatedAt:              testTime,
+		UpdatedAt:              testTime,
+	}
 
 	tests := []struct {
 		name           string
@@ -45,7 +66,7 @@ func TestGetAssetById(t *testing.T) {
 			name: "Success - Asset found",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedResult: &dto.BaseResponse{
@@ -61,11 +82,41 @@ func TestGetAssetById(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Success - Asset found with depreciation",
+			id:   "depreciated-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "depreciated-id"}).Return(depreciatedAsset, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.BaseResponse{
+				Message: common.Success,
+				Data: &dto.AssetOutputDto{
+					Id:              "depreciated-id",
+					Name:            "Test Asset",
+					Type:            "Test Type",
+					Value:           1000,
+					AcquisitionDate: "2000-06-20 00:00:00",
+					Depreciation: &dto.AssetDepreciationDto{
+						Method:                  "straight_line",
+						Convention:              "full_month",
+						UsefulLifeYears:         3,
+						SalvageValue:            100,
+						StartDate:               "2000-06-01",
+						AccumulatedDepreciation: 900,
+						BookValue:               100,
+						AsOf:                    time.Now().UTC().Format("2006-01-02"),
+					},
+					CreatedAt: testTime.Format("2006-01-02 15:04:05"),
+					UpdatedAt: testTime.Format("2006-01-02 15:04:05"),
+				},
+			},
+		},
 		{
 			name: "Error - Asset not found",
 			id:   "non-existent-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
 			},
 			expectedCode: http.StatusNotFound,
 			expectedResult: &dto.BaseResponse{
@@ -77,7 +128,7 @@ func TestGetAssetById(t *testing.T) {
 			name: "Error - Repository error",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedResult: &dto.BaseResponse{
@@ -90,7 +141,7 @@ func TestGetAssetById(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			code, response := service.GetAssetById(tt.id)
+			code, response := service.GetAssetById(context.Background(), tt.id)
 			assert.Equal(t, tt.expectedCode, code)
 			assert.Equal(t, tt.expectedResult, response)
 		})
@@ -102,7 +153,12 @@ func TestCreateAsset(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
-	service := NewAssetService(mockRepo)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Test Type").Return(&models.Category{Id: "category-id", Name: "Test Type"}, nil).AnyTimes()
 
 	tests := []struct {
 		name           string
@@ -120,15 +176,17 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any()).Return(&models.Asset{
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Asset{
 					Id:              "test-id",
 					Name:            "Test Asset",
 					Type:            "Test Type",
 					Value:           1000,
 					AcquisitionDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
 				}, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusCreated,
@@ -152,7 +210,7 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(&models.Asset{}, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedResult: &dto.BaseResponse{
@@ -168,13 +226,67 @@ func TestCreateAsset(t *testing.T) {
 				Value:           1000,
 				AcquisitionDate: "invalid-date",
 			},
+			mockSetup:    func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "acquisition_date must be a date in the format YYYY-MM-DD",
+				Errors:           []dto.FieldErrorDto{{Field: "acquisition_date", Code: validation.CodeDateFormat, Message: "acquisition_date must be a date in the format YYYY-MM-DD"}},
+			},
+		},
+		{
+			name: "Error - Invalid fields",
+			input: &dto.AssetInputDto{
+				Type:            "Test Type",
+				Value:           -5,
+				AcquisitionDate: "2999-01-01",
+			},
+			mockSetup:    func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "name is required; value must be 0 or more; acquisition_date must not be in the future",
+				Errors: []dto.FieldErrorDto{
+					{Field: "name", Code: validation.CodeRequired, Message: "name is required"},
+					{Field: "value", Code: validation.CodeMin, Message: "value must be 0 or more"},
+					{Field: "acquisition_date", Code: validation.CodeFutureDate, Message: "acquisition_date must not be in the future"},
+				},
+			},
+		},
+		{
+			name: "Error - Invalid depreciation settings",
+			input: &dto.AssetInputDto{
+				Name:               "Test Asset",
+				Type:               "Test Type",
+				Value:              1000,
+				AcquisitionDate:    "2023-01-01",
+				DepreciationMethod: "straight_line",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Invalid depreciation settings: useful life must be greater than zero",
+			},
+		},
+		{
+			name: "Error - Invalid status",
+			input: &dto.AssetInputDto{
+				Name:            "Test Asset",
+				Type:            "Test Type",
+				Status:          "sold",
+				Value:           1000,
+				AcquisitionDate: "2023-01-01",
+			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedResult: &dto.BaseResponse{
 				Error:            common.BadRequest,
-				ErrorDescription: "Invalid acqusition date format",
+				ErrorDescription: "Invalid status, allowed statuses are ordered, in_stock, deployed, in_repair, retired, disposed, lost",
 			},
 		},
 		{
@@ -186,7 +298,7 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, errors.New("get exist asset error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, errors.New("get exist asset error"))
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedResult: &dto.BaseResponse{
@@ -203,9 +315,52 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any()).Return(nil, errors.New("create error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("create error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		},
+		{
+			name: "Error - Failed to record audit",
+			input: &dto.AssetInputDto{
+				Name:            "Test Asset",
+				Type:            "Test Type",
+				Value:           1000,
+				AcquisitionDate: "2023-01-01",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("audit error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		},
+		{
+			name: "Error - Failed to publish event",
+			input: &dto.AssetInputDto{
+				Name:            "Test Asset",
+				Type:            "Test Type",
+				Value:           1000,
+				AcquisitionDate: "2023-01-01",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("outbox error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusInternalServerError,
@@ -223,9 +378,11 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("commit error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
@@ -244,9 +401,11 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Asset{}, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("commit error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("rollback error"))
 			},
@@ -265,9 +424,9 @@ func TestCreateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any()).Return(nil, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any()).Return(nil, errors.New("create error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("create error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("rollback error"))
 			},
 			expectedCode: http.StatusInternalServerError,
@@ -281,7 +440,7 @@ func TestCreateAsset(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			code, _ := service.CreateAsset(tt.input)
+			code, _ := service.CreateAsset(context.Background(), tt.input)
 			assert.Equal(t, tt.expectedCode, code)
 		})
 	}
@@ -292,7 +451,11 @@ func TestGetAssets(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
-	service := NewAssetService(mockRepo)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
 
 	testTime := time.Now()
 	testAssets := []*models.Asset{
@@ -316,21 +479,26 @@ func TestGetAssets(t *testing.T) {
 		},
 	}
 
+	valueMin := 2000.0
+	valueMax := 1000.0
+
 	tests := []struct {
 		name           string
+		filter         *dto.AssetFilterDto
 		pagination     *dto.MetaPagination
 		mockSetup      func()
 		expectedCode   int
 		expectedResult *dto.MetaPagination
 	}{
 		{
-			name: "Success - Get assets with pagination",
+			name:   "Success - Get assets with pagination",
+			filter: &dto.AssetFilterDto{},
 			pagination: &dto.MetaPagination{
 				Limit:  10,
 				Offset: 0,
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssets(gomock.Any()).Return(testAssets, int64(2), nil)
+				mockRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).Return(testAssets, int64(2), nil)
 			},
 			expectedCode: http.StatusOK,
 			expectedResult: &dto.MetaPagination{
@@ -363,13 +531,116 @@ func TestGetAssets(t *testing.T) {
 			},
 		},
 		{
-			name: "Error - Repository error",
+			name: "Success - Date filters are parsed",
+			filter: &dto.AssetFilterDto{
+				Q:                   "laptop",
+				AcquisitionDateFrom: "2023-01-01",
+				AcquisitionDateTo:   "2023-12-31",
+				CreatedFrom:         "2023-01-01T10:00:00Z",
+			},
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				Offset: 0,
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+					assert.Equal(t, "laptop", filter.Q)
+					assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), filter.AcquisitionDateFromTime)
+					assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), filter.AcquisitionDateToTime)
+					assert.Equal(t, time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), filter.CreatedFromTime)
+					return []*models.Asset{}, int64(0), nil
+				})
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.MetaPagination{
+				Limit: 10,
+				BaseResponse: dto.BaseResponse{
+					Data: []*dto.AssetOutputDto{},
+				},
+			},
+		},
+		{
+			name:   "Success - Multi column sort",
+			filter: &dto.AssetFilterDto{},
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				Order:  "asc",
+				SortBy: "type,-value",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+					assert.Equal(t, []dto.SortField{{Field: "type"}, {Field: "value", Desc: true}}, pagination.Sorts)
+					return []*models.Asset{}, int64(0), nil
+				})
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.MetaPagination{
+				Limit:  10,
+				Order:  "asc",
+				SortBy: "type,-value",
+				Sorts:  []dto.SortField{{Field: "type"}, {Field: "value", Desc: true}},
+				BaseResponse: dto.BaseResponse{
+					Data: []*dto.AssetOutputDto{},
+				},
+			},
+		},
+		{
+			name:   "Error - Unknown sort field",
+			filter: &dto.AssetFilterDto{},
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				SortBy: "name,deleted_at",
+			},
+			mockSetup:    func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid sort field deleted_at, allowed fields are id, name, type, value, acquisition_date, created_at, updated_at",
+				},
+			},
+		},
+		{
+			name:   "Error - Invalid date filter",
+			filter: &dto.AssetFilterDto{UpdatedTo: "31-12-2023"},
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				Offset: 0,
+			},
+			mockSetup:    func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid updated_to date format",
+				},
+			},
+		},
+		{
+			name:   "Error - Inverted value range",
+			filter: &dto.AssetFilterDto{ValueMin: &valueMin, ValueMax: &valueMax},
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				Offset: 0,
+			},
+			mockSetup:    func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "value_min must not be greater than value_max",
+				},
+			},
+		},
+		{
+			name:   "Error - Repository error",
+			filter: &dto.AssetFilterDto{},
 			pagination: &dto.MetaPagination{
 				Limit:  10,
 				Offset: 0,
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssets(gomock.Any()).Return(nil, int64(0), errors.New("repository error"))
+				mockRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("repository error"))
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedResult: &dto.MetaPagination{
@@ -384,7 +655,7 @@ func TestGetAssets(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			code, response := service.GetAssets(tt.pagination)
+			code, response := service.GetAssets(context.Background(), tt.filter, tt.pagination)
 			assert.Equal(t, tt.expectedCode, code)
 			assert.Equal(t, tt.expectedResult, response)
 		})
@@ -396,7 +667,12 @@ func TestUpdateAsset(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
-	service := NewAssetService(mockRepo)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Updated Type").Return(&models.Category{Id: "category-id", Name: "Updated Type"}, nil).AnyTimes()
 
 	testTime := time.Now()
 	testAsset := &models.Asset{
@@ -407,12 +683,14 @@ func TestUpdateAsset(t *testing.T) {
 		AcquisitionDate: testTime,
 		CreatedAt:       testTime,
 		UpdatedAt:       testTime,
+		Version:         3,
 	}
 
 	tests := []struct {
 		name           string
 		id             string
 		input          *dto.AssetInputDto
+		ifMatch        string
 		mockSetup      func()
 		expectedCode   int
 		expectedResult *dto.BaseResponse
@@ -427,9 +705,11 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any()).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusOK,
@@ -443,9 +723,92 @@ func TestUpdateAsset(t *testing.T) {
 					AcquisitionDate: testTime.Format("2006-01-02 15:04:05"),
 					CreatedAt:       testTime.Format("2006-01-02 15:04:05"),
 					UpdatedAt:       testTime.Format("2006-01-02 15:04:05"),
+					Version:         3,
 				},
 			},
 		},
+		{
+			name: "Success - Update asset with matching If-Match",
+			id:   "test-id",
+			input: &dto.AssetInputDto{
+				Name:            "Updated Asset",
+				Type:            "Updated Type",
+				Value:           2000,
+				AcquisitionDate: "2023-01-01",
+			},
+			ifMatch: `"2", "3"`,
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Error - If-Match mismatch",
+			id:   "test-id",
+			input: &dto.AssetInputDto{
+				Name:            "Updated Asset",
+				Type:            "Updated Type",
+				Value:           2000,
+				AcquisitionDate: "2023-01-01",
+			},
+			ifMatch: `"1"`,
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.PreconditionFailed,
+				ErrorDescription: "Asset was changed since it was read",
+			},
+		},
+		{
+			name: "Error - Updated by another request after If-Match check",
+			id:   "test-id",
+			input: &dto.AssetInputDto{
+				Name:            "Updated Asset",
+				Type:            "Updated Type",
+				Value:           2000,
+				AcquisitionDate: "2023-01-01",
+			},
+			ifMatch: "*",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.PreconditionFailed,
+				ErrorDescription: "Asset was changed since it was read",
+			},
+		},
+		{
+			name: "Error - Updated by another request",
+			id:   "test-id",
+			input: &dto.AssetInputDto{
+				Name:            "Updated Asset",
+				Type:            "Updated Type",
+				Value:           2000,
+				AcquisitionDate: "2023-01-01",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusConflict,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.Conflict,
+				ErrorDescription: "Asset was changed by another request",
+			},
+		},
 		{
 			name: "Error - Asset not found",
 			id:   "non-existent-id",
@@ -456,7 +819,7 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
 			},
 			expectedCode: http.StatusNotFound,
 			expectedResult: &dto.BaseResponse{
@@ -474,12 +837,32 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "invalid-date",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "acquisition_date must be a date in the format YYYY-MM-DD",
+				Errors:           []dto.FieldErrorDto{{Field: "acquisition_date", Code: validation.CodeDateFormat, Message: "acquisition_date must be a date in the format YYYY-MM-DD"}},
+			},
+		},
+		{
+			name: "Error - Status change",
+			id:   "test-id",
+			input: &dto.AssetInputDto{
+				Name:            "Updated Asset",
+				Type:            "Updated Type",
+				Status:          "retired",
+				Value:           2000,
+				AcquisitionDate: "2023-01-01",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedResult: &dto.BaseResponse{
 				Error:            common.BadRequest,
-				ErrorDescription: "Invalid acqusition date format",
+				ErrorDescription: "Status can only be changed through the transitions endpoint",
 			},
 		},
 		{
@@ -492,7 +875,7 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedResult: &dto.BaseResponse{
@@ -510,9 +893,31 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("repository error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		},
+		{
+			name: "Error - Record audit error",
+			id:   "test-id",
+			input: &dto.AssetInputDto{
+				Name:            "Updated Asset",
+				Type:            "Updated Type",
+				Value:           2000,
+				AcquisitionDate: "2023-01-01",
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("audit error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusInternalServerError,
@@ -531,9 +936,11 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any()).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("repository error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
@@ -553,9 +960,11 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any()).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("repository error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("repository error"))
 			},
@@ -575,9 +984,9 @@ func TestUpdateAsset(t *testing.T) {
 				AcquisitionDate: "2023-01-01",
 			},
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any()).Return(testAsset, errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, errors.New("repository error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("repository error"))
 			},
 			expectedCode: http.StatusInternalServerError,
@@ -591,8 +1000,11 @@ func TestUpdateAsset(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			code, _ := service.UpdateAsset(tt.id, tt.input)
+			code, response := service.UpdateAsset(context.Background(), tt.id, tt.input, tt.ifMatch)
 			assert.Equal(t, tt.expectedCode, code)
+			if code == http.StatusPreconditionFailed || code == http.StatusConflict {
+				assert.Equal(t, tt.expectedResult, response)
+			}
 		})
 	}
 }
@@ -602,15 +1014,21 @@ func TestDeleteAsset(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
-	service := NewAssetService(mockRepo)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
 
 	testAsset := &models.Asset{
-		Id: "test-id",
+		Id:      "test-id",
+		Version: 2,
 	}
 
 	tests := []struct {
 		name           string
 		id             string
+		ifMatch        string
 		mockSetup      func()
 		expectedCode   int
 		expectedResult *dto.BaseResponse
@@ -619,9 +1037,11 @@ func TestDeleteAsset(t *testing.T) {
 			name: "Success - Delete asset",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().DeleteAsset(testAsset, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusOK,
@@ -629,11 +1049,55 @@ func TestDeleteAsset(t *testing.T) {
 				Message: common.Success,
 			},
 		},
+		{
+			name:    "Error - If-Match mismatch",
+			id:      "test-id",
+			ifMatch: `"1"`,
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.PreconditionFailed,
+				ErrorDescription: "Asset was changed since it was read",
+			},
+		},
+		{
+			name:    "Error - Updated by another request after If-Match check",
+			id:      "test-id",
+			ifMatch: `"2"`,
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.PreconditionFailed,
+				ErrorDescription: "Asset was changed since it was read",
+			},
+		},
+		{
+			name: "Error - Updated by another request",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusConflict,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.Conflict,
+				ErrorDescription: "Asset was changed by another request",
+			},
+		},
 		{
 			name: "Error - Asset not found",
 			id:   "non-existent-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
 			},
 			expectedCode: http.StatusNotFound,
 			expectedResult: &dto.BaseResponse{
@@ -645,7 +1109,7 @@ func TestDeleteAsset(t *testing.T) {
 			name: "Error - Repository error on get",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedResult: &dto.BaseResponse{
@@ -657,9 +1121,9 @@ func TestDeleteAsset(t *testing.T) {
 			name: "Error - Repository error on delete",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().DeleteAsset(testAsset, gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(false, errors.New("repository error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusInternalServerError,
@@ -669,13 +1133,13 @@ func TestDeleteAsset(t *testing.T) {
 			},
 		},
 		{
-			name: "Error - Repository error on commit",
+			name: "Error - Repository error on audit",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().DeleteAsset(testAsset, gomock.Any()).Return(nil)
-				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("audit error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusInternalServerError,
@@ -685,13 +1149,16 @@ func TestDeleteAsset(t *testing.T) {
 			},
 		},
 		{
-			name: "Error - Repository error on rollback 1",
+			name: "Error - Repository error on commit",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().DeleteAsset(testAsset, gomock.Any()).Return(errors.New("repository error"))
-				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedResult: &dto.BaseResponse{
@@ -700,12 +1167,29 @@ func TestDeleteAsset(t *testing.T) {
 			},
 		},
 		{
-			name: "Error - Repository error on rollback 2",
+			name: "Error - Repository error on rollback 1",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(false, errors.New("repository error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("repository error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		},
+		{
+			name: "Error - Repository error on rollback 2",
 			id:   "test-id",
 			mockSetup: func() {
-				mockRepo.EXPECT().GetAssetByAttribute(map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
-				mockRepo.EXPECT().StartTransaction().Return(&gorm.DB{})
-				mockRepo.EXPECT().DeleteAsset(testAsset, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteAsset(gomock.Any(), testAsset, gomock.Any()).Return(true, nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("repository error"))
 				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("repository error"))
 			},
@@ -720,9 +1204,1220 @@ func TestDeleteAsset(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			code, response := service.DeleteAsset(tt.id)
+			code, response := service.DeleteAsset(context.Background(), tt.id, tt.ifMatch)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedResult, response)
+		})
+	}
+}
+
+func TestGetDepreciationSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	testAsset := &models.Asset{
+		Id:                     "test-id",
+		Value:                  1000,
+		AcquisitionDate:        time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+		DepreciationMethod:     "straight_line",
+		DepreciationConvention: "full_month",
+		UsefulLifeYears:        2,
+		SalvageValue:           200,
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		mockSetup      func()
+		expectedCode   int
+		expectedResult *dto.BaseResponse
+	}{
+		{
+			name: "Success - Schedule returned",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.BaseResponse{
+				Message: common.Success,
+				Data: &dto.DepreciationScheduleDto{
+					AssetId:      "test-id",
+					Cost:         1000,
+					SalvageValue: 200,
+					Method:       "straight_line",
+					Convention:   "full_month",
+					Periods: []*dto.DepreciationPeriodDto{
+						{
+							Period:                  1,
+							StartDate:               "2020-01-01",
+							EndDate:                 "2020-12-31",
+							Depreciation:            400,
+							AccumulatedDepreciation: 400,
+							BookValue:               600,
+						},
+						{
+							Period:                  2,
+							StartDate:               "2021-01-01",
+							EndDate:                 "2021-12-31",
+							Depreciation:            400,
+							AccumulatedDepreciation: 800,
+							BookValue:               200,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Error - No depreciation settings",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id"}, nil)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Asset has no depreciation settings",
+			},
+		},
+		{
+			name: "Error - Asset not found",
+			id:   "non-existent-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.NotFound,
+				ErrorDescription: "Asset not found",
+			},
+		},
+		{
+			name: "Error - Repository error",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.GetDepreciationSchedule(context.Background(), tt.id)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedResult, response)
+		})
+	}
+}
+
+func TestGetDeletedAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	testTime := time.Now()
+	testAssets := []*models.Asset{
+		{
+			Id:              "test-id-1",
+			Name:            "Test Asset 1",
+			Type:            "Test Type",
+			Value:           1000,
+			AcquisitionDate: testTime,
+			CreatedAt:       testTime,
+			UpdatedAt:       testTime,
+			DeletedAt:       gorm.DeletedAt{Time: testTime, Valid: true},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		pagination     *dto.MetaPagination
+		mockSetup      func()
+		expectedCode   int
+		expectedResult *dto.MetaPagination
+	}{
+		{
+			name: "Success - Get deleted assets",
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				Offset: 0,
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssets(gomock.Any(), gomock.Any()).Return(testAssets, int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.MetaPagination{
+				Limit:     10,
+				Offset:    0,
+				Total:     1,
+				TotalPage: 1,
+				BaseResponse: dto.BaseResponse{
+					Data: []*dto.AssetOutputDto{
+						{
+							Id:              "test-id-1",
+							Name:            "Test Asset 1",
+							Type:            "Test Type",
+							Value:           1000,
+							AcquisitionDate: testTime.Format("2006-01-02"),
+							CreatedAt:       testTime.Format("2006-01-02"),
+							UpdatedAt:       testTime.Format("2006-01-02"),
+							DeletedAt:       testTime.Format("2006-01-02 15:04:05"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Error - Repository error",
+			pagination: &dto.MetaPagination{
+				Limit:  10,
+				Offset: 0,
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssets(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("repository error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.InternalServerError,
+					ErrorDescription: "Something went wrong",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.GetDeletedAssets(context.Background(), tt.pagination)
 			assert.Equal(t, tt.expectedCode, code)
 			assert.Equal(t, tt.expectedResult, response)
 		})
 	}
 }
+
+func TestRestoreAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	testAsset := &models.Asset{
+		Id:   "test-id",
+		Name: "Test Asset",
+		Type: "Test Type",
+	}
+
+	tests := []struct {
+		name         string
+		id           string
+		mockSetup    func()
+		expectedCode int
+	}{
+		{
+			name: "Success - Restore asset",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "Test Asset", "type": "Test Type"}).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().RestoreAsset(gomock.Any(), testAsset, gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Error - Asset not in trash",
+			id:   "non-existent-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Error - Active duplicate exists",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "Test Asset", "type": "Test Type"}).Return(&models.Asset{Id: "other-id"}, nil)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Error - Repository error on get",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(nil, errors.New("repository error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "Error - Repository error on restore",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "Test Asset", "type": "Test Type"}).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().RestoreAsset(gomock.Any(), testAsset, gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "Error - Repository error on commit",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "Test Asset", "type": "Test Type"}).Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().RestoreAsset(gomock.Any(), testAsset, gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(errors.New("repository error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, _ := service.RestoreAsset(context.Background(), tt.id)
+			assert.Equal(t, tt.expectedCode, code)
+		})
+	}
+}
+
+func TestPurgeAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	mockAttachmentRepo := repositories.NewMockAttachmentRepositoryInterface(ctrl)
+	mockFileStorage := mockStorage.NewMockStorage(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, mockAttachmentRepo, mockFileStorage, common.DefaultAssetTransitions)
+
+	testAsset := &models.Asset{
+		Id: "test-id",
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		mockSetup      func()
+		expectedCode   int
+		expectedResult *dto.BaseResponse
+	}{
+		{
+			name: "Success - Purge asset",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockAttachmentRepo.EXPECT().GetAttachments(gomock.Any(), "test-id").Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().PurgeAsset(gomock.Any(), testAsset, gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.BaseResponse{
+				Message: common.Success,
+			},
+		},
+		{
+			name: "Success - Purge asset with attachments",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockAttachmentRepo.EXPECT().GetAttachments(gomock.Any(), "test-id").Return([]*models.Attachment{
+					{Id: "photo", AssetId: "test-id", StorageKey: "attachments/photo", ThumbnailKey: "attachments/photo_thumb.jpg"},
+					{Id: "invoice", AssetId: "test-id", StorageKey: "attachments/invoice"},
+				}, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{}),
+					mockRepo.EXPECT().PurgeAsset(gomock.Any(), testAsset, gomock.Any()).Return(nil),
+					mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil),
+					mockFileStorage.EXPECT().Delete(gomock.Any(), "attachments/photo").Return(nil),
+					mockFileStorage.EXPECT().Delete(gomock.Any(), "attachments/photo_thumb.jpg").Return(nil),
+					mockFileStorage.EXPECT().Delete(gomock.Any(), "attachments/invoice").Return(errors.New("storage error")),
+				)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.BaseResponse{
+				Message: common.Success,
+			},
+		},
+		{
+			name: "Error - Asset not in trash",
+			id:   "non-existent-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "non-existent-id"}).Return(nil, nil)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.NotFound,
+				ErrorDescription: "Asset not found in trash",
+			},
+		},
+		{
+			name: "Error - Repository error on purge",
+			id:   "test-id",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetDeletedAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+				mockAttachmentRepo.EXPECT().GetAttachments(gomock.Any(), "test-id").Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().PurgeAsset(gomock.Any(), testAsset, gomock.Any()).Return(errors.New("repository error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.PurgeAsset(context.Background(), tt.id)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedResult, response)
+		})
+	}
+}
+
+func TestPurgeExpiredAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	mockAttachmentRepo := repositories.NewMockAttachmentRepositoryInterface(ctrl)
+	mockFileStorage := mockStorage.NewMockStorage(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, mockAttachmentRepo, mockFileStorage, common.DefaultAssetTransitions)
+
+	t.Run("Success - Purge expired assets", func(t *testing.T) {
+		expired := []*models.Asset{{Id: "asset-1", TenantId: "acme"}, {Id: "asset-2", TenantId: "globex"}}
+		mockRepo.EXPECT().GetDeletedAssetsBefore(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, before time.Time) ([]*models.Asset, error) {
+			assert.WithinDuration(t, time.Now().UTC().AddDate(0, 0, -30), before, time.Minute)
+			return expired, nil
+		})
+		for _, v := range expired {
+			asset := v
+			mockAttachmentRepo.EXPECT().GetAttachments(gomock.Any(), asset.Id).Return(nil, nil)
+			mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+			mockRepo.EXPECT().PurgeAsset(gomock.Any(), asset, gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, tx *gorm.DB) error {
+				assert.Equal(t, asset.TenantId, common.TenantFromContext(ctx))
+				return nil
+			})
+			mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+				assert.Equal(t, common.AuditActionPurge, auditLog.Action)
+				assert.Equal(t, asset.Id, auditLog.EntityId)
+				return nil
+			})
+			mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+		}
+		purged, err := service.PurgeExpiredAssets(context.Background(), 30)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+	})
+
+	t.Run("Error - Repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetDeletedAssetsBefore(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+		purged, err := service.PurgeExpiredAssets(context.Background(), 30)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), purged)
+	})
+
+	t.Run("Error - Purge error", func(t *testing.T) {
+		asset := &models.Asset{Id: "asset-1"}
+		mockRepo.EXPECT().GetDeletedAssetsBefore(gomock.Any(), gomock.Any()).Return([]*models.Asset{asset, {Id: "asset-2"}}, nil)
+		mockAttachmentRepo.EXPECT().GetAttachments(gomock.Any(), "asset-1").Return(nil, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().PurgeAsset(gomock.Any(), asset, gomock.Any()).Return(errors.New("repository error"))
+		mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+		purged, err := service.PurgeExpiredAssets(context.Background(), 30)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), purged)
+	})
+}
+
+func TestUpdateAssetRecordsAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+	categoryId := "category-id"
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Test Type").Return(&models.Category{Id: categoryId, Name: "Test Type"}, nil)
+
+	testAsset := &models.Asset{
+		Id:              "test-id",
+		Name:            "Test Asset",
+		Type:            "Test Type",
+		CategoryId:      &categoryId,
+		Value:           1000,
+		AcquisitionDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	ctx := common.WithRequestId(common.WithActor(context.Background(), "auditor@example.com"), "req-1")
+	mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+	mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+	mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
+		return true, nil
+	})
+	mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+		assert.Equal(t, common.AuditEntityAsset, auditLog.EntityType)
+		assert.Equal(t, "test-id", auditLog.EntityId)
+		assert.Equal(t, common.AuditActionUpdate, auditLog.Action)
+		assert.Equal(t, "auditor@example.com", auditLog.Actor)
+		assert.Equal(t, "req-1", auditLog.RequestId)
+		assert.JSONEq(t, `[{"field":"value","before":1000,"after":2500}]`, auditLog.Changes)
+		return nil
+	})
+	mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+	code, _ := service.UpdateAsset(ctx, "test-id", &dto.AssetInputDto{
+		Name:            "Test Asset",
+		Type:            "Test Type",
+		Value:           2500,
+		AcquisitionDate: "2023-01-01",
+	}, "")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestGetAssetsByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	testTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	testAssets := []*models.Asset{
+		{Id: "test-id-1", Name: "Asset 1", Value: 300, CreatedAt: testTime},
+		{Id: "test-id-2", Name: "Asset 2", Value: 200, CreatedAt: testTime},
+	}
+	keys := []dto.SortField{{Field: "value", Desc: true}, {Field: "id"}}
+
+	t.Run("Success - First page returns next cursor only", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssetsByCursor(gomock.Any(), gomock.Any(), gomock.Any()).Return(testAssets, true, nil)
+		mockRepo.EXPECT().CountAssets(gomock.Any(), gomock.Any()).Return(int64(5), nil)
+
+		code, response := service.GetAssets(context.Background(), &dto.AssetFilterDto{}, &dto.MetaPagination{
+			Limit:        2,
+			SortBy:       "-value",
+			CursorMode:   true,
+			IncludeTotal: true,
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(5), response.Total)
+		assert.Empty(t, response.PrevCursor)
+
+		next, err := parseAssetCursor(response.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, keys, next.Sorts)
+		assert.Equal(t, []interface{}{float64(200), "test-id-2"}, next.Values)
+		assert.False(t, next.Before)
+	})
+
+	t.Run("Success - Following page skips count and returns both cursors", func(t *testing.T) {
+		cursor := dto.EncodeCursor(&dto.Cursor{Sorts: keys, Values: []interface{}{float64(400), "test-id-0"}})
+		mockRepo.EXPECT().GetAssetsByCursor(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, bool, error) {
+			assert.Equal(t, keys, pagination.DecodedCursor.Sorts)
+			return testAssets, false, nil
+		})
+
+		code, response := service.GetAssets(context.Background(), &dto.AssetFilterDto{}, &dto.MetaPagination{
+			Limit:      2,
+			CursorMode: true,
+			Cursor:     cursor,
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(0), response.Total)
+		assert.Empty(t, response.NextCursor)
+
+		prev, err := parseAssetCursor(response.PrevCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{float64(300), "test-id-1"}, prev.Values)
+		assert.True(t, prev.Before)
+	})
+
+	t.Run("Error - Cursor with unknown field", func(t *testing.T) {
+		cursor := dto.EncodeCursor(&dto.Cursor{
+			Sorts:  []dto.SortField{{Field: "deleted_at"}, {Field: "id"}},
+			Values: []interface{}{"2024-01-01T00:00:00Z", "test-id-0"},
+		})
+
+		code, response := service.GetAssets(context.Background(), &dto.AssetFilterDto{}, &dto.MetaPagination{
+			Limit:      2,
+			CursorMode: true,
+			Cursor:     cursor,
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Invalid cursor", response.ErrorDescription)
+	})
+
+	t.Run("Error - Malformed cursor", func(t *testing.T) {
+		code, _ := service.GetAssets(context.Background(), &dto.AssetFilterDto{}, &dto.MetaPagination{
+			Limit:      2,
+			CursorMode: true,
+			Cursor:     "not-a-cursor",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestCreateAssetCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	laptop := &models.Category{
+		Id:                 "laptop-id",
+		Name:               "Laptop",
+		DepreciationMethod: "straight_line",
+		UsefulLifeYears:    3,
+	}
+
+	t.Run("Success - Category id sets type and default depreciation", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "laptop-id"}).Return(laptop, nil)
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "MacBook", "type": "Laptop"}).Return(nil, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+			assert.Equal(t, "Laptop", asset.Type)
+			assert.Equal(t, "laptop-id", *asset.CategoryId)
+			assert.Equal(t, "straight_line", asset.DepreciationMethod)
+			assert.Equal(t, 3, asset.UsefulLifeYears)
+			return asset, nil
+		})
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			CategoryId:      "laptop-id",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusCreated, code)
+	})
+
+	t.Run("Success - Type is matched to the category name", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "laptop").Return(laptop, nil)
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"name": "MacBook", "type": "Laptop"}).Return(&models.Asset{}, nil)
+
+		code, response := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			Type:            "laptop",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Asset already exist", response.ErrorDescription)
+	})
+
+	t.Run("Error - Unknown category", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptops").Return(nil, nil)
+
+		code, response := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			Type:            "Laptops",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Category not found", response.ErrorDescription)
+	})
+
+	t.Run("Error - No category", func(t *testing.T) {
+		code, response := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "category_id or type is required", response.ErrorDescription)
+	})
+
+	t.Run("Error - Get category error", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), gomock.Any()).Return(nil, errors.New("get category error"))
+
+		code, _ := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			CategoryId:      "laptop-id",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
+
+func TestGetAssetsByCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	t.Run("Success - Includes descendants", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetDescendantIds(gomock.Any(), "computers-id").Return([]string{"laptop-id", "desktop-id"}, nil)
+		mockRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+			assert.Equal(t, []string{"computers-id", "laptop-id", "desktop-id"}, filter.CategoryIds)
+			return []*models.Asset{}, 0, nil
+		})
+
+		code, _ := service.GetAssets(context.Background(), &dto.AssetFilterDto{CategoryId: "computers-id"}, &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Get descendants error", func(t *testing.T) {
+		mockCategoryRepo.EXPECT().GetDescendantIds(gomock.Any(), "computers-id").Return(nil, errors.New("descendants error"))
+
+		code, _ := service.GetAssets(context.Background(), &dto.AssetFilterDto{CategoryId: "computers-id"}, &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
+
+func TestCreateAssetLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+	mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptop").Return(&models.Category{Id: "laptop-id", Name: "Laptop"}, nil).AnyTimes()
+
+	t.Run("Success - Asset placed in a room", func(t *testing.T) {
+		mockLocationRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "room-id"}).Return(&models.Location{Id: "room-id"}, nil)
+		mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().CreateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+			assert.Equal(t, "room-id", *asset.LocationId)
+			return asset, nil
+		})
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			Type:            "Laptop",
+			LocationId:      "room-id",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusCreated, code)
+	})
+
+	t.Run("Error - Unknown location", func(t *testing.T) {
+		mockLocationRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+
+		code, response := service.CreateAsset(context.Background(), &dto.AssetInputDto{
+			Name:            "MacBook",
+			Type:            "Laptop",
+			LocationId:      "missing-id",
+			Value:           1500,
+			AcquisitionDate: "2023-01-01",
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Location not found", response.ErrorDescription)
+	})
+}
+
+func TestGetAssetsByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	t.Run("Success - Overdue sets due cutoff", func(t *testing.T) {
+		mockRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+			assert.Equal(t, common.AssignmentStatusOverdue, filter.Status)
+			assert.Equal(t, today(), filter.DueBefore)
+			return []*models.Asset{}, 0, nil
+		})
+
+		code, _ := service.GetAssets(context.Background(), &dto.AssetFilterDto{Status: "overdue"}, &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Unknown status", func(t *testing.T) {
+		code, response := service.GetAssets(context.Background(), &dto.AssetFilterDto{Status: "lost"}, &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Invalid status lost, allowed statuses are available, checked_out, overdue", response.ErrorDescription)
+	})
+}
+
+func TestTransitionAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+	tests := []struct {
+		name                string
+		input               *dto.AssetTransitionInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedError       string
+		expectedDescription string
+		expectedData        interface{}
+	}{
+		{
+			name:  "Success - Deploy asset in stock",
+			input: &dto.AssetTransitionInputDto{Status: "Deployed", Reason: "Issued to new hire"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id", Status: common.AssetStatusInStock}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().TransitionAsset(gomock.Any(), gomock.Any(), common.AssetStatusInStock, gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, fromStatus string, tx *gorm.DB) (bool, error) {
+					assert.Equal(t, common.AssetStatusDeployed, asset.Status)
+					return true, nil
+				})
+				mockRepo.EXPECT().CreateAssetTransition(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transition *models.AssetTransition, tx *gorm.DB) (*models.AssetTransition, error) {
+					assert.Equal(t, common.AssetStatusInStock, transition.FromStatus)
+					assert.Equal(t, common.AssetStatusDeployed, transition.ToStatus)
+					assert.Equal(t, "Issued to new hire", transition.Reason)
+					return transition, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditActionTransition, auditLog.Action)
+					return nil
+				})
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *models.OutboxEvent, tx *gorm.DB) error {
+					assert.Equal(t, common.EventAssetStatusChanged, event.Type)
+					assert.Contains(t, event.Payload, `"changes":[{"field":"status","before":"in_stock","after":"deployed"}]`)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:                "Error - Unknown status",
+			input:               &dto.AssetTransitionInputDto{Status: "sold", Reason: "Sold"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedError:       common.BadRequest,
+			expectedDescription: "Invalid status, allowed statuses are ordered, in_stock, deployed, in_repair, retired, disposed, lost",
+		},
+		{
+			name:                "Error - Missing reason",
+			input:               &dto.AssetTransitionInputDto{Status: "deployed", Reason: " "},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedError:       common.BadRequest,
+			expectedDescription: "Reason is required",
+		},
+		{
+			name:  "Error - Asset not found",
+			input: &dto.AssetTransitionInputDto{Status: "deployed", Reason: "Issued"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedError:       common.NotFound,
+			expectedDescription: "Asset not found",
+		},
+		{
+			name:  "Error - Move not in graph",
+			input: &dto.AssetTransitionInputDto{Status: "deployed", Reason: "Issued"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id", Status: common.AssetStatusOrdered}, nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedError:       common.InvalidTransition,
+			expectedDescription: "Cannot move asset from ordered to deployed",
+			expectedData: &dto.InvalidTransitionDto{
+				CurrentStatus:   common.AssetStatusOrdered,
+				RequestedStatus: common.AssetStatusDeployed,
+				AllowedStatuses: []string{common.AssetStatusInStock, common.AssetStatusLost},
+			},
+		},
+		{
+			name:  "Error - Disposed is final",
+			input: &dto.AssetTransitionInputDto{Status: "in_stock", Reason: "Found it"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id", Status: common.AssetStatusDisposed}, nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedError:       common.InvalidTransition,
+			expectedDescription: "Cannot move asset from disposed, it is a final status",
+			expectedData: &dto.InvalidTransitionDto{
+				CurrentStatus:   common.AssetStatusDisposed,
+				RequestedStatus: common.AssetStatusInStock,
+				AllowedStatuses: []string{},
+			},
+		},
+		{
+			name:  "Error - Status changed concurrently",
+			input: &dto.AssetTransitionInputDto{Status: "in_repair", Reason: "Broken screen"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id", Status: common.AssetStatusDeployed}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().TransitionAsset(gomock.Any(), gomock.Any(), common.AssetStatusDeployed, gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedError:       common.Conflict,
+			expectedDescription: "Asset status was changed by another request",
+		},
+		{
+			name:  "Error - Create transition",
+			input: &dto.AssetTransitionInputDto{Status: "in_repair", Reason: "Broken screen"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id", Status: common.AssetStatusDeployed}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().TransitionAsset(gomock.Any(), gomock.Any(), common.AssetStatusDeployed, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().CreateAssetTransition(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedError:       common.InternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.TransitionAsset(context.Background(), "test-id", tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedError, response.Error)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+			if tt.expectedData != nil {
+				assert.Equal(t, tt.expectedData, response.Data)
+			}
+		})
+	}
+}
+
+func TestTransitionAssetCustomGraph(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, map[string][]string{
+		common.AssetStatusOrdered: {common.AssetStatusDeployed},
+	})
+
+	mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(&models.Asset{Id: "test-id", Status: common.AssetStatusOrdered}, nil)
+	mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+	mockRepo.EXPECT().TransitionAsset(gomock.Any(), gomock.Any(), common.AssetStatusOrdered, gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().CreateAssetTransition(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, transition *models.AssetTransition, tx *gorm.DB) (*models.AssetTransition, error) {
+		return transition, nil
+	})
+	mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+	code, _ := service.TransitionAsset(context.Background(), "test-id", &dto.AssetTransitionInputDto{Status: "deployed", Reason: "Drop shipped to user"})
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestPatchAsset(t *testing.T) {
+	categoryId := "category-id"
+	tests := []struct {
+		name            string
+		contentType     string
+		patch           string
+		ifMatch         string
+		roles           []string
+		mockSetup       func(mockCategoryRepo *repositories.MockCategoryRepositoryInterface)
+		expectedColumns []string
+		expectedCode    int
+		expectedError   string
+	}{
+		{
+			name:        "Merge patch of the value",
+			contentType: "application/merge-patch+json",
+			patch:       `{"value":2500}`,
+			roles:       []string{common.RoleFinance},
+			mockSetup: func(mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockCategoryRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "category-id"}).Return(&models.Category{Id: "category-id", Name: "Test Type"}, nil)
+			},
+			expectedColumns: []string{"value"},
+			expectedCode:    http.StatusOK,
+		},
+		{
+			name:        "JSON patch of the name with a passing test",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op":"test","path":"/value","value":1000},{"op":"replace","path":"/name","value":"Renamed"}]`,
+			ifMatch:     `"4"`,
+			roles:       []string{common.RoleEditor},
+			mockSetup: func(mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockCategoryRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "category-id"}).Return(&models.Category{Id: "category-id", Name: "Test Type"}, nil)
+			},
+			expectedColumns: []string{"name"},
+			expectedCode:    http.StatusOK,
+		},
+		{
+			name:        "Type moves the asset to another category",
+			contentType: "application/merge-patch+json",
+			patch:       `{"type":"Other Type"}`,
+			roles:       []string{common.RoleEditor},
+			mockSetup: func(mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Other Type").Return(&models.Category{Id: "other-id", Name: "Other Type"}, nil)
+			},
+			expectedColumns: []string{"category_id", "type"},
+			expectedCode:    http.StatusOK,
+		},
+		{
+			name:        "Nothing changed",
+			contentType: "application/merge-patch+json",
+			patch:       `{"name":"Test Asset"}`,
+			roles:       []string{common.RoleEditor},
+			mockSetup: func(mockCategoryRepo *repositories.MockCategoryRepositoryInterface) {
+				mockCategoryRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "category-id"}).Return(&models.Category{Id: "category-id", Name: "Test Type"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Editor changing the value",
+			contentType:   "application/merge-patch+json",
+			patch:         `{"value":2500}`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "Changing the value requires the finance or admin role",
+		},
+		{
+			name:          "Status change",
+			contentType:   "application/json-patch+json",
+			patch:         `[{"op":"replace","path":"/status","value":"retired"}]`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Status can only be changed through the transitions endpoint",
+		},
+		{
+			name:          "Invalid patched date",
+			contentType:   "application/merge-patch+json",
+			patch:         `{"acquisition_date":null}`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "acquisition_date is required",
+		},
+		{
+			name:          "Unknown field",
+			contentType:   "application/json-patch+json",
+			patch:         `[{"op":"add","path":"/colour","value":"red"}]`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: `Invalid patched asset: json: unknown field "colour"`,
+		},
+		{
+			name:          "Failing test operation",
+			contentType:   "application/json-patch+json",
+			patch:         `[{"op":"test","path":"/name","value":"Other"},{"op":"replace","path":"/name","value":"Renamed"}]`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusConflict,
+			expectedError: "Invalid patch: operation 0 (test /name): test operation failed",
+		},
+		{
+			name:          "Malformed patch",
+			contentType:   "application/merge-patch+json",
+			patch:         `{"name":`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid patch: invalid merge patch: unexpected end of JSON input",
+		},
+		{
+			name:          "If-Match mismatch",
+			contentType:   "application/merge-patch+json",
+			patch:         `{"name":"Renamed"}`,
+			ifMatch:       `"3"`,
+			roles:         []string{common.RoleEditor},
+			expectedCode:  http.StatusPreconditionFailed,
+			expectedError: "Asset was changed since it was read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+			mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+			mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+			mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+			mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+			service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+			testAsset := &models.Asset{Id: "test-id", Name: "Test Asset", Type: "Test Type", CategoryId: &categoryId, Status: common.AssetStatusInStock, Value: 1000, AcquisitionDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Version: 4}
+			mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockCategoryRepo)
+			}
+			if tt.expectedColumns != nil {
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().PatchAsset(gomock.Any(), testAsset, tt.expectedColumns, gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, columns []string, tx *gorm.DB) (bool, error) {
+					asset.Version++
+					return true, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					var changes []*dto.AuditChangeDto
+					assert.NoError(t, json.Unmarshal([]byte(auditLog.Changes), &changes))
+					fields := []string{}
+					for _, v := range changes {
+						fields = append(fields, v.Field)
+					}
+					assert.Equal(t, tt.expectedColumns, fields)
+					return nil
+				})
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			}
+
+			ctx := common.WithPrincipal(context.Background(), &common.Principal{Subject: "user-1", Roles: tt.roles})
+			code, response := service.PatchAsset(ctx, "test-id", tt.contentType, []byte(tt.patch), tt.ifMatch)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedError, response.ErrorDescription)
+			if tt.expectedCode == http.StatusOK {
+				version := int64(4)
+				if tt.expectedColumns != nil {
+					version = 5
+				}
+				assert.Equal(t, version, response.Data.(*dto.AssetOutputDto).Version)
+			}
+		})
+	}
+}
+
+func TestPatchAssetUnsupportedContentType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewAssetService(repositories.NewMockAssetRepositoryInterface(ctrl), nil, nil, nil, nil, nil, nil, common.DefaultAssetTransitions)
+	code, response := service.PatchAsset(context.Background(), "test-id", "application/json", []byte(`{"name":"Renamed"}`), "")
+	assert.Equal(t, http.StatusUnsupportedMediaType, code)
+	assert.Equal(t, common.UnsupportedMedia, response.Error)
+}
+
+func TestUpdateAssetValuePermission(t *testing.T) {
+	tests := []struct {
+		name         string
+		roles        []string
+		value        float64
+		expectedCode int
+	}{
+		{name: "Editor changing the value", roles: []string{common.RoleEditor}, value: 2000, expectedCode: http.StatusForbidden},
+		{name: "Editor keeping the value", roles: []string{common.RoleEditor}, value: 1000, expectedCode: http.StatusOK},
+		{name: "Finance changing the value", roles: []string{common.RoleFinance}, value: 2000, expectedCode: http.StatusOK},
+		{name: "Editor and finance changing the value", roles: []string{common.RoleEditor, common.RoleFinance}, value: 2000, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+			mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+			mockCategoryRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+			mockLocationRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+			mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+			service := NewAssetService(mockRepo, mockAuditRepo, mockCategoryRepo, mockLocationRepo, mockOutboxRepo, nil, nil, common.DefaultAssetTransitions)
+
+			testAsset := &models.Asset{Id: "test-id", Name: "Test Asset", Type: "Test Type", Value: 1000, AcquisitionDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+			mockRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "test-id"}).Return(testAsset, nil)
+			if tt.expectedCode == http.StatusOK {
+				mockCategoryRepo.EXPECT().GetCategoryByName(gomock.Any(), "Test Type").Return(&models.Category{Id: "category-id", Name: "Test Type"}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().UpdateAsset(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
+					return true, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockOutboxRepo.EXPECT().CreateOutboxEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			}
+
+			ctx := common.WithPrincipal(context.Background(), &common.Principal{Subject: "user-1", Roles: tt.roles})
+			code, response := service.UpdateAsset(ctx, "test-id", &dto.AssetInputDto{
+				Name:            "Test Asset",
+				Type:            "Test Type",
+				Value:           tt.value,
+				AcquisitionDate: "2023-01-01",
+			}, "")
+			assert.Equal(t, tt.expectedCode, code)
+			if tt.expectedCode == http.StatusForbidden {
+				assert.Equal(t, "Changing the value requires the finance or admin role", response.ErrorDescription)
+			}
+		})
+	}
+}
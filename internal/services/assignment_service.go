@@ -0,0 +1,330 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AssignmentServiceInterface interface {
+	CheckoutAsset(ctx context.Context, assetId string, input *dto.CheckoutInputDto) (code int, response *dto.BaseResponse)
+	CheckinAsset(ctx context.Context, assetId string, input *dto.CheckinInputDto) (code int, response *dto.BaseResponse)
+	GetAssignments(ctx context.Context, assetId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+}
+
+type assignmentService struct {
+	assignmentRepo repositories.AssignmentRepositoryInterface
+	assetRepo      repositories.AssetRepositoryInterface
+	auditRepo      repositories.AuditRepositoryInterface
+}
+
+func NewAssignmentService(assignmentRepo repositories.AssignmentRepositoryInterface, assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface) AssignmentServiceInterface {
+	return &assignmentService{assignmentRepo: assignmentRepo, assetRepo: assetRepo, auditRepo: auditRepo}
+}
+
+func (s *assignmentService) CheckoutAsset(ctx context.Context, assetId string, input *dto.CheckoutInputDto) (code int, response *dto.BaseResponse) {
+	input.Assignee = strings.TrimSpace(input.Assignee)
+	if input.Assignee == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Assignee is required",
+		}
+	}
+
+	dueDate, err := time.Parse("2006-01-02", input.DueDate)
+	if err != nil {
+		log.Println("[assignmentService][CheckoutAsset] error parsing date :", err)
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid due date format",
+		}
+	}
+
+	if dueDate.Before(today()) {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Due date must not be in the past",
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": assetId,
+	})
+	if err != nil {
+		log.Println("[assignmentService][CheckoutAsset] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	open, err := s.assignmentRepo.GetOpenAssignment(ctx, asset.Id)
+	if err != nil {
+		log.Println("[assignmentService][CheckoutAsset] error get open assignment :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if open != nil {
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Asset is already checked out to " + open.Assignee,
+		}
+	}
+
+	assignment := &models.Assignment{
+		AssetId:      asset.Id,
+		Assignee:     input.Assignee,
+		DueDate:      dueDate,
+		Note:         input.Note,
+		CheckedOutBy: common.ActorFromContext(ctx),
+	}
+
+	tx := s.assignmentRepo.StartTransaction(ctx)
+	assignment, err = s.assignmentRepo.CreateAssignment(ctx, assignment, tx)
+	if err != nil {
+		log.Println("[assignmentService][CheckoutAsset] error create assignment :", err)
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckoutAsset] error rollback transaction :", err)
+		}
+
+		// a concurrent check-out won the open assignment unique index
+		if open, _ = s.assignmentRepo.GetOpenAssignment(ctx, asset.Id); open != nil {
+			return http.StatusConflict, &dto.BaseResponse{
+				Error:            common.Conflict,
+				ErrorDescription: "Asset is already checked out to " + open.Assignee,
+			}
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCheckout, asset.Id,
+		map[string]interface{}{"assignee": nil},
+		map[string]interface{}{"assignee": assignment.Assignee, "due_date": input.DueDate})
+	if err != nil {
+		log.Println("[assignmentService][CheckoutAsset] error record audit :", err)
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckoutAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.assignmentRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[assignmentService][CheckoutAsset] error commit transaction :", err)
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckoutAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    assignmentOutput(assignment),
+	}
+}
+
+func (s *assignmentService) CheckinAsset(ctx context.Context, assetId string, input *dto.CheckinInputDto) (code int, response *dto.BaseResponse) {
+	input.Condition = strings.ToLower(strings.TrimSpace(input.Condition))
+	valid := false
+	for _, v := range common.ReturnConditions {
+		if v == input.Condition {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid condition, allowed conditions are " + strings.Join(common.ReturnConditions, ", "),
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": assetId,
+	})
+	if err != nil {
+		log.Println("[assignmentService][CheckinAsset] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	assignment, err := s.assignmentRepo.GetOpenAssignment(ctx, asset.Id)
+	if err != nil {
+		log.Println("[assignmentService][CheckinAsset] error get open assignment :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if assignment == nil {
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Asset is not checked out",
+		}
+	}
+
+	checkedInAt := time.Now().UTC()
+	assignment.CheckedInAt = &checkedInAt
+	assignment.CheckedInBy = common.ActorFromContext(ctx)
+	assignment.ReturnCondition = input.Condition
+	assignment.ReturnNote = input.Note
+
+	tx := s.assignmentRepo.StartTransaction(ctx)
+	closed, err := s.assignmentRepo.CloseAssignment(ctx, assignment, tx)
+	if err != nil {
+		log.Println("[assignmentService][CheckinAsset] error close assignment :", err)
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckinAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// a concurrent check-in closed the assignment first
+	if !closed {
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckinAsset] error rollback transaction :", err)
+		}
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Asset is not checked out",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCheckin, asset.Id,
+		map[string]interface{}{"assignee": assignment.Assignee},
+		map[string]interface{}{"assignee": nil, "return_condition": assignment.ReturnCondition})
+	if err != nil {
+		log.Println("[assignmentService][CheckinAsset] error record audit :", err)
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckinAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.assignmentRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[assignmentService][CheckinAsset] error commit transaction :", err)
+		err = s.assignmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[assignmentService][CheckinAsset] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    assignmentOutput(assignment),
+	}
+}
+
+// GetAssignments returns the assignment history of an asset, newest first
+func (s *assignmentService) GetAssignments(ctx context.Context, assetId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	assignments, count, err := s.assignmentRepo.GetAssignments(ctx, assetId, pagination)
+	if err != nil {
+		log.Println("[assignmentService][GetAssignments] error get assignments :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	assignmentsRes := []*dto.AssignmentOutputDto{}
+	for _, v := range assignments {
+		assignmentsRes = append(assignmentsRes, assignmentOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = assignmentsRes
+	return http.StatusOK, pagination
+}
+
+func (s *assignmentService) recordAudit(ctx context.Context, tx *gorm.DB, action string, assetId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityAsset, assetId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// today returns the start of the current day in UTC, due dates before it are overdue
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func assignmentOutput(assignment *models.Assignment) *dto.AssignmentOutputDto {
+	res := &dto.AssignmentOutputDto{
+		Id:              assignment.Id,
+		AssetId:         assignment.AssetId,
+		Assignee:        assignment.Assignee,
+		DueDate:         assignment.DueDate.Format("2006-01-02"),
+		Note:            assignment.Note,
+		CheckedOutAt:    assignment.CheckedOutAt.Format("2006-01-02 15:04:05"),
+		CheckedOutBy:    assignment.CheckedOutBy,
+		CheckedInBy:     assignment.CheckedInBy,
+		ReturnCondition: assignment.ReturnCondition,
+		ReturnNote:      assignment.ReturnNote,
+	}
+	if assignment.CheckedInAt != nil {
+		res.CheckedInAt = assignment.CheckedInAt.Format("2006-01-02 15:04:05")
+	} else {
+		res.Overdue = assignment.DueDate.Before(today())
+	}
+	return res
+}
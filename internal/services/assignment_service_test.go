@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCheckoutAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssignmentRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewAssignmentService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	dueDate := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+	pastDate := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	asset := &models.Asset{Id: "asset-id", Name: "Laptop"}
+
+	tests := []struct {
+		name                string
+		input               *dto.CheckoutInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:  "Success - Checkout asset",
+			input: &dto.CheckoutInputDto{Assignee: " Jane Doe ", DueDate: dueDate},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAssignment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, assignment *models.Assignment, tx *gorm.DB) (*models.Assignment, error) {
+					assert.Equal(t, "asset-id", assignment.AssetId)
+					assert.Equal(t, "Jane Doe", assignment.Assignee)
+					return assignment, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityAsset, auditLog.EntityType)
+					assert.Equal(t, common.AuditActionCheckout, auditLog.Action)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:                "Error - Missing assignee",
+			input:               &dto.CheckoutInputDto{Assignee: " ", DueDate: dueDate},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Assignee is required",
+		},
+		{
+			name:                "Error - Invalid due date",
+			input:               &dto.CheckoutInputDto{Assignee: "Jane Doe", DueDate: "next week"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid due date format",
+		},
+		{
+			name:                "Error - Due date in the past",
+			input:               &dto.CheckoutInputDto{Assignee: "Jane Doe", DueDate: pastDate},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Due date must not be in the past",
+		},
+		{
+			name:  "Error - Asset not found",
+			input: &dto.CheckoutInputDto{Assignee: "Jane Doe", DueDate: dueDate},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedDescription: "Asset not found",
+		},
+		{
+			name:  "Error - Already checked out",
+			input: &dto.CheckoutInputDto{Assignee: "Jane Doe", DueDate: dueDate},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(&models.Assignment{Id: "assignment-id", Assignee: "John Doe"}, nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedDescription: "Asset is already checked out to John Doe",
+		},
+		{
+			name:  "Error - Concurrent checkout wins",
+			input: &dto.CheckoutInputDto{Assignee: "Jane Doe", DueDate: dueDate},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(nil, nil),
+					mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(&models.Assignment{Id: "assignment-id", Assignee: "John Doe"}, nil),
+				)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAssignment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("UNIQUE constraint failed"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedDescription: "Asset is already checked out to John Doe",
+		},
+		{
+			name:  "Error - Create assignment",
+			input: &dto.CheckoutInputDto{Assignee: "Jane Doe", DueDate: dueDate},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(nil, nil).Times(2)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAssignment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CheckoutAsset(context.Background(), "asset-id", tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestCheckinAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssignmentRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewAssignmentService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	asset := &models.Asset{Id: "asset-id", Name: "Laptop"}
+	openAssignment := func() *models.Assignment {
+		return &models.Assignment{Id: "assignment-id", AssetId: "asset-id", Assignee: "Jane Doe", DueDate: today()}
+	}
+
+	tests := []struct {
+		name                string
+		input               *dto.CheckinInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:  "Success - Checkin asset",
+			input: &dto.CheckinInputDto{Condition: "Good", Note: "minor scratches"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(openAssignment(), nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CloseAssignment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, assignment *models.Assignment, tx *gorm.DB) (bool, error) {
+					assert.NotNil(t, assignment.CheckedInAt)
+					assert.Equal(t, "good", assignment.ReturnCondition)
+					assert.Equal(t, "minor scratches", assignment.ReturnNote)
+					return true, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditActionCheckin, auditLog.Action)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:                "Error - Invalid condition",
+			input:               &dto.CheckinInputDto{Condition: "broken"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid condition, allowed conditions are good, fair, poor, damaged",
+		},
+		{
+			name:  "Error - Asset not found",
+			input: &dto.CheckinInputDto{Condition: "good"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedDescription: "Asset not found",
+		},
+		{
+			name:  "Error - Not checked out",
+			input: &dto.CheckinInputDto{Condition: "good"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(nil, nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedDescription: "Asset is not checked out",
+		},
+		{
+			name:  "Error - Concurrent checkin wins",
+			input: &dto.CheckinInputDto{Condition: "good"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetOpenAssignment(gomock.Any(), "asset-id").Return(openAssignment(), nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CloseAssignment(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusConflict,
+			expectedDescription: "Asset is not checked out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CheckinAsset(context.Background(), "asset-id", tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestGetAssignments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAssignmentRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewAssignmentService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	checkedInAt := time.Now().UTC()
+	assignments := []*models.Assignment{
+		{Id: "open-overdue", AssetId: "asset-id", Assignee: "Jane Doe", DueDate: today().AddDate(0, 0, -1)},
+		{Id: "closed-late", AssetId: "asset-id", Assignee: "John Doe", DueDate: today().AddDate(0, 0, -30), CheckedInAt: &checkedInAt, ReturnCondition: "good"},
+	}
+
+	mockRepo.EXPECT().GetAssignments(gomock.Any(), "asset-id", gomock.Any()).Return(assignments, int64(2), nil)
+
+	code, response := service.GetAssignments(context.Background(), "asset-id", &dto.MetaPagination{Page: 1, Limit: 10})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, int64(1), response.TotalPage)
+
+	data := response.Data.([]*dto.AssignmentOutputDto)
+	assert.True(t, data[0].Overdue)
+	assert.False(t, data[1].Overdue)
+	assert.NotEmpty(t, data[1].CheckedInAt)
+}
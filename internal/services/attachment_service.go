@@ -0,0 +1,401 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/storage"
+	"assets-api-go/internal/thumbnail"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AttachmentServiceInterface interface {
+	UploadAttachment(ctx context.Context, assetId string, fileName string, size int64, content io.Reader) (code int, response *dto.BaseResponse)
+	GetAttachments(ctx context.Context, assetId string) (code int, response *dto.BaseResponse)
+	DownloadAttachment(ctx context.Context, id string, thumbnail bool) (code int, content *dto.AttachmentContentDto, response *dto.BaseResponse)
+	DeleteAttachment(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+}
+
+// thumbnailSize is the longest side of attachment thumbnails in pixels
+const thumbnailSize = 256
+
+// thumbnailTypes are the image types a thumbnail can be generated for
+var thumbnailTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true}
+
+type attachmentService struct {
+	attachmentRepo repositories.AttachmentRepositoryInterface
+	assetRepo      repositories.AssetRepositoryInterface
+	auditRepo      repositories.AuditRepositoryInterface
+	storage        storage.Storage
+	maxSize        int64
+	allowedTypes   map[string]bool
+}
+
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepositoryInterface, assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, fileStorage storage.Storage, maxSize int64, allowedTypes []string) AttachmentServiceInterface {
+	allowed := map[string]bool{}
+	for _, v := range allowedTypes {
+		allowed[v] = true
+	}
+	return &attachmentService{attachmentRepo: attachmentRepo, assetRepo: assetRepo, auditRepo: auditRepo, storage: fileStorage, maxSize: maxSize, allowedTypes: allowed}
+}
+
+// UploadAttachment stores a file with an asset. The MIME type is detected from the content
+// rather than trusted from the client, images get a thumbnail.
+func (s *attachmentService) UploadAttachment(ctx context.Context, assetId string, fileName string, size int64, content io.Reader) (code int, response *dto.BaseResponse) {
+	if size > s.maxSize {
+		return s.tooLarge()
+	}
+
+	fileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "File name is required",
+		}
+	}
+	if len(fileName) > 255 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "File name must be at most 255 characters",
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": assetId,
+	})
+	if err != nil {
+		log.Println("[attachmentService][UploadAttachment] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	// the declared size can be wrong, never read more than one byte past the limit
+	data, err := io.ReadAll(io.LimitReader(content, s.maxSize+1))
+	if err != nil {
+		log.Println("[attachmentService][UploadAttachment] error read file :", err)
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Could not read the uploaded file",
+		}
+	}
+	if int64(len(data)) > s.maxSize {
+		return s.tooLarge()
+	}
+	if len(data) == 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "File is empty",
+		}
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !s.allowedTypes[contentType] {
+		return http.StatusUnsupportedMediaType, &dto.BaseResponse{
+			Error:            common.UnsupportedMedia,
+			ErrorDescription: fmt.Sprintf("File type %s is not allowed", contentType),
+		}
+	}
+
+	checksum := sha256.Sum256(data)
+	// the id is chosen up front so the storage key can be derived from it
+	attachmentId := uuid.New().String()
+	attachment := &models.Attachment{
+		Id:          attachmentId,
+		AssetId:     asset.Id,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+		StorageKey:  "assets/" + asset.Id + "/" + attachmentId,
+		UploadedBy:  common.ActorFromContext(ctx),
+	}
+
+	err = s.storage.Put(ctx, attachment.StorageKey, data, contentType)
+	if err != nil {
+		log.Println("[attachmentService][UploadAttachment] error store file :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// a thumbnail is a convenience, the upload still succeeds without one
+	if thumbnailTypes[contentType] {
+		thumb, err := thumbnail.Generate(data, thumbnailSize)
+		if err != nil {
+			log.Println("[attachmentService][UploadAttachment] error generate thumbnail :", err)
+		} else if err = s.storage.Put(ctx, attachment.StorageKey+"_thumb.jpg", thumb, "image/jpeg"); err != nil {
+			log.Println("[attachmentService][UploadAttachment] error store thumbnail :", err)
+		} else {
+			attachment.ThumbnailKey = attachment.StorageKey + "_thumb.jpg"
+		}
+	}
+
+	storageKey, thumbnailKey := attachment.StorageKey, attachment.ThumbnailKey
+	tx := s.attachmentRepo.StartTransaction(ctx)
+	attachment, err = s.attachmentRepo.CreateAttachment(ctx, attachment, tx)
+	if err != nil {
+		log.Println("[attachmentService][UploadAttachment] error create attachment :", err)
+		err = s.attachmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[attachmentService][UploadAttachment] error rollback transaction :", err)
+		}
+		s.removeFiles(ctx, "UploadAttachment", storageKey, thumbnailKey)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, attachment.Id, nil, attachmentAuditFields(attachment))
+	if err != nil {
+		log.Println("[attachmentService][UploadAttachment] error record audit :", err)
+		err = s.attachmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[attachmentService][UploadAttachment] error rollback transaction :", err)
+		}
+		s.removeFiles(ctx, "UploadAttachment", storageKey, thumbnailKey)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.attachmentRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[attachmentService][UploadAttachment] error commit transaction :", err)
+		err = s.attachmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[attachmentService][UploadAttachment] error rollback transaction :", err)
+		}
+		s.removeFiles(ctx, "UploadAttachment", storageKey, thumbnailKey)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    attachmentOutput(attachment),
+	}
+}
+
+func (s *attachmentService) GetAttachments(ctx context.Context, assetId string) (code int, response *dto.BaseResponse) {
+	attachments, err := s.attachmentRepo.GetAttachments(ctx, assetId)
+	if err != nil {
+		log.Println("[attachmentService][GetAttachments] error get attachments :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	attachmentsRes := []*dto.AttachmentOutputDto{}
+	for _, v := range attachments {
+		attachmentsRes = append(attachmentsRes, attachmentOutput(v))
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    attachmentsRes,
+	}
+}
+
+// DownloadAttachment opens the stored file of an attachment, or its thumbnail
+func (s *attachmentService) DownloadAttachment(ctx context.Context, id string, thumbnail bool) (code int, content *dto.AttachmentContentDto, response *dto.BaseResponse) {
+	attachment, err := s.attachmentRepo.GetAttachmentByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[attachmentService][DownloadAttachment] error get attachment :", err)
+		return http.StatusInternalServerError, nil, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if attachment == nil {
+		return http.StatusNotFound, nil, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Attachment not found",
+		}
+	}
+
+	content = &dto.AttachmentContentDto{
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Checksum:    attachment.Checksum,
+	}
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return http.StatusNotFound, nil, &dto.BaseResponse{
+				Error:            common.NotFound,
+				ErrorDescription: "Attachment has no thumbnail",
+			}
+		}
+		key = attachment.ThumbnailKey
+		content.FileName = strings.TrimSuffix(attachment.FileName, filepath.Ext(attachment.FileName)) + "_thumb.jpg"
+		content.ContentType = "image/jpeg"
+		content.Checksum = ""
+	}
+
+	content.Content, err = s.storage.Get(ctx, key)
+	if err != nil {
+		log.Println("[attachmentService][DownloadAttachment] error get file :", err)
+		if errors.Is(err, storage.ErrNotFound) {
+			return http.StatusNotFound, nil, &dto.BaseResponse{
+				Error:            common.NotFound,
+				ErrorDescription: "Attachment file not found",
+			}
+		}
+		return http.StatusInternalServerError, nil, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, content, nil
+}
+
+// DeleteAttachment removes an attachment. The stored files are removed once the record is gone,
+// a file left behind by a storage error is only logged.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	attachment, err := s.attachmentRepo.GetAttachmentByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[attachmentService][DeleteAttachment] error get attachment :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if attachment == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Attachment not found",
+		}
+	}
+
+	tx := s.attachmentRepo.StartTransaction(ctx)
+	err = s.attachmentRepo.DeleteAttachment(ctx, attachment, tx)
+	if err != nil {
+		log.Println("[attachmentService][DeleteAttachment] error delete attachment :", err)
+		err = s.attachmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[attachmentService][DeleteAttachment] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionDelete, attachment.Id, attachmentAuditFields(attachment), nil)
+	if err != nil {
+		log.Println("[attachmentService][DeleteAttachment] error record audit :", err)
+		err = s.attachmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[attachmentService][DeleteAttachment] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.attachmentRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[attachmentService][DeleteAttachment] error commit transaction :", err)
+		err = s.attachmentRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[attachmentService][DeleteAttachment] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	s.removeFiles(ctx, "DeleteAttachment", attachment.StorageKey, attachment.ThumbnailKey)
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+func (s *attachmentService) removeFiles(ctx context.Context, method string, keys ...string) {
+	for _, v := range keys {
+		if v == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, v); err != nil {
+			log.Printf("[attachmentService][%s] error delete file %s : %v", method, v, err)
+		}
+	}
+}
+
+func (s *attachmentService) tooLarge() (int, *dto.BaseResponse) {
+	return http.StatusRequestEntityTooLarge, &dto.BaseResponse{
+		Error:            common.PayloadTooLarge,
+		ErrorDescription: fmt.Sprintf("File must be at most %d bytes", s.maxSize),
+	}
+}
+
+func (s *attachmentService) recordAudit(ctx context.Context, tx *gorm.DB, action string, attachmentId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityAttachment, attachmentId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+func attachmentAuditFields(attachment *models.Attachment) map[string]interface{} {
+	return map[string]interface{}{
+		"asset_id":     attachment.AssetId,
+		"file_name":    attachment.FileName,
+		"content_type": attachment.ContentType,
+		"size":         attachment.Size,
+		"checksum":     attachment.Checksum,
+	}
+}
+
+func attachmentOutput(attachment *models.Attachment) *dto.AttachmentOutputDto {
+	return &dto.AttachmentOutputDto{
+		Id:           attachment.Id,
+		AssetId:      attachment.AssetId,
+		FileName:     attachment.FileName,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		Checksum:     attachment.Checksum,
+		HasThumbnail: attachment.ThumbnailKey != "",
+		UploadedBy:   attachment.UploadedBy,
+		CreatedAt:    attachment.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/storage"
+	"assets-api-go/mocks/repositories"
+	mockStorage "assets-api-go/mocks/storage"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUploadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAttachmentRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockFileStorage := mockStorage.NewMockStorage(ctrl)
+	service := NewAttachmentService(mockRepo, mockAssetRepo, mockAuditRepo, mockFileStorage, 1024, common.DefaultAttachmentTypes)
+
+	pdf := []byte("%PDF-1.4\ninvoice")
+	sum := sha256.Sum256(pdf)
+	pdfChecksum := hex.EncodeToString(sum[:])
+	var pngBuf bytes.Buffer
+	assert.NoError(t, png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 20, 10))))
+
+	tests := []struct {
+		name                string
+		fileName            string
+		content             []byte
+		declaredSize        int64
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:     "Success - Upload pdf",
+			fileName: `C:\invoices\invoice.pdf`,
+			content:  pdf,
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(&models.Asset{Id: "asset-id"}, nil)
+				mockFileStorage.EXPECT().Put(gomock.Any(), gomock.Any(), pdf, "application/pdf").DoAndReturn(func(ctx context.Context, key string, content []byte, contentType string) error {
+					assert.True(t, strings.HasPrefix(key, "assets/asset-id/"))
+					return nil
+				})
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attachment *models.Attachment, tx *gorm.DB) (*models.Attachment, error) {
+					assert.Equal(t, "invoice.pdf", attachment.FileName)
+					assert.Equal(t, pdfChecksum, attachment.Checksum)
+					assert.Equal(t, int64(len(pdf)), attachment.Size)
+					assert.Empty(t, attachment.ThumbnailKey)
+					return attachment, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityAttachment, auditLog.EntityType)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:     "Success - Upload image with thumbnail",
+			fileName: "photo.png",
+			content:  pngBuf.Bytes(),
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(&models.Asset{Id: "asset-id"}, nil)
+				mockFileStorage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), "image/png").Return(nil)
+				mockFileStorage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), "image/jpeg").Return(nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attachment *models.Attachment, tx *gorm.DB) (*models.Attachment, error) {
+					assert.Equal(t, attachment.StorageKey+"_thumb.jpg", attachment.ThumbnailKey)
+					return attachment, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:     "Error - Type not allowed",
+			fileName: "tool.exe",
+			content:  []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff"),
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(&models.Asset{Id: "asset-id"}, nil)
+			},
+			expectedCode:        http.StatusUnsupportedMediaType,
+			expectedDescription: "File type application/octet-stream is not allowed",
+		},
+		{
+			name:         "Error - Content larger than the declared size and the limit",
+			fileName:     "manual.pdf",
+			content:      append([]byte("%PDF-1.4\n"), make([]byte, 1024)...),
+			declaredSize: 100,
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(&models.Asset{Id: "asset-id"}, nil)
+			},
+			expectedCode:        http.StatusRequestEntityTooLarge,
+			expectedDescription: "File must be at most 1024 bytes",
+		},
+		{
+			name:     "Error - Asset not found",
+			fileName: "invoice.pdf",
+			content:  pdf,
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedDescription: "Asset not found",
+		},
+		{
+			name:     "Error - Stored file removed when the record fails",
+			fileName: "invoice.pdf",
+			content:  pdf,
+			mockSetup: func() {
+				var storedKey string
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(&models.Asset{Id: "asset-id"}, nil)
+				mockFileStorage.EXPECT().Put(gomock.Any(), gomock.Any(), pdf, "application/pdf").DoAndReturn(func(ctx context.Context, key string, content []byte, contentType string) error {
+					storedKey = key
+					return nil
+				})
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+				mockFileStorage.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) error {
+					assert.Equal(t, storedKey, key)
+					return nil
+				})
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			size := tt.declaredSize
+			if size == 0 {
+				size = int64(len(tt.content))
+			}
+			code, response := service.UploadAttachment(context.Background(), "asset-id", tt.fileName, size, bytes.NewReader(tt.content))
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+
+	t.Run("Error - Declared size larger than the limit", func(t *testing.T) {
+		code, response := service.UploadAttachment(context.Background(), "asset-id", "manual.pdf", 4096, bytes.NewReader(pdf))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+		assert.Equal(t, common.PayloadTooLarge, response.Error)
+	})
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAttachmentRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockFileStorage := mockStorage.NewMockStorage(ctrl)
+	service := NewAttachmentService(mockRepo, mockAssetRepo, mockAuditRepo, mockFileStorage, 1024, common.DefaultAttachmentTypes)
+
+	photo := &models.Attachment{Id: "photo-id", FileName: "photo.png", ContentType: "image/png", Checksum: "abc", StorageKey: "assets/asset-id/photo-id", ThumbnailKey: "assets/asset-id/photo-id_thumb.jpg"}
+	invoice := &models.Attachment{Id: "invoice-id", FileName: "invoice.pdf", ContentType: "application/pdf", StorageKey: "assets/asset-id/invoice-id"}
+
+	t.Run("Success - File", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "photo-id"}).Return(photo, nil)
+		mockFileStorage.EXPECT().Get(gomock.Any(), "assets/asset-id/photo-id").Return(io.NopCloser(strings.NewReader("png")), nil)
+
+		code, content, response := service.DownloadAttachment(context.Background(), "photo-id", false)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, response)
+		assert.Equal(t, "photo.png", content.FileName)
+		assert.Equal(t, "image/png", content.ContentType)
+		assert.Equal(t, "abc", content.Checksum)
+	})
+
+	t.Run("Success - Thumbnail", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "photo-id"}).Return(photo, nil)
+		mockFileStorage.EXPECT().Get(gomock.Any(), "assets/asset-id/photo-id_thumb.jpg").Return(io.NopCloser(strings.NewReader("jpeg")), nil)
+
+		code, content, _ := service.DownloadAttachment(context.Background(), "photo-id", true)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "photo_thumb.jpg", content.FileName)
+		assert.Equal(t, "image/jpeg", content.ContentType)
+	})
+
+	t.Run("Error - No thumbnail", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "invoice-id"}).Return(invoice, nil)
+
+		code, _, response := service.DownloadAttachment(context.Background(), "invoice-id", true)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "Attachment has no thumbnail", response.ErrorDescription)
+	})
+
+	t.Run("Error - File missing from storage", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "invoice-id"}).Return(invoice, nil)
+		mockFileStorage.EXPECT().Get(gomock.Any(), "assets/asset-id/invoice-id").Return(nil, storage.ErrNotFound)
+
+		code, _, response := service.DownloadAttachment(context.Background(), "invoice-id", false)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "Attachment file not found", response.ErrorDescription)
+	})
+
+	t.Run("Error - Attachment not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+
+		code, _, response := service.DownloadAttachment(context.Background(), "missing-id", false)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "Attachment not found", response.ErrorDescription)
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockAttachmentRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockFileStorage := mockStorage.NewMockStorage(ctrl)
+	service := NewAttachmentService(mockRepo, mockAssetRepo, mockAuditRepo, mockFileStorage, 1024, common.DefaultAttachmentTypes)
+
+	t.Run("Success - Record and files removed", func(t *testing.T) {
+		photo := &models.Attachment{Id: "photo-id", StorageKey: "assets/asset-id/photo-id", ThumbnailKey: "assets/asset-id/photo-id_thumb.jpg"}
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "photo-id"}).Return(photo, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().DeleteAttachment(gomock.Any(), photo, gomock.Any()).Return(nil)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+		mockFileStorage.EXPECT().Delete(gomock.Any(), "assets/asset-id/photo-id").Return(nil)
+		mockFileStorage.EXPECT().Delete(gomock.Any(), "assets/asset-id/photo-id_thumb.jpg").Return(errors.New("storage error"))
+
+		code, _ := service.DeleteAttachment(context.Background(), "photo-id")
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Files kept when the record fails", func(t *testing.T) {
+		invoice := &models.Attachment{Id: "invoice-id", StorageKey: "assets/asset-id/invoice-id"}
+		mockRepo.EXPECT().GetAttachmentByAttribute(gomock.Any(), map[string]interface{}{"id": "invoice-id"}).Return(invoice, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().DeleteAttachment(gomock.Any(), invoice, gomock.Any()).Return(errors.New("database error"))
+		mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.DeleteAttachment(context.Background(), "invoice-id")
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+)
+
+type AuditServiceInterface interface {
+	GetAssetHistory(ctx context.Context, assetId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	GetAuditLogs(ctx context.Context, filter *dto.AuditFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepositoryInterface
+}
+
+func NewAuditService(auditRepo repositories.AuditRepositoryInterface) AuditServiceInterface {
+	return &auditService{auditRepo: auditRepo}
+}
+
+func (s *auditService) GetAssetHistory(ctx context.Context, assetId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	return s.GetAuditLogs(ctx, &dto.AuditFilterDto{
+		EntityType: common.AuditEntityAsset,
+		EntityId:   assetId,
+	}, pagination)
+}
+
+func (s *auditService) GetAuditLogs(ctx context.Context, filter *dto.AuditFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	var err error
+	if filter.From != "" {
+		filter.FromTime, err = parseFilterTime(filter.From, false)
+		if err != nil {
+			log.Println("[auditService][GetAuditLogs] error parsing from :", err)
+			return http.StatusBadRequest, &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid from date format",
+				},
+			}
+		}
+	}
+	if filter.To != "" {
+		filter.ToTime, err = parseFilterTime(filter.To, true)
+		if err != nil {
+			log.Println("[auditService][GetAuditLogs] error parsing to :", err)
+			return http.StatusBadRequest, &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid to date format",
+				},
+			}
+		}
+	}
+
+	auditLogs, count, err := s.auditRepo.GetAuditLogs(ctx, filter, pagination)
+	if err != nil {
+		log.Println("[auditService][GetAuditLogs] error get audit logs :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	auditLogsRes := []*dto.AuditLogOutputDto{}
+	for _, v := range auditLogs {
+		changes := []*dto.AuditChangeDto{}
+		if v.Changes != "" {
+			if err = json.Unmarshal([]byte(v.Changes), &changes); err != nil {
+				log.Println("[auditService][GetAuditLogs] error decode changes :", err)
+			}
+		}
+		auditLogsRes = append(auditLogsRes, &dto.AuditLogOutputDto{
+			Id:         v.Id,
+			EntityType: v.EntityType,
+			EntityId:   v.EntityId,
+			Action:     v.Action,
+			Actor:      v.Actor,
+			RequestId:  v.RequestId,
+			Changes:    changes,
+			CreatedAt:  v.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = auditLogsRes
+	return http.StatusOK, pagination
+}
+
+// newAuditLog builds an audit record for the actor and request found in ctx
+func newAuditLog(ctx context.Context, entityType string, entityId string, action string, changes []*dto.AuditChangeDto) (*models.AuditLog, error) {
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuditLog{
+		EntityType: entityType,
+		EntityId:   entityId,
+		Action:     action,
+		Actor:      common.ActorFromContext(ctx),
+		RequestId:  common.RequestIdFromContext(ctx),
+		Changes:    string(encoded),
+	}, nil
+}
+
+// diffFields returns a change for every field whose value differs between before and after.
+// A nil map stands for a record that does not exist yet or anymore.
+func diffFields(before, after map[string]interface{}) []*dto.AuditChangeDto {
+	fields := map[string]bool{}
+	for k := range before {
+		fields[k] = true
+	}
+	for k := range after {
+		fields[k] = true
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	changes := []*dto.AuditChangeDto{}
+	for _, k := range keys {
+		if before != nil && after != nil && reflect.DeepEqual(before[k], after[k]) {
+			continue
+		}
+		changes = append(changes, &dto.AuditChangeDto{
+			Field:  k,
+			Before: before[k],
+			After:  after[k],
+		})
+	}
+	return changes
+}
+
+// parseFilterTime accepts a date or an RFC3339 timestamp. A date used as an upper bound
+// includes the whole day.
+func parseFilterTime(value string, upperBound bool) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if upperBound {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
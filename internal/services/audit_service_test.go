@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewAuditService(mockAuditRepo)
+
+	testTime := time.Now()
+	testAuditLogs := []*models.AuditLog{
+		{
+			Id:         "audit-id",
+			EntityType: common.AuditEntityAsset,
+			EntityId:   "test-id",
+			Action:     common.AuditActionUpdate,
+			Actor:      "auditor@example.com",
+			RequestId:  "req-1",
+			Changes:    `[{"field":"value","before":1000,"after":2500}]`,
+			CreatedAt:  testTime,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		filter         *dto.AuditFilterDto
+		mockSetup      func()
+		expectedCode   int
+		expectedResult *dto.MetaPagination
+	}{
+		{
+			name:   "Success - Get audit logs",
+			filter: &dto.AuditFilterDto{Actor: "auditor@example.com", From: "2023-01-01", To: "2023-01-31"},
+			mockSetup: func() {
+				mockAuditRepo.EXPECT().GetAuditLogs(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AuditFilterDto, pagination *dto.MetaPagination) ([]*models.AuditLog, int64, error) {
+					assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), filter.FromTime)
+					assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), filter.ToTime)
+					return testAuditLogs, 1, nil
+				})
+			},
+			expectedCode: http.StatusOK,
+			expectedResult: &dto.MetaPagination{
+				Limit:     10,
+				Total:     1,
+				TotalPage: 1,
+				BaseResponse: dto.BaseResponse{
+					Data: []*dto.AuditLogOutputDto{
+						{
+							Id:         "audit-id",
+							EntityType: common.AuditEntityAsset,
+							EntityId:   "test-id",
+							Action:     common.AuditActionUpdate,
+							Actor:      "auditor@example.com",
+							RequestId:  "req-1",
+							Changes: []*dto.AuditChangeDto{
+								{Field: "value", Before: float64(1000), After: float64(2500)},
+							},
+							CreatedAt: testTime.Format("2006-01-02 15:04:05"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "Error - Invalid from date",
+			filter:       &dto.AuditFilterDto{From: "yesterday"},
+			mockSetup:    func() {},
+			expectedCode: http.StatusBadRequest,
+			expectedResult: &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid from date format",
+				},
+			},
+		},
+		{
+			name:   "Error - Repository error",
+			filter: &dto.AuditFilterDto{},
+			mockSetup: func() {
+				mockAuditRepo.EXPECT().GetAuditLogs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("repository error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedResult: &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.InternalServerError,
+					ErrorDescription: "Something went wrong",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.GetAuditLogs(context.Background(), tt.filter, &dto.MetaPagination{Limit: 10})
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedResult, response)
+		})
+	}
+}
+
+func TestGetAssetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewAuditService(mockAuditRepo)
+
+	mockAuditRepo.EXPECT().GetAuditLogs(gomock.Any(), &dto.AuditFilterDto{
+		EntityType: common.AuditEntityAsset,
+		EntityId:   "test-id",
+	}, gomock.Any()).Return([]*models.AuditLog{}, int64(0), nil)
+
+	code, response := service.GetAssetHistory(context.Background(), "test-id", &dto.MetaPagination{Limit: 10})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []*dto.AuditLogOutputDto{}, response.Data)
+}
+
+func TestDiffFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   map[string]interface{}
+		after    map[string]interface{}
+		expected []*dto.AuditChangeDto
+	}{
+		{
+			name:   "Created record lists every field",
+			before: nil,
+			after:  map[string]interface{}{"name": "Laptop", "value": 1000.0},
+			expected: []*dto.AuditChangeDto{
+				{Field: "name", Before: nil, After: "Laptop"},
+				{Field: "value", Before: nil, After: 1000.0},
+			},
+		},
+		{
+			name:   "Updated record lists changed fields only",
+			before: map[string]interface{}{"name": "Laptop", "value": 1000.0},
+			after:  map[string]interface{}{"name": "Laptop", "value": 900.0},
+			expected: []*dto.AuditChangeDto{
+				{Field: "value", Before: 1000.0, After: 900.0},
+			},
+		},
+		{
+			name:     "Unchanged record has no changes",
+			before:   map[string]interface{}{"name": "Laptop"},
+			after:    map[string]interface{}{"name": "Laptop"},
+			expected: []*dto.AuditChangeDto{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffFields(tt.before, tt.after))
+		})
+	}
+}
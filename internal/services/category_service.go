@@ -0,0 +1,573 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/depreciation"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type CategoryServiceInterface interface {
+	CreateCategory(ctx context.Context, input *dto.CategoryInputDto) (code int, response *dto.BaseResponse)
+	GetCategoryById(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetCategories(ctx context.Context, filter *dto.CategoryFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	GetCategoryTree(ctx context.Context) (code int, response *dto.BaseResponse)
+	UpdateCategory(ctx context.Context, id string, input *dto.CategoryInputDto) (code int, response *dto.BaseResponse)
+	DeleteCategory(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+}
+
+type categoryService struct {
+	categoryRepo repositories.CategoryRepositoryInterface
+	auditRepo    repositories.AuditRepositoryInterface
+}
+
+func NewCategoryService(categoryRepo repositories.CategoryRepositoryInterface, auditRepo repositories.AuditRepositoryInterface) CategoryServiceInterface {
+	return &categoryService{categoryRepo: categoryRepo, auditRepo: auditRepo}
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, input *dto.CategoryInputDto) (code int, response *dto.BaseResponse) {
+	if err := validateCategoryInput(input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if code, response = s.checkCategoryUnique(ctx, "", input); response != nil {
+		return code, response
+	}
+
+	category := &models.Category{}
+	if input.ParentId != "" {
+		parent, err := s.categoryRepo.GetCategoryByAttribute(ctx, map[string]interface{}{
+			"id": input.ParentId,
+		})
+		if err != nil {
+			log.Println("[categoryService][CreateCategory] error get parent category :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+
+		if parent == nil {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Parent category not found",
+			}
+		}
+		category.ParentId = &parent.Id
+	}
+	applyCategoryInput(category, input)
+
+	tx := s.categoryRepo.StartTransaction(ctx)
+	category, err := s.categoryRepo.CreateCategory(ctx, category, tx)
+	if err != nil {
+		log.Println("[categoryService][CreateCategory] error create category :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][CreateCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, category.Id, nil, categoryAuditFields(category))
+	if err != nil {
+		log.Println("[categoryService][CreateCategory] error record audit :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][CreateCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.categoryRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[categoryService][CreateCategory] error commit transaction :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][CreateCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    categoryOutput(category),
+	}
+}
+
+func (s *categoryService) GetCategoryById(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	category, err := s.categoryRepo.GetCategoryByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[categoryService][GetCategoryById] error get category :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if category == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Category not found",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    categoryOutput(category),
+	}
+}
+
+func (s *categoryService) GetCategories(ctx context.Context, filter *dto.CategoryFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	categories, count, err := s.categoryRepo.GetCategories(ctx, filter, pagination)
+	if err != nil {
+		log.Println("[categoryService][GetCategories] error get categories :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	categoriesRes := []*dto.CategoryOutputDto{}
+	for _, v := range categories {
+		categoriesRes = append(categoriesRes, categoryOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = categoriesRes
+	return http.StatusOK, pagination
+}
+
+// GetCategoryTree returns every category nested under its parent
+func (s *categoryService) GetCategoryTree(ctx context.Context) (code int, response *dto.BaseResponse) {
+	categories, err := s.categoryRepo.GetAllCategories(ctx)
+	if err != nil {
+		log.Println("[categoryService][GetCategoryTree] error get categories :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	nodes := map[string]*dto.CategoryOutputDto{}
+	for _, v := range categories {
+		nodes[v.Id] = categoryOutput(v)
+	}
+
+	roots := []*dto.CategoryOutputDto{}
+	for _, v := range categories {
+		node := nodes[v.Id]
+		if v.ParentId != nil {
+			if parent, ok := nodes[*v.ParentId]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    roots,
+	}
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, id string, input *dto.CategoryInputDto) (code int, response *dto.BaseResponse) {
+	category, err := s.categoryRepo.GetCategoryByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[categoryService][UpdateCategory] error get category :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if category == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Category not found",
+		}
+	}
+
+	if err = validateCategoryInput(input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if code, response = s.checkCategoryUnique(ctx, category.Id, input); response != nil {
+		return code, response
+	}
+
+	before := categoryAuditFields(category)
+	renamed := category.Name != input.Name
+
+	category.ParentId = nil
+	if input.ParentId != "" {
+		if code, response = s.checkCategoryParent(ctx, category.Id, input.ParentId); response != nil {
+			return code, response
+		}
+		category.ParentId = &input.ParentId
+	}
+	applyCategoryInput(category, input)
+
+	tx := s.categoryRepo.StartTransaction(ctx)
+	category, err = s.categoryRepo.UpdateCategory(ctx, category, tx)
+	if err != nil {
+		log.Println("[categoryService][UpdateCategory] error update category :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][UpdateCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// the asset type mirrors the category name
+	if renamed {
+		err = s.categoryRepo.UpdateAssetsType(ctx, category.Id, category.Name, tx)
+		if err != nil {
+			log.Println("[categoryService][UpdateCategory] error update assets type :", err)
+			err = s.categoryRepo.RollbackTransaction(tx)
+			if err != nil {
+				log.Println("[categoryService][UpdateCategory] error rollback transaction :", err)
+			}
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionUpdate, category.Id, before, categoryAuditFields(category))
+	if err != nil {
+		log.Println("[categoryService][UpdateCategory] error record audit :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][UpdateCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.categoryRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[categoryService][UpdateCategory] error commit transaction :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][UpdateCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    categoryOutput(category),
+	}
+}
+
+// DeleteCategory removes a category that has no sub categories and no assets, trashed
+// assets included
+func (s *categoryService) DeleteCategory(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	category, err := s.categoryRepo.GetCategoryByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[categoryService][DeleteCategory] error get category :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if category == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Category not found",
+		}
+	}
+
+	children, err := s.categoryRepo.CountChildren(ctx, category.Id)
+	if err != nil {
+		log.Println("[categoryService][DeleteCategory] error count children :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if children > 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Category has sub categories",
+		}
+	}
+
+	assets, err := s.categoryRepo.CountAssets(ctx, category.Id)
+	if err != nil {
+		log.Println("[categoryService][DeleteCategory] error count assets :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if assets > 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Category is used by assets",
+		}
+	}
+
+	tx := s.categoryRepo.StartTransaction(ctx)
+	err = s.categoryRepo.DeleteCategory(ctx, category, tx)
+	if err != nil {
+		log.Println("[categoryService][DeleteCategory] error delete category :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][DeleteCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionDelete, category.Id, categoryAuditFields(category), nil)
+	if err != nil {
+		log.Println("[categoryService][DeleteCategory] error record audit :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][DeleteCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.categoryRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[categoryService][DeleteCategory] error commit transaction :", err)
+		err = s.categoryRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[categoryService][DeleteCategory] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// checkCategoryUnique rejects a name or code that another category already uses. Both are
+// compared case-insensitively.
+func (s *categoryService) checkCategoryUnique(ctx context.Context, id string, input *dto.CategoryInputDto) (code int, response *dto.BaseResponse) {
+	existing, err := s.categoryRepo.GetCategoryByName(ctx, input.Name)
+	if err != nil {
+		log.Println("[categoryService][checkCategoryUnique] error get category by name :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if existing != nil && existing.Id != id {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Category already exist",
+		}
+	}
+
+	if input.Code == "" {
+		return 0, nil
+	}
+
+	existing, err = s.categoryRepo.GetCategoryByCode(ctx, input.Code)
+	if err != nil {
+		log.Println("[categoryService][checkCategoryUnique] error get category by code :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if existing != nil && existing.Id != id {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Category code already exist",
+		}
+	}
+
+	return 0, nil
+}
+
+// checkCategoryParent makes sure the parent exists and is neither the category itself nor
+// one of its descendants, which would create a cycle
+func (s *categoryService) checkCategoryParent(ctx context.Context, id string, parentId string) (code int, response *dto.BaseResponse) {
+	if parentId == id {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Category cannot be its own parent",
+		}
+	}
+
+	parent, err := s.categoryRepo.GetCategoryByAttribute(ctx, map[string]interface{}{
+		"id": parentId,
+	})
+	if err != nil {
+		log.Println("[categoryService][checkCategoryParent] error get parent category :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if parent == nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Parent category not found",
+		}
+	}
+
+	descendants, err := s.categoryRepo.GetDescendantIds(ctx, id)
+	if err != nil {
+		log.Println("[categoryService][checkCategoryParent] error get descendants :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	for _, v := range descendants {
+		if v == parentId {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Category cannot be moved below its own sub category",
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *categoryService) recordAudit(ctx context.Context, tx *gorm.DB, action string, categoryId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityCategory, categoryId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// validateCategoryInput trims the input and checks the default depreciation settings.
+// Cost, salvage and units belong to each asset, so they are left out of the check.
+func validateCategoryInput(input *dto.CategoryInputDto) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Code = strings.TrimSpace(input.Code)
+	if input.Name == "" {
+		return errors.New("Category name is required")
+	}
+
+	if input.DepreciationMethod == "" {
+		return nil
+	}
+
+	err := depreciation.Validate(depreciation.Params{
+		Cost:            1,
+		UsefulLifeYears: input.UsefulLifeYears,
+		Method:          input.DepreciationMethod,
+		Convention:      input.DepreciationConvention,
+		DecliningFactor: input.DecliningFactor,
+		TotalUnits:      1,
+	})
+	if err != nil {
+		return errors.New("Invalid depreciation settings: " + err.Error())
+	}
+
+	return nil
+}
+
+func applyCategoryInput(category *models.Category, input *dto.CategoryInputDto) {
+	category.Name = input.Name
+	category.Code = input.Code
+	category.Icon = input.Icon
+	category.DepreciationMethod = input.DepreciationMethod
+	category.DepreciationConvention = input.DepreciationConvention
+	category.UsefulLifeYears = input.UsefulLifeYears
+	category.DecliningFactor = input.DecliningFactor
+	if category.DepreciationMethod == "" {
+		category.DepreciationConvention = ""
+		category.UsefulLifeYears = 0
+		category.DecliningFactor = 0
+	}
+}
+
+// categoryAuditFields returns the audited fields of a category keyed by their json name
+func categoryAuditFields(category *models.Category) map[string]interface{} {
+	return map[string]interface{}{
+		"parent_id":               category.ParentId,
+		"name":                    category.Name,
+		"code":                    category.Code,
+		"icon":                    category.Icon,
+		"depreciation_method":     category.DepreciationMethod,
+		"depreciation_convention": category.DepreciationConvention,
+		"useful_life_years":       category.UsefulLifeYears,
+		"declining_factor":        category.DecliningFactor,
+	}
+}
+
+func categoryOutput(category *models.Category) *dto.CategoryOutputDto {
+	return &dto.CategoryOutputDto{
+		Id:                     category.Id,
+		ParentId:               category.ParentId,
+		Name:                   category.Name,
+		Code:                   category.Code,
+		Icon:                   category.Icon,
+		DepreciationMethod:     category.DepreciationMethod,
+		DepreciationConvention: category.DepreciationConvention,
+		UsefulLifeYears:        category.UsefulLifeYears,
+		DecliningFactor:        category.DecliningFactor,
+		CreatedAt:              category.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:              category.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewCategoryService(mockRepo, mockAuditRepo)
+
+	tests := []struct {
+		name                string
+		input               *dto.CategoryInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name: "Success - Create sub category",
+			input: &dto.CategoryInputDto{
+				Name:               " Laptop ",
+				ParentId:           "computers-id",
+				Code:               "LPT",
+				DepreciationMethod: "straight_line",
+				UsefulLifeYears:    3,
+			},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptop").Return(nil, nil)
+				mockRepo.EXPECT().GetCategoryByCode(gomock.Any(), "LPT").Return(nil, nil)
+				mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "computers-id"}).Return(&models.Category{Id: "computers-id"}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateCategory(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, category *models.Category, tx *gorm.DB) (*models.Category, error) {
+					assert.Equal(t, "Laptop", category.Name)
+					assert.Equal(t, "computers-id", *category.ParentId)
+					return category, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityCategory, auditLog.EntityType)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:                "Error - Empty name",
+			input:               &dto.CategoryInputDto{Name: " "},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Category name is required",
+		},
+		{
+			name:                "Error - Invalid default depreciation",
+			input:               &dto.CategoryInputDto{Name: "Laptop", DepreciationMethod: "straight_line"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid depreciation settings: useful life must be greater than zero",
+		},
+		{
+			name:  "Error - Name already exists",
+			input: &dto.CategoryInputDto{Name: "laptop"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "laptop").Return(&models.Category{Id: "laptop-id"}, nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Category already exist",
+		},
+		{
+			name:  "Error - Code already exists",
+			input: &dto.CategoryInputDto{Name: "Laptop", Code: "lpt"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptop").Return(nil, nil)
+				mockRepo.EXPECT().GetCategoryByCode(gomock.Any(), "lpt").Return(&models.Category{Id: "other-id"}, nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Category code already exist",
+		},
+		{
+			name:  "Error - Parent not found",
+			input: &dto.CategoryInputDto{Name: "Laptop", ParentId: "missing-id"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptop").Return(nil, nil)
+				mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Parent category not found",
+		},
+		{
+			name:  "Error - Failed to create category",
+			input: &dto.CategoryInputDto{Name: "Laptop"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptop").Return(nil, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateCategory(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("create error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CreateCategory(context.Background(), tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewCategoryService(mockRepo, mockAuditRepo)
+
+	t.Run("Success - Rename updates the asset type", func(t *testing.T) {
+		mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "laptop-id"}).Return(&models.Category{Id: "laptop-id", Name: "Laptops"}, nil)
+		mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Laptop").Return(&models.Category{Id: "laptop-id"}, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().UpdateCategory(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, category *models.Category, tx *gorm.DB) (*models.Category, error) {
+			return category, nil
+		})
+		mockRepo.EXPECT().UpdateAssetsType(gomock.Any(), "laptop-id", "Laptop", gomock.Any()).Return(nil)
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+			assert.JSONEq(t, `[{"field":"name","before":"Laptops","after":"Laptop"}]`, auditLog.Changes)
+			return nil
+		})
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.UpdateCategory(context.Background(), "laptop-id", &dto.CategoryInputDto{Name: "Laptop"})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Parent is a descendant", func(t *testing.T) {
+		mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "computers-id"}).Return(&models.Category{Id: "computers-id", Name: "Computers"}, nil)
+		mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Computers").Return(&models.Category{Id: "computers-id"}, nil)
+		mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "laptop-id"}).Return(&models.Category{Id: "laptop-id"}, nil)
+		mockRepo.EXPECT().GetDescendantIds(gomock.Any(), "computers-id").Return([]string{"laptop-id"}, nil)
+
+		code, response := service.UpdateCategory(context.Background(), "computers-id", &dto.CategoryInputDto{Name: "Computers", ParentId: "laptop-id"})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Category cannot be moved below its own sub category", response.ErrorDescription)
+	})
+
+	t.Run("Error - Parent is itself", func(t *testing.T) {
+		mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "computers-id"}).Return(&models.Category{Id: "computers-id", Name: "Computers"}, nil)
+		mockRepo.EXPECT().GetCategoryByName(gomock.Any(), "Computers").Return(&models.Category{Id: "computers-id"}, nil)
+
+		code, response := service.UpdateCategory(context.Background(), "computers-id", &dto.CategoryInputDto{Name: "Computers", ParentId: "computers-id"})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Category cannot be its own parent", response.ErrorDescription)
+	})
+
+	t.Run("Error - Category not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+
+		code, _ := service.UpdateCategory(context.Background(), "missing-id", &dto.CategoryInputDto{Name: "Laptop"})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewCategoryService(mockRepo, mockAuditRepo)
+
+	category := &models.Category{Id: "laptop-id", Name: "Laptop"}
+
+	tests := []struct {
+		name                string
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name: "Success - Delete category",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), gomock.Any()).Return(category, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "laptop-id").Return(int64(0), nil)
+				mockRepo.EXPECT().CountAssets(gomock.Any(), "laptop-id").Return(int64(0), nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteCategory(gomock.Any(), category, gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Error - Has sub categories",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), gomock.Any()).Return(category, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "laptop-id").Return(int64(2), nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Category has sub categories",
+		},
+		{
+			name: "Error - Used by assets",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), gomock.Any()).Return(category, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "laptop-id").Return(int64(0), nil)
+				mockRepo.EXPECT().CountAssets(gomock.Any(), "laptop-id").Return(int64(1), nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Category is used by assets",
+		},
+		{
+			name: "Error - Failed to record audit",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetCategoryByAttribute(gomock.Any(), gomock.Any()).Return(category, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "laptop-id").Return(int64(0), nil)
+				mockRepo.EXPECT().CountAssets(gomock.Any(), "laptop-id").Return(int64(0), nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteCategory(gomock.Any(), category, gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("audit error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.DeleteCategory(context.Background(), "laptop-id")
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestGetCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockCategoryRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewCategoryService(mockRepo, mockAuditRepo)
+
+	computersId := "computers-id"
+	laptopId := "laptop-id"
+	mockRepo.EXPECT().GetAllCategories(gomock.Any()).Return([]*models.Category{
+		{Id: computersId, Name: "Computers"},
+		{Id: "gaming-id", Name: "Gaming", ParentId: &laptopId},
+		{Id: laptopId, Name: "Laptop", ParentId: &computersId},
+		{Id: "vehicles-id", Name: "Vehicles"},
+	}, nil)
+
+	code, response := service.GetCategoryTree(context.Background())
+	assert.Equal(t, http.StatusOK, code)
+
+	roots := response.Data.([]*dto.CategoryOutputDto)
+	assert.Len(t, roots, 2)
+	assert.Equal(t, "Computers", roots[0].Name)
+	assert.Equal(t, "Vehicles", roots[1].Name)
+	assert.Equal(t, "Laptop", roots[0].Children[0].Name)
+	assert.Equal(t, "Gaming", roots[0].Children[0].Children[0].Name)
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// idempotencyKeyMaxLength is the longest Idempotency-Key accepted
+const idempotencyKeyMaxLength = 255
+
+type IdempotencyServiceInterface interface {
+	BeginIdempotentRequest(ctx context.Context, key string, fingerprint string) (stored *dto.IdempotentResponseDto, code int, response *dto.BaseResponse)
+	CompleteIdempotentRequest(ctx context.Context, key string, stored *dto.IdempotentResponseDto) error
+	AbortIdempotentRequest(ctx context.Context, key string) error
+	PurgeExpiredIdempotencyKeys(ctx context.Context) (purged int64, err error)
+}
+
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepositoryInterface
+	// ttl is how long a key and its response are kept
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepositoryInterface, ttl time.Duration) IdempotencyServiceInterface {
+	return &idempotencyService{idempotencyRepo: idempotencyRepo, ttl: ttl, now: time.Now}
+}
+
+// BeginIdempotentRequest claims the Idempotency-Key of the caller for a request. It returns the
+// stored response when a request with the same fingerprint already completed with the key,
+// and an error response when the key was used for another request or its first request is
+// still running. Otherwise the request runs and is finished with CompleteIdempotentRequest
+// or AbortIdempotentRequest.
+func (s *idempotencyService) BeginIdempotentRequest(ctx context.Context, key string, fingerprint string) (stored *dto.IdempotentResponseDto, code int, response *dto.BaseResponse) {
+	if len(key) > idempotencyKeyMaxLength {
+		return nil, http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Idempotency-Key is longer than 255 characters",
+		}
+	}
+
+	now := s.now().UTC()
+	existing, err := s.getIdempotencyKey(ctx, key)
+	if err != nil {
+		log.Println("[idempotencyService][BeginIdempotentRequest] error get idempotency key :", err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	// an expired key the cleanup has not removed yet is free again
+	if existing != nil && !existing.ExpiresAt.After(now) {
+		if err = s.idempotencyRepo.DeleteIdempotencyKey(ctx, existing); err != nil {
+			log.Println("[idempotencyService][BeginIdempotentRequest] error delete expired idempotency key :", err)
+			return nil, http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+		existing = nil
+	}
+
+	if existing == nil {
+		err = s.idempotencyRepo.CreateIdempotencyKey(ctx, &models.IdempotencyKey{
+			Actor:       common.ActorFromContext(ctx),
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   now.Add(s.ttl),
+		})
+		if err == nil {
+			return nil, 0, nil
+		}
+		log.Println("[idempotencyService][BeginIdempotentRequest] error create idempotency key :", err)
+
+		// a concurrent request with the same key won the unique index
+		if existing, _ = s.getIdempotencyKey(ctx, key); existing == nil {
+			return nil, http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, http.StatusUnprocessableEntity, &dto.BaseResponse{
+			Error:            common.UnprocessableEntity,
+			ErrorDescription: "Idempotency-Key was already used for a different request",
+		}
+	}
+
+	if existing.StatusCode == 0 {
+		return nil, http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "A request with this Idempotency-Key is still in progress",
+		}
+	}
+
+	stored = &dto.IdempotentResponseDto{
+		StatusCode: existing.StatusCode,
+		Header:     map[string]string{},
+		Body:       []byte(existing.Body),
+	}
+	if existing.Header != "" {
+		if err = json.Unmarshal([]byte(existing.Header), &stored.Header); err != nil {
+			log.Println("[idempotencyService][BeginIdempotentRequest] error unmarshal header :", err)
+			return nil, http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+	}
+	return stored, 0, nil
+}
+
+// CompleteIdempotentRequest stores the response of the request holding the key, to be replayed
+// to its retries
+func (s *idempotencyService) CompleteIdempotentRequest(ctx context.Context, key string, stored *dto.IdempotentResponseDto) error {
+	idempotencyKey, err := s.getIdempotencyKey(ctx, key)
+	if err != nil || idempotencyKey == nil {
+		return err
+	}
+
+	header, err := json.Marshal(stored.Header)
+	if err != nil {
+		return err
+	}
+	idempotencyKey.StatusCode = stored.StatusCode
+	idempotencyKey.Header = string(header)
+	idempotencyKey.Body = string(stored.Body)
+	return s.idempotencyRepo.SaveIdempotencyResponse(ctx, idempotencyKey)
+}
+
+// AbortIdempotentRequest releases the key of a request that failed without a response worth
+// replaying, so that a retry runs again
+func (s *idempotencyService) AbortIdempotentRequest(ctx context.Context, key string) error {
+	idempotencyKey, err := s.getIdempotencyKey(ctx, key)
+	if err != nil || idempotencyKey == nil {
+		return err
+	}
+
+	return s.idempotencyRepo.DeleteIdempotencyKey(ctx, idempotencyKey)
+}
+
+// PurgeExpiredIdempotencyKeys removes the keys older than the TTL
+func (s *idempotencyService) PurgeExpiredIdempotencyKeys(ctx context.Context) (purged int64, err error) {
+	purged, err = s.idempotencyRepo.DeleteExpiredIdempotencyKeys(ctx, s.now().UTC())
+	if err != nil {
+		log.Println("[idempotencyService][PurgeExpiredIdempotencyKeys] error delete expired idempotency keys :", err)
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// getIdempotencyKey returns a key of the caller, keys are scoped to the actor and the tenant
+func (s *idempotencyService) getIdempotencyKey(ctx context.Context, key string) (*models.IdempotencyKey, error) {
+	return s.idempotencyRepo.GetIdempotencyKeyByAttribute(ctx, map[string]interface{}{
+		"actor": common.ActorFromContext(ctx),
+		"key":   key,
+	})
+}
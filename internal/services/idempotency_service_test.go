@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeginIdempotentRequest(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	completed := &models.IdempotencyKey{
+		Id:          "key-id",
+		Key:         "key-1",
+		Fingerprint: "fingerprint",
+		StatusCode:  http.StatusCreated,
+		Header:      `{"Content-Type":"application/json; charset=utf-8"}`,
+		Body:        `{"data":{"id":"asset-1"}}`,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		fingerprint    string
+		mockSetup      func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface)
+		expectedStored *dto.IdempotentResponseDto
+		expectedCode   int
+		expectedError  string
+	}{
+		{
+			name:        "New key",
+			key:         "key-1",
+			fingerprint: "fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockIdempotencyRepo.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, idempotencyKey *models.IdempotencyKey) error {
+					assert.Equal(t, "key-1", idempotencyKey.Key)
+					assert.Equal(t, "fingerprint", idempotencyKey.Fingerprint)
+					assert.Equal(t, now.Add(24*time.Hour), idempotencyKey.ExpiresAt)
+					return nil
+				})
+			},
+		},
+		{
+			name:        "Replay completed request",
+			key:         "key-1",
+			fingerprint: "fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(completed, nil)
+			},
+			expectedStored: &dto.IdempotentResponseDto{
+				StatusCode: http.StatusCreated,
+				Header:     map[string]string{"Content-Type": "application/json; charset=utf-8"},
+				Body:       []byte(`{"data":{"id":"asset-1"}}`),
+			},
+		},
+		{
+			name:        "Different request with the key",
+			key:         "key-1",
+			fingerprint: "other-fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(completed, nil)
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "Idempotency-Key was already used for a different request",
+		},
+		{
+			name:        "Request still in progress",
+			key:         "key-1",
+			fingerprint: "fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(&models.IdempotencyKey{
+					Key:         "key-1",
+					Fingerprint: "fingerprint",
+					ExpiresAt:   now.Add(time.Hour),
+				}, nil)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "A request with this Idempotency-Key is still in progress",
+		},
+		{
+			name:        "Expired key is reused",
+			key:         "key-1",
+			fingerprint: "other-fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				expired := *completed
+				expired.ExpiresAt = now.Add(-time.Minute)
+				mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(&expired, nil)
+				mockIdempotencyRepo.EXPECT().DeleteIdempotencyKey(gomock.Any(), &expired).Return(nil)
+				mockIdempotencyRepo.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "Concurrent request created the key",
+			key:         "key-1",
+			fingerprint: "fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				gomock.InOrder(
+					mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil),
+					mockIdempotencyRepo.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(errors.New("duplicate key")),
+					mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(&models.IdempotencyKey{
+						Key:         "key-1",
+						Fingerprint: "fingerprint",
+						ExpiresAt:   now.Add(time.Hour),
+					}, nil),
+				)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "A request with this Idempotency-Key is still in progress",
+		},
+		{
+			name:        "Create error",
+			key:         "key-1",
+			fingerprint: "fingerprint",
+			mockSetup: func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {
+				mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+				mockIdempotencyRepo.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Something went wrong",
+		},
+		{
+			name:          "Key too long",
+			key:           strings.Repeat("k", 256),
+			fingerprint:   "fingerprint",
+			mockSetup:     func(mockIdempotencyRepo *repositories.MockIdempotencyRepositoryInterface) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Idempotency-Key is longer than 255 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockIdempotencyRepo := repositories.NewMockIdempotencyRepositoryInterface(ctrl)
+			tt.mockSetup(mockIdempotencyRepo)
+
+			service := &idempotencyService{idempotencyRepo: mockIdempotencyRepo, ttl: 24 * time.Hour, now: func() time.Time { return now }}
+			stored, code, response := service.BeginIdempotentRequest(context.Background(), tt.key, tt.fingerprint)
+
+			assert.Equal(t, tt.expectedStored, stored)
+			assert.Equal(t, tt.expectedCode, code)
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, response.ErrorDescription)
+			} else {
+				assert.Nil(t, response)
+			}
+		})
+	}
+}
+
+func TestCompleteIdempotentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdempotencyRepo := repositories.NewMockIdempotencyRepositoryInterface(ctrl)
+	mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(&models.IdempotencyKey{Id: "key-id", Key: "key-1"}, nil)
+	mockIdempotencyRepo.EXPECT().SaveIdempotencyResponse(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, idempotencyKey *models.IdempotencyKey) error {
+		assert.Equal(t, http.StatusCreated, idempotencyKey.StatusCode)
+		assert.Equal(t, `{"ETag":"\"1\""}`, idempotencyKey.Header)
+		assert.Equal(t, `{"data":{}}`, idempotencyKey.Body)
+		return nil
+	})
+
+	service := NewIdempotencyService(mockIdempotencyRepo, time.Hour)
+	err := service.CompleteIdempotentRequest(context.Background(), "key-1", &dto.IdempotentResponseDto{
+		StatusCode: http.StatusCreated,
+		Header:     map[string]string{"ETag": `"1"`},
+		Body:       []byte(`{"data":{}}`),
+	})
+	assert.NoError(t, err)
+}
+
+func TestAbortIdempotentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idempotencyKey := &models.IdempotencyKey{Id: "key-id", Key: "key-1"}
+	mockIdempotencyRepo := repositories.NewMockIdempotencyRepositoryInterface(ctrl)
+	mockIdempotencyRepo.EXPECT().GetIdempotencyKeyByAttribute(gomock.Any(), gomock.Any()).Return(idempotencyKey, nil)
+	mockIdempotencyRepo.EXPECT().DeleteIdempotencyKey(gomock.Any(), idempotencyKey).Return(nil)
+
+	service := NewIdempotencyService(mockIdempotencyRepo, time.Hour)
+	assert.NoError(t, service.AbortIdempotentRequest(context.Background(), "key-1"))
+}
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockIdempotencyRepo := repositories.NewMockIdempotencyRepositoryInterface(ctrl)
+	mockIdempotencyRepo.EXPECT().DeleteExpiredIdempotencyKeys(gomock.Any(), now).Return(int64(3), nil)
+
+	service := &idempotencyService{idempotencyRepo: mockIdempotencyRepo, ttl: time.Hour, now: func() time.Time { return now }}
+	purged, err := service.PurgeExpiredIdempotencyKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+}
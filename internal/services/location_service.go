@@ -0,0 +1,592 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type LocationServiceInterface interface {
+	CreateLocation(ctx context.Context, input *dto.LocationInputDto) (code int, response *dto.BaseResponse)
+	GetLocationById(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetLocations(ctx context.Context, filter *dto.LocationFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	GetLocationTree(ctx context.Context) (code int, response *dto.BaseResponse)
+	UpdateLocation(ctx context.Context, id string, input *dto.LocationInputDto) (code int, response *dto.BaseResponse)
+	DeleteLocation(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetLocationAssets(ctx context.Context, id string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+}
+
+type locationService struct {
+	locationRepo repositories.LocationRepositoryInterface
+	assetRepo    repositories.AssetRepositoryInterface
+	auditRepo    repositories.AuditRepositoryInterface
+}
+
+func NewLocationService(locationRepo repositories.LocationRepositoryInterface, assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface) LocationServiceInterface {
+	return &locationService{locationRepo: locationRepo, assetRepo: assetRepo, auditRepo: auditRepo}
+}
+
+func (s *locationService) CreateLocation(ctx context.Context, input *dto.LocationInputDto) (code int, response *dto.BaseResponse) {
+	if err := validateLocationInput(input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if code, response = s.checkLocationParent(ctx, input); response != nil {
+		return code, response
+	}
+
+	location := &models.Location{}
+	applyLocationInput(location, input)
+
+	tx := s.locationRepo.StartTransaction(ctx)
+	location, err := s.locationRepo.CreateLocation(ctx, location, tx)
+	if err != nil {
+		log.Println("[locationService][CreateLocation] error create location :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][CreateLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, location.Id, nil, locationAuditFields(location))
+	if err != nil {
+		log.Println("[locationService][CreateLocation] error record audit :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][CreateLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.locationRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[locationService][CreateLocation] error commit transaction :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][CreateLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    locationOutput(location),
+	}
+}
+
+func (s *locationService) GetLocationById(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	location, err := s.locationRepo.GetLocationByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[locationService][GetLocationById] error get location :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if location == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Location not found",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    locationOutput(location),
+	}
+}
+
+func (s *locationService) GetLocations(ctx context.Context, filter *dto.LocationFilterDto, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	locations, count, err := s.locationRepo.GetLocations(ctx, filter, pagination)
+	if err != nil {
+		log.Println("[locationService][GetLocations] error get locations :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	locationsRes := []*dto.LocationOutputDto{}
+	for _, v := range locations {
+		locationsRes = append(locationsRes, locationOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = locationsRes
+	return http.StatusOK, pagination
+}
+
+// GetLocationTree returns every location nested under its parent
+func (s *locationService) GetLocationTree(ctx context.Context) (code int, response *dto.BaseResponse) {
+	locations, err := s.locationRepo.GetAllLocations(ctx)
+	if err != nil {
+		log.Println("[locationService][GetLocationTree] error get locations :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	nodes := map[string]*dto.LocationOutputDto{}
+	for _, v := range locations {
+		nodes[v.Id] = locationOutput(v)
+	}
+
+	roots := []*dto.LocationOutputDto{}
+	for _, v := range locations {
+		node := nodes[v.Id]
+		if v.ParentId != nil {
+			if parent, ok := nodes[*v.ParentId]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    roots,
+	}
+}
+
+func (s *locationService) UpdateLocation(ctx context.Context, id string, input *dto.LocationInputDto) (code int, response *dto.BaseResponse) {
+	location, err := s.locationRepo.GetLocationByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[locationService][UpdateLocation] error get location :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if location == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Location not found",
+		}
+	}
+
+	if err = validateLocationInput(input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if code, response = s.checkLocationParent(ctx, input); response != nil {
+		return code, response
+	}
+
+	// the sub locations are only valid below their current kind
+	if input.Kind != location.Kind {
+		children, err := s.locationRepo.CountChildren(ctx, location.Id)
+		if err != nil {
+			log.Println("[locationService][UpdateLocation] error count children :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+
+		if children > 0 {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Location kind cannot change while it has sub locations",
+			}
+		}
+	}
+
+	before := locationAuditFields(location)
+	applyLocationInput(location, input)
+
+	tx := s.locationRepo.StartTransaction(ctx)
+	location, err = s.locationRepo.UpdateLocation(ctx, location, tx)
+	if err != nil {
+		log.Println("[locationService][UpdateLocation] error update location :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][UpdateLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionUpdate, location.Id, before, locationAuditFields(location))
+	if err != nil {
+		log.Println("[locationService][UpdateLocation] error record audit :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][UpdateLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.locationRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[locationService][UpdateLocation] error commit transaction :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][UpdateLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    locationOutput(location),
+	}
+}
+
+// DeleteLocation removes a location that has no sub locations and no assets, trashed
+// assets included
+func (s *locationService) DeleteLocation(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	location, err := s.locationRepo.GetLocationByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[locationService][DeleteLocation] error get location :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if location == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Location not found",
+		}
+	}
+
+	children, err := s.locationRepo.CountChildren(ctx, location.Id)
+	if err != nil {
+		log.Println("[locationService][DeleteLocation] error count children :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if children > 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Location has sub locations",
+		}
+	}
+
+	assets, err := s.locationRepo.CountAssets(ctx, location.Id)
+	if err != nil {
+		log.Println("[locationService][DeleteLocation] error count assets :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if assets > 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Location has assets",
+		}
+	}
+
+	tx := s.locationRepo.StartTransaction(ctx)
+	err = s.locationRepo.DeleteLocation(ctx, location, tx)
+	if err != nil {
+		log.Println("[locationService][DeleteLocation] error delete location :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][DeleteLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionDelete, location.Id, locationAuditFields(location), nil)
+	if err != nil {
+		log.Println("[locationService][DeleteLocation] error record audit :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][DeleteLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.locationRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[locationService][DeleteLocation] error commit transaction :", err)
+		err = s.locationRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[locationService][DeleteLocation] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// GetLocationAssets lists the assets of the location and every location below it, with
+// the count and total value of the whole subtree
+func (s *locationService) GetLocationAssets(ctx context.Context, id string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	location, err := s.locationRepo.GetLocationByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[locationService][GetLocationAssets] error get location :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	if location == nil {
+		return http.StatusNotFound, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.NotFound,
+				ErrorDescription: "Location not found",
+			},
+		}
+	}
+
+	descendants, err := s.locationRepo.GetDescendantIds(ctx, location.Id)
+	if err != nil {
+		log.Println("[locationService][GetLocationAssets] error get descendants :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	filter := &dto.AssetFilterDto{LocationIds: append([]string{location.Id}, descendants...)}
+	assets, count, err := s.assetRepo.GetAssets(ctx, filter, pagination)
+	if err != nil {
+		log.Println("[locationService][GetLocationAssets] error get assets :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	totalValue, err := s.assetRepo.SumAssetValue(ctx, filter)
+	if err != nil {
+		log.Println("[locationService][GetLocationAssets] error sum asset value :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	assetsRes := []*dto.AssetOutputDto{}
+	for _, v := range assets {
+		assetsRes = append(assetsRes, &dto.AssetOutputDto{
+			Id:              v.Id,
+			Name:            v.Name,
+			Type:            v.Type,
+			CategoryId:      v.CategoryId,
+			LocationId:      v.LocationId,
+			Value:           v.Value,
+			AcquisitionDate: v.AcquisitionDate.Format("2006-01-02"),
+			CreatedAt:       v.CreatedAt.Format("2006-01-02"),
+			UpdatedAt:       v.UpdatedAt.Format("2006-01-02"),
+			Version:         v.Version,
+		})
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = &dto.LocationAssetsDto{
+		Location:   locationOutput(location),
+		AssetCount: count,
+		TotalValue: totalValue,
+		Assets:     assetsRes,
+	}
+	return http.StatusOK, pagination
+}
+
+// checkLocationParent enforces the site, building, floor, room order: a site has no parent
+// and every other kind sits directly below the previous kind. As kinds only go down the
+// tree, a location can never end up below itself.
+func (s *locationService) checkLocationParent(ctx context.Context, input *dto.LocationInputDto) (code int, response *dto.BaseResponse) {
+	level := locationLevel(input.Kind)
+	if level == 0 {
+		if input.ParentId != "" {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "A site cannot have a parent location",
+			}
+		}
+		return 0, nil
+	}
+
+	parentKind := common.LocationKinds[level-1]
+	if input.ParentId == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "A " + input.Kind + " must be placed in a " + parentKind,
+		}
+	}
+
+	parent, err := s.locationRepo.GetLocationByAttribute(ctx, map[string]interface{}{
+		"id": input.ParentId,
+	})
+	if err != nil {
+		log.Println("[locationService][checkLocationParent] error get parent location :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if parent == nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Parent location not found",
+		}
+	}
+
+	if parent.Kind != parentKind {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "A " + input.Kind + " must be placed in a " + parentKind,
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *locationService) recordAudit(ctx context.Context, tx *gorm.DB, action string, locationId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityLocation, locationId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// locationLevel returns the depth of the kind in the hierarchy, or -1 for an unknown kind
+func locationLevel(kind string) int {
+	for i, v := range common.LocationKinds {
+		if v == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+func validateLocationInput(input *dto.LocationInputDto) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Kind = strings.ToLower(strings.TrimSpace(input.Kind))
+	if input.Name == "" {
+		return errors.New("Location name is required")
+	}
+
+	if locationLevel(input.Kind) < 0 {
+		return errors.New("Invalid location kind, allowed kinds are " + strings.Join(common.LocationKinds, ", "))
+	}
+
+	if (input.Latitude == nil) != (input.Longitude == nil) {
+		return errors.New("latitude and longitude must be set together")
+	}
+	if input.Latitude != nil && (*input.Latitude < -90 || *input.Latitude > 90) {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if input.Longitude != nil && (*input.Longitude < -180 || *input.Longitude > 180) {
+		return errors.New("longitude must be between -180 and 180")
+	}
+
+	return nil
+}
+
+func applyLocationInput(location *models.Location, input *dto.LocationInputDto) {
+	location.Name = input.Name
+	location.Kind = input.Kind
+	location.ParentId = nil
+	if input.ParentId != "" {
+		location.ParentId = &input.ParentId
+	}
+	location.Latitude = input.Latitude
+	location.Longitude = input.Longitude
+}
+
+// locationAuditFields returns the audited fields of a location keyed by their json name
+func locationAuditFields(location *models.Location) map[string]interface{} {
+	return map[string]interface{}{
+		"parent_id": location.ParentId,
+		"name":      location.Name,
+		"kind":      location.Kind,
+		"latitude":  location.Latitude,
+		"longitude": location.Longitude,
+	}
+}
+
+func locationOutput(location *models.Location) *dto.LocationOutputDto {
+	return &dto.LocationOutputDto{
+		Id:        location.Id,
+		ParentId:  location.ParentId,
+		Name:      location.Name,
+		Kind:      location.Kind,
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		CreatedAt: location.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: location.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
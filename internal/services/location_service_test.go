@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewLocationService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	lat, long := -6.2, 106.8
+	badLat := 91.0
+
+	tests := []struct {
+		name                string
+		input               *dto.LocationInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:  "Success - Create site",
+			input: &dto.LocationInputDto{Name: "Jakarta Office", Kind: "Site", Latitude: &lat, Longitude: &long},
+			mockSetup: func() {
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateLocation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error) {
+					assert.Equal(t, common.LocationKindSite, location.Kind)
+					assert.Nil(t, location.ParentId)
+					return location, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityLocation, auditLog.EntityType)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Success - Create building in site",
+			input: &dto.LocationInputDto{Name: "Tower A", Kind: "building", ParentId: "site-id"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "site-id"}).Return(&models.Location{Id: "site-id", Kind: common.LocationKindSite}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateLocation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error) {
+					return location, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:                "Error - Unknown kind",
+			input:               &dto.LocationInputDto{Name: "Desk 1", Kind: "desk"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid location kind, allowed kinds are site, building, floor, room",
+		},
+		{
+			name:                "Error - Latitude out of range",
+			input:               &dto.LocationInputDto{Name: "Jakarta Office", Kind: "site", Latitude: &badLat, Longitude: &long},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "latitude must be between -90 and 90",
+		},
+		{
+			name:                "Error - Latitude without longitude",
+			input:               &dto.LocationInputDto{Name: "Jakarta Office", Kind: "site", Latitude: &lat},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "latitude and longitude must be set together",
+		},
+		{
+			name:                "Error - Site with parent",
+			input:               &dto.LocationInputDto{Name: "Jakarta Office", Kind: "site", ParentId: "site-id"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "A site cannot have a parent location",
+		},
+		{
+			name:                "Error - Room without parent",
+			input:               &dto.LocationInputDto{Name: "Room 101", Kind: "room"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "A room must be placed in a floor",
+		},
+		{
+			name:  "Error - Room in a building",
+			input: &dto.LocationInputDto{Name: "Room 101", Kind: "room", ParentId: "building-id"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "building-id"}).Return(&models.Location{Id: "building-id", Kind: common.LocationKindBuilding}, nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "A room must be placed in a floor",
+		},
+		{
+			name:  "Error - Parent not found",
+			input: &dto.LocationInputDto{Name: "Tower A", Kind: "building", ParentId: "missing-id"},
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Parent location not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CreateLocation(context.Background(), tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewLocationService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	t.Run("Error - Kind change with sub locations", func(t *testing.T) {
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "floor-id"}).Return(&models.Location{Id: "floor-id", Kind: common.LocationKindFloor}, nil)
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "site-id"}).Return(&models.Location{Id: "site-id", Kind: common.LocationKindSite}, nil)
+		mockRepo.EXPECT().CountChildren(gomock.Any(), "floor-id").Return(int64(3), nil)
+
+		code, response := service.UpdateLocation(context.Background(), "floor-id", &dto.LocationInputDto{Name: "Tower B", Kind: "building", ParentId: "site-id"})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Location kind cannot change while it has sub locations", response.ErrorDescription)
+	})
+
+	t.Run("Success - Move room to another floor", func(t *testing.T) {
+		oldFloor := "floor-1"
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "room-id"}).Return(&models.Location{Id: "room-id", Name: "Room 101", Kind: common.LocationKindRoom, ParentId: &oldFloor}, nil)
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "floor-2"}).Return(&models.Location{Id: "floor-2", Kind: common.LocationKindFloor}, nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().UpdateLocation(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, location *models.Location, tx *gorm.DB) (*models.Location, error) {
+			return location, nil
+		})
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+			assert.JSONEq(t, `[{"field":"parent_id","before":"floor-1","after":"floor-2"}]`, auditLog.Changes)
+			return nil
+		})
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.UpdateLocation(context.Background(), "room-id", &dto.LocationInputDto{Name: "Room 101", Kind: "room", ParentId: "floor-2"})
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
+
+func TestDeleteLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewLocationService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	location := &models.Location{Id: "room-id", Name: "Room 101", Kind: common.LocationKindRoom}
+
+	tests := []struct {
+		name                string
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name: "Success - Delete location",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), gomock.Any()).Return(location, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "room-id").Return(int64(0), nil)
+				mockRepo.EXPECT().CountAssets(gomock.Any(), "room-id").Return(int64(0), nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteLocation(gomock.Any(), location, gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Error - Location not found",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedDescription: "Location not found",
+		},
+		{
+			name: "Error - Has assets",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), gomock.Any()).Return(location, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "room-id").Return(int64(0), nil)
+				mockRepo.EXPECT().CountAssets(gomock.Any(), "room-id").Return(int64(4), nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Location has assets",
+		},
+		{
+			name: "Error - Failed to delete location",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), gomock.Any()).Return(location, nil)
+				mockRepo.EXPECT().CountChildren(gomock.Any(), "room-id").Return(int64(0), nil)
+				mockRepo.EXPECT().CountAssets(gomock.Any(), "room-id").Return(int64(0), nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().DeleteLocation(gomock.Any(), location, gomock.Any()).Return(errors.New("delete error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.DeleteLocation(context.Background(), "room-id")
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestGetLocationAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockLocationRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewLocationService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	testTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	site := &models.Location{Id: "site-id", Name: "Jakarta Office", Kind: common.LocationKindSite}
+
+	t.Run("Success - Rolls up the subtree", func(t *testing.T) {
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), map[string]interface{}{"id": "site-id"}).Return(site, nil)
+		mockRepo.EXPECT().GetDescendantIds(gomock.Any(), "site-id").Return([]string{"building-id", "floor-id"}, nil)
+		mockAssetRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+			assert.Equal(t, []string{"site-id", "building-id", "floor-id"}, filter.LocationIds)
+			return []*models.Asset{
+				{Id: "asset-1", Name: "Laptop", Value: 1500, AcquisitionDate: testTime, CreatedAt: testTime, UpdatedAt: testTime},
+			}, 3, nil
+		})
+		mockAssetRepo.EXPECT().SumAssetValue(gomock.Any(), gomock.Any()).Return(4200.5, nil)
+
+		code, response := service.GetLocationAssets(context.Background(), "site-id", &dto.MetaPagination{Page: 1, Limit: 1})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(3), response.TotalPage)
+
+		data := response.Data.(*dto.LocationAssetsDto)
+		assert.Equal(t, "Jakarta Office", data.Location.Name)
+		assert.Equal(t, int64(3), data.AssetCount)
+		assert.Equal(t, 4200.5, data.TotalValue)
+		assert.Len(t, data.Assets, 1)
+	})
+
+	t.Run("Error - Location not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		code, _ := service.GetLocationAssets(context.Background(), "missing-id", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("Error - Sum value error", func(t *testing.T) {
+		mockRepo.EXPECT().GetLocationByAttribute(gomock.Any(), gomock.Any()).Return(site, nil)
+		mockRepo.EXPECT().GetDescendantIds(gomock.Any(), "site-id").Return(nil, nil)
+		mockAssetRepo.EXPECT().GetAssets(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*models.Asset{}, int64(0), nil)
+		mockAssetRepo.EXPECT().SumAssetValue(gomock.Any(), gomock.Any()).Return(float64(0), errors.New("sum error"))
+
+		code, _ := service.GetLocationAssets(context.Background(), "site-id", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
@@ -0,0 +1,593 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MaintenanceServiceInterface interface {
+	CreateMaintenanceRecord(ctx context.Context, assetId string, input *dto.MaintenanceRecordInputDto) (code int, response *dto.BaseResponse)
+	GetMaintenanceRecords(ctx context.Context, assetId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	CreateMaintenancePlan(ctx context.Context, assetId string, input *dto.MaintenancePlanInputDto) (code int, response *dto.BaseResponse)
+	GetMaintenancePlans(ctx context.Context, assetId string) (code int, response *dto.BaseResponse)
+	DeleteMaintenancePlan(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetDueMaintenance(ctx context.Context, within string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+}
+
+var errInvalidWithin = errors.New("Invalid within, use a number of days or weeks such as 30d or 4w")
+
+type maintenanceService struct {
+	maintenanceRepo repositories.MaintenanceRepositoryInterface
+	assetRepo       repositories.AssetRepositoryInterface
+	auditRepo       repositories.AuditRepositoryInterface
+}
+
+func NewMaintenanceService(maintenanceRepo repositories.MaintenanceRepositoryInterface, assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface) MaintenanceServiceInterface {
+	return &maintenanceService{maintenanceRepo: maintenanceRepo, assetRepo: assetRepo, auditRepo: auditRepo}
+}
+
+// CreateMaintenanceRecord records work done on an asset. Work done for a plan moves the plan
+// to its next due date or units.
+func (s *maintenanceService) CreateMaintenanceRecord(ctx context.Context, assetId string, input *dto.MaintenanceRecordInputDto) (code int, response *dto.BaseResponse) {
+	performedAt, err := time.Parse("2006-01-02", input.PerformedAt)
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenanceRecord] error parsing date :", err)
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid performed date format",
+		}
+	}
+
+	if performedAt.After(today()) {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Performed date must not be in the future",
+		}
+	}
+
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Description == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Description is required",
+		}
+	}
+
+	if input.Cost < 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Cost must not be negative",
+		}
+	}
+
+	if input.DowntimeHours < 0 {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Downtime must not be negative",
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": assetId,
+	})
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenanceRecord] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	var plan *models.MaintenancePlan
+	if input.PlanId != "" {
+		plan, err = s.maintenanceRepo.GetMaintenancePlanByAttribute(ctx, map[string]interface{}{
+			"id":       input.PlanId,
+			"asset_id": asset.Id,
+		})
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenanceRecord] error get maintenance plan :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+
+		if plan == nil {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "Maintenance plan not found",
+			}
+		}
+	}
+
+	record := &models.MaintenanceRecord{
+		AssetId:       asset.Id,
+		PerformedAt:   performedAt,
+		Vendor:        strings.TrimSpace(input.Vendor),
+		Cost:          input.Cost,
+		Description:   input.Description,
+		DowntimeHours: input.DowntimeHours,
+		CreatedBy:     common.ActorFromContext(ctx),
+	}
+	if plan != nil {
+		record.PlanId = &plan.Id
+	}
+
+	tx := s.maintenanceRepo.StartTransaction(ctx)
+	record, err = s.maintenanceRepo.CreateMaintenanceRecord(ctx, record, tx)
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenanceRecord] error create maintenance record :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenanceRecord] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if plan != nil && advanceMaintenancePlan(plan, performedAt, asset.UnitsUsed) {
+		_, err = s.maintenanceRepo.UpdateMaintenancePlan(ctx, plan, tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenanceRecord] error update maintenance plan :", err)
+			err = s.maintenanceRepo.RollbackTransaction(tx)
+			if err != nil {
+				log.Println("[maintenanceService][CreateMaintenanceRecord] error rollback transaction :", err)
+			}
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditEntityAsset, asset.Id, common.AuditActionMaintenance, nil, map[string]interface{}{
+		"performed_at": input.PerformedAt,
+		"description":  record.Description,
+		"cost":         record.Cost,
+		"plan_id":      record.PlanId,
+	})
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenanceRecord] error record audit :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenanceRecord] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.maintenanceRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenanceRecord] error commit transaction :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenanceRecord] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    maintenanceRecordOutput(record),
+	}
+}
+
+// GetMaintenanceRecords returns the maintenance work of an asset, latest first
+func (s *maintenanceService) GetMaintenanceRecords(ctx context.Context, assetId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	records, count, err := s.maintenanceRepo.GetMaintenanceRecords(ctx, assetId, pagination)
+	if err != nil {
+		log.Println("[maintenanceService][GetMaintenanceRecords] error get maintenance records :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	recordsRes := []*dto.MaintenanceRecordOutputDto{}
+	for _, v := range records {
+		recordsRes = append(recordsRes, maintenanceRecordOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = recordsRes
+	return http.StatusOK, pagination
+}
+
+func (s *maintenanceService) CreateMaintenancePlan(ctx context.Context, assetId string, input *dto.MaintenancePlanInputDto) (code int, response *dto.BaseResponse) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Name is required",
+		}
+	}
+
+	input.Kind = strings.ToLower(strings.TrimSpace(input.Kind))
+	var nextDueDate time.Time
+	switch input.Kind {
+	case common.MaintenancePlanKindCalendar:
+		if input.IntervalDays <= 0 {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "interval_days must be greater than zero for calendar plans",
+			}
+		}
+
+		nextDueDate = today().AddDate(0, 0, input.IntervalDays)
+		if input.NextDueDate != "" {
+			var err error
+			nextDueDate, err = time.Parse("2006-01-02", input.NextDueDate)
+			if err != nil {
+				log.Println("[maintenanceService][CreateMaintenancePlan] error parsing date :", err)
+				return http.StatusBadRequest, &dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid next due date format",
+				}
+			}
+		}
+	case common.MaintenancePlanKindUsage:
+		if input.IntervalUnits <= 0 {
+			return http.StatusBadRequest, &dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: "interval_units must be greater than zero for usage plans",
+			}
+		}
+	default:
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Invalid plan kind, allowed kinds are " + strings.Join(common.MaintenancePlanKinds, ", "),
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": assetId,
+	})
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenancePlan] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	plan := &models.MaintenancePlan{
+		AssetId: asset.Id,
+		Name:    input.Name,
+		Kind:    input.Kind,
+	}
+	if plan.Kind == common.MaintenancePlanKindCalendar {
+		plan.IntervalDays = input.IntervalDays
+		plan.NextDueDate = &nextDueDate
+	} else {
+		plan.IntervalUnits = input.IntervalUnits
+		plan.LastPerformedUnits = asset.UnitsUsed
+		plan.NextDueUnits = asset.UnitsUsed + input.IntervalUnits
+	}
+
+	tx := s.maintenanceRepo.StartTransaction(ctx)
+	plan, err = s.maintenanceRepo.CreateMaintenancePlan(ctx, plan, tx)
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenancePlan] error create maintenance plan :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenancePlan] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditEntityMaintenancePlan, plan.Id, common.AuditActionCreate, nil, maintenancePlanAuditFields(plan))
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenancePlan] error record audit :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenancePlan] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.maintenanceRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[maintenanceService][CreateMaintenancePlan] error commit transaction :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][CreateMaintenancePlan] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    maintenancePlanOutput(plan),
+	}
+}
+
+func (s *maintenanceService) GetMaintenancePlans(ctx context.Context, assetId string) (code int, response *dto.BaseResponse) {
+	plans, err := s.maintenanceRepo.GetMaintenancePlans(ctx, assetId)
+	if err != nil {
+		log.Println("[maintenanceService][GetMaintenancePlans] error get maintenance plans :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	plansRes := []*dto.MaintenancePlanOutputDto{}
+	for _, v := range plans {
+		plansRes = append(plansRes, maintenancePlanOutput(v))
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    plansRes,
+	}
+}
+
+func (s *maintenanceService) DeleteMaintenancePlan(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	plan, err := s.maintenanceRepo.GetMaintenancePlanByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[maintenanceService][DeleteMaintenancePlan] error get maintenance plan :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if plan == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Maintenance plan not found",
+		}
+	}
+
+	tx := s.maintenanceRepo.StartTransaction(ctx)
+	err = s.maintenanceRepo.DeleteMaintenancePlan(ctx, plan, tx)
+	if err != nil {
+		log.Println("[maintenanceService][DeleteMaintenancePlan] error delete maintenance plan :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][DeleteMaintenancePlan] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditEntityMaintenancePlan, plan.Id, common.AuditActionDelete, maintenancePlanAuditFields(plan), nil)
+	if err != nil {
+		log.Println("[maintenanceService][DeleteMaintenancePlan] error record audit :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][DeleteMaintenancePlan] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.maintenanceRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[maintenanceService][DeleteMaintenancePlan] error commit transaction :", err)
+		err = s.maintenanceRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[maintenanceService][DeleteMaintenancePlan] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// GetDueMaintenance lists the plans across all assets that are overdue or due within the
+// window, such as 30d. Usage plans are listed once the asset reached the due units.
+func (s *maintenanceService) GetDueMaintenance(ctx context.Context, within string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	days, err := parseWithin(within)
+	if err != nil {
+		return http.StatusBadRequest, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.BadRequest,
+				ErrorDescription: err.Error(),
+			},
+		}
+	}
+
+	now := today()
+	dues, count, err := s.maintenanceRepo.GetDueMaintenance(ctx, now.AddDate(0, 0, days), pagination)
+	if err != nil {
+		log.Println("[maintenanceService][GetDueMaintenance] error get due maintenance :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	duesRes := []*dto.MaintenanceDueDto{}
+	for _, v := range dues {
+		duesRes = append(duesRes, maintenanceDueOutput(v, now))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = duesRes
+	return http.StatusOK, pagination
+}
+
+func (s *maintenanceService) recordAudit(ctx context.Context, tx *gorm.DB, entityType string, entityId string, action string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, entityType, entityId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// advanceMaintenancePlan moves a plan past work done on performedAt. Work older than the last
+// performed work of the plan does not move it, it reports whether the plan changed.
+func advanceMaintenancePlan(plan *models.MaintenancePlan, performedAt time.Time, unitsUsed float64) bool {
+	if plan.LastPerformedAt != nil && performedAt.Before(*plan.LastPerformedAt) {
+		return false
+	}
+
+	plan.LastPerformedAt = &performedAt
+	if plan.Kind == common.MaintenancePlanKindCalendar {
+		nextDueDate := performedAt.AddDate(0, 0, plan.IntervalDays)
+		plan.NextDueDate = &nextDueDate
+	} else {
+		plan.LastPerformedUnits = unitsUsed
+		plan.NextDueUnits = unitsUsed + plan.IntervalUnits
+	}
+	return true
+}
+
+// parseWithin reads a window such as 30d, 4w or 30 (days) into days, empty means 30 days
+func parseWithin(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 30, nil
+	}
+
+	multiplier := 1
+	if strings.HasSuffix(value, "w") {
+		multiplier = 7
+		value = strings.TrimSuffix(value, "w")
+	} else {
+		value = strings.TrimSuffix(value, "d")
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, errInvalidWithin
+	}
+	return days * multiplier, nil
+}
+
+func maintenancePlanAuditFields(plan *models.MaintenancePlan) map[string]interface{} {
+	fields := map[string]interface{}{
+		"asset_id": plan.AssetId,
+		"name":     plan.Name,
+		"kind":     plan.Kind,
+	}
+	if plan.Kind == common.MaintenancePlanKindCalendar {
+		fields["interval_days"] = plan.IntervalDays
+	} else {
+		fields["interval_units"] = plan.IntervalUnits
+	}
+	return fields
+}
+
+func maintenanceRecordOutput(record *models.MaintenanceRecord) *dto.MaintenanceRecordOutputDto {
+	return &dto.MaintenanceRecordOutputDto{
+		Id:            record.Id,
+		AssetId:       record.AssetId,
+		PlanId:        record.PlanId,
+		PerformedAt:   record.PerformedAt.Format("2006-01-02"),
+		Vendor:        record.Vendor,
+		Cost:          record.Cost,
+		Description:   record.Description,
+		DowntimeHours: record.DowntimeHours,
+		CreatedBy:     record.CreatedBy,
+		CreatedAt:     record.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func maintenancePlanOutput(plan *models.MaintenancePlan) *dto.MaintenancePlanOutputDto {
+	res := &dto.MaintenancePlanOutputDto{
+		Id:                 plan.Id,
+		AssetId:            plan.AssetId,
+		Name:               plan.Name,
+		Kind:               plan.Kind,
+		IntervalDays:       plan.IntervalDays,
+		IntervalUnits:      plan.IntervalUnits,
+		NextDueUnits:       plan.NextDueUnits,
+		LastPerformedUnits: plan.LastPerformedUnits,
+		CreatedAt:          plan.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:          plan.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if plan.NextDueDate != nil {
+		res.NextDueDate = plan.NextDueDate.Format("2006-01-02")
+	}
+	if plan.LastPerformedAt != nil {
+		res.LastPerformedAt = plan.LastPerformedAt.Format("2006-01-02")
+	}
+	return res
+}
+
+func maintenanceDueOutput(due *models.MaintenanceDue, now time.Time) *dto.MaintenanceDueDto {
+	res := &dto.MaintenanceDueDto{
+		PlanId:    due.Id,
+		PlanName:  due.Name,
+		Kind:      due.Kind,
+		AssetId:   due.AssetId,
+		AssetName: due.AssetName,
+	}
+	if due.Kind == common.MaintenancePlanKindCalendar && due.NextDueDate != nil {
+		daysUntilDue := int(due.NextDueDate.Sub(now).Hours() / 24)
+		res.NextDueDate = due.NextDueDate.Format("2006-01-02")
+		res.DaysUntilDue = &daysUntilDue
+		res.Overdue = daysUntilDue < 0
+	} else {
+		remainingUnits := due.NextDueUnits - due.AssetUnitsUsed
+		res.NextDueUnits = due.NextDueUnits
+		res.UnitsUsed = &due.AssetUnitsUsed
+		res.RemainingUnits = &remainingUnits
+		res.Overdue = remainingUnits < 0
+	}
+	return res
+}
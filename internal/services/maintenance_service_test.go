@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateMaintenanceRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockMaintenanceRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewMaintenanceService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	asset := &models.Asset{Id: "asset-id", Name: "Printer", UnitsUsed: 1200}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	lastPerformed := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		input               *dto.MaintenanceRecordInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:  "Success - Record without plan",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15", Vendor: "Acme", Cost: 150, Description: "Replaced toner", DowntimeHours: 1.5},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenanceRecord(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) (*models.MaintenanceRecord, error) {
+					assert.Equal(t, "asset-id", record.AssetId)
+					assert.Nil(t, record.PlanId)
+					assert.Equal(t, 1.5, record.DowntimeHours)
+					return record, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityAsset, auditLog.EntityType)
+					assert.Equal(t, common.AuditActionMaintenance, auditLog.Action)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Success - Calendar plan moves to next due date",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-04-01", Description: "Quarterly service", PlanId: "plan-id"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetMaintenancePlanByAttribute(gomock.Any(), map[string]interface{}{"id": "plan-id", "asset_id": "asset-id"}).Return(&models.MaintenancePlan{
+					Id: "plan-id", AssetId: "asset-id", Kind: common.MaintenancePlanKindCalendar, IntervalDays: 90, LastPerformedAt: &lastPerformed,
+				}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenanceRecord(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) (*models.MaintenanceRecord, error) {
+					assert.Equal(t, "plan-id", *record.PlanId)
+					return record, nil
+				})
+				mockRepo.EXPECT().UpdateMaintenancePlan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error) {
+					assert.Equal(t, "2024-06-30", plan.NextDueDate.Format("2006-01-02"))
+					assert.Equal(t, "2024-04-01", plan.LastPerformedAt.Format("2006-01-02"))
+					return plan, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Success - Usage plan moves to next due units",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-04-01", Description: "Drum replaced", PlanId: "plan-id"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetMaintenancePlanByAttribute(gomock.Any(), gomock.Any()).Return(&models.MaintenancePlan{
+					Id: "plan-id", AssetId: "asset-id", Kind: common.MaintenancePlanKindUsage, IntervalUnits: 500, NextDueUnits: 1000,
+				}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenanceRecord(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) (*models.MaintenanceRecord, error) {
+					return record, nil
+				})
+				mockRepo.EXPECT().UpdateMaintenancePlan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error) {
+					assert.Equal(t, 1200.0, plan.LastPerformedUnits)
+					assert.Equal(t, 1700.0, plan.NextDueUnits)
+					return plan, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Success - Backfilled work keeps the plan",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-01", Description: "Old service", PlanId: "plan-id"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetMaintenancePlanByAttribute(gomock.Any(), gomock.Any()).Return(&models.MaintenancePlan{
+					Id: "plan-id", AssetId: "asset-id", Kind: common.MaintenancePlanKindCalendar, IntervalDays: 90, LastPerformedAt: &lastPerformed,
+				}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenanceRecord(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record *models.MaintenanceRecord, tx *gorm.DB) (*models.MaintenanceRecord, error) {
+					return record, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:                "Error - Invalid date",
+			input:               &dto.MaintenanceRecordInputDto{PerformedAt: "15-01-2024", Description: "Replaced toner"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid performed date format",
+		},
+		{
+			name:                "Error - Future date",
+			input:               &dto.MaintenanceRecordInputDto{PerformedAt: tomorrow, Description: "Replaced toner"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Performed date must not be in the future",
+		},
+		{
+			name:                "Error - Missing description",
+			input:               &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Description is required",
+		},
+		{
+			name:                "Error - Negative cost",
+			input:               &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15", Description: "Replaced toner", Cost: -1},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Cost must not be negative",
+		},
+		{
+			name:                "Error - Negative downtime",
+			input:               &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15", Description: "Replaced toner", DowntimeHours: -2},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Downtime must not be negative",
+		},
+		{
+			name:  "Error - Asset not found",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15", Description: "Replaced toner"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedDescription: "Asset not found",
+		},
+		{
+			name:  "Error - Plan of another asset",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15", Description: "Replaced toner", PlanId: "other-plan"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().GetMaintenancePlanByAttribute(gomock.Any(), map[string]interface{}{"id": "other-plan", "asset_id": "asset-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Maintenance plan not found",
+		},
+		{
+			name:  "Error - Create record",
+			input: &dto.MaintenanceRecordInputDto{PerformedAt: "2024-01-15", Description: "Replaced toner"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenanceRecord(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				mockRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:        http.StatusInternalServerError,
+			expectedDescription: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CreateMaintenanceRecord(context.Background(), "asset-id", tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestCreateMaintenancePlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockMaintenanceRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewMaintenanceService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	asset := &models.Asset{Id: "asset-id", Name: "Van", UnitsUsed: 42000}
+
+	tests := []struct {
+		name                string
+		input               *dto.MaintenancePlanInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:  "Success - Calendar plan defaults to one interval from today",
+			input: &dto.MaintenancePlanInputDto{Name: "Oil change", Kind: "Calendar", IntervalDays: 90},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenancePlan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error) {
+					assert.Equal(t, common.MaintenancePlanKindCalendar, plan.Kind)
+					assert.Equal(t, today().AddDate(0, 0, 90), *plan.NextDueDate)
+					return plan, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityMaintenancePlan, auditLog.EntityType)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Success - Usage plan counts from current units",
+			input: &dto.MaintenancePlanInputDto{Name: "Tyre rotation", Kind: "usage", IntervalUnits: 10000},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(asset, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateMaintenancePlan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plan *models.MaintenancePlan, tx *gorm.DB) (*models.MaintenancePlan, error) {
+					assert.Nil(t, plan.NextDueDate)
+					assert.Equal(t, 52000.0, plan.NextDueUnits)
+					return plan, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:                "Error - Unknown kind",
+			input:               &dto.MaintenancePlanInputDto{Name: "Oil change", Kind: "weekly"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid plan kind, allowed kinds are calendar, usage",
+		},
+		{
+			name:                "Error - Calendar plan without interval",
+			input:               &dto.MaintenancePlanInputDto{Name: "Oil change", Kind: "calendar"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "interval_days must be greater than zero for calendar plans",
+		},
+		{
+			name:                "Error - Usage plan without interval",
+			input:               &dto.MaintenancePlanInputDto{Name: "Tyre rotation", Kind: "usage", IntervalDays: 30},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "interval_units must be greater than zero for usage plans",
+		},
+		{
+			name:                "Error - Invalid next due date",
+			input:               &dto.MaintenancePlanInputDto{Name: "Oil change", Kind: "calendar", IntervalDays: 90, NextDueDate: "soon"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid next due date format",
+		},
+		{
+			name:                "Error - Missing name",
+			input:               &dto.MaintenancePlanInputDto{Kind: "calendar", IntervalDays: 90},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CreateMaintenancePlan(context.Background(), "asset-id", tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestGetDueMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockMaintenanceRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewMaintenanceService(mockRepo, mockAssetRepo, mockAuditRepo)
+
+	overdueDate := today().AddDate(0, 0, -5)
+	upcomingDate := today().AddDate(0, 0, 10)
+
+	t.Run("Success - Upcoming and overdue plans", func(t *testing.T) {
+		mockRepo.EXPECT().GetDueMaintenance(gomock.Any(), today().AddDate(0, 0, 14), gomock.Any()).Return([]*models.MaintenanceDue{
+			{MaintenancePlan: models.MaintenancePlan{Id: "usage-plan", Kind: common.MaintenancePlanKindUsage, NextDueUnits: 1000}, AssetName: "Printer", AssetUnitsUsed: 1200},
+			{MaintenancePlan: models.MaintenancePlan{Id: "overdue-plan", Kind: common.MaintenancePlanKindCalendar, NextDueDate: &overdueDate}, AssetName: "Van"},
+			{MaintenancePlan: models.MaintenancePlan{Id: "upcoming-plan", Kind: common.MaintenancePlanKindCalendar, NextDueDate: &upcomingDate}, AssetName: "Server"},
+		}, int64(3), nil)
+
+		code, response := service.GetDueMaintenance(context.Background(), "2w", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(3), response.Total)
+
+		data := response.Data.([]*dto.MaintenanceDueDto)
+		assert.True(t, data[0].Overdue)
+		assert.Equal(t, -200.0, *data[0].RemainingUnits)
+		assert.True(t, data[1].Overdue)
+		assert.Equal(t, -5, *data[1].DaysUntilDue)
+		assert.False(t, data[2].Overdue)
+		assert.Equal(t, 10, *data[2].DaysUntilDue)
+	})
+
+	t.Run("Success - Defaults to 30 days", func(t *testing.T) {
+		mockRepo.EXPECT().GetDueMaintenance(gomock.Any(), today().AddDate(0, 0, 30), gomock.Any()).Return([]*models.MaintenanceDue{}, int64(0), nil)
+
+		code, _ := service.GetDueMaintenance(context.Background(), "", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Invalid window", func(t *testing.T) {
+		code, response := service.GetDueMaintenance(context.Background(), "next month", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Invalid within, use a number of days or weeks such as 30d or 4w", response.ErrorDescription)
+	})
+
+	t.Run("Error - Repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetDueMaintenance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("database error"))
+
+		code, _ := service.GetDueMaintenance(context.Background(), "30d", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
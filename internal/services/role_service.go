@@ -0,0 +1,271 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type RoleServiceInterface interface {
+	GetRoles(ctx context.Context) (code int, response *dto.BaseResponse)
+	GetRoleAssignments(ctx context.Context, subject string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	AssignRole(ctx context.Context, input *dto.RoleAssignmentInputDto) (code int, response *dto.BaseResponse)
+	RevokeRole(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	ResolveRoles(ctx context.Context, subject string) ([]string, error)
+}
+
+type roleService struct {
+	roleRepo  repositories.RoleRepositoryInterface
+	auditRepo repositories.AuditRepositoryInterface
+	// adminSubjects always have the admin role, so the first assignments can be made
+	adminSubjects []string
+	// defaultRole is given to subjects without an assignment, none when empty
+	defaultRole string
+}
+
+func NewRoleService(roleRepo repositories.RoleRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, adminSubjects []string, defaultRole string) RoleServiceInterface {
+	return &roleService{roleRepo: roleRepo, auditRepo: auditRepo, adminSubjects: adminSubjects, defaultRole: defaultRole}
+}
+
+func (s *roleService) GetRoles(ctx context.Context) (code int, response *dto.BaseResponse) {
+	rolesRes := []*dto.RoleOutputDto{}
+	for _, v := range common.Roles {
+		rolesRes = append(rolesRes, &dto.RoleOutputDto{
+			Name:        v,
+			Permissions: common.RolePermissions[v],
+		})
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    rolesRes,
+	}
+}
+
+func (s *roleService) GetRoleAssignments(ctx context.Context, subject string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	assignments, count, err := s.roleRepo.GetRoleAssignments(ctx, subject, pagination)
+	if err != nil {
+		log.Println("[roleService][GetRoleAssignments] error get role assignments :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	assignmentsRes := []*dto.RoleAssignmentOutputDto{}
+	for _, v := range assignments {
+		assignmentsRes = append(assignmentsRes, roleAssignmentOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = assignmentsRes
+	return http.StatusOK, pagination
+}
+
+func (s *roleService) AssignRole(ctx context.Context, input *dto.RoleAssignmentInputDto) (code int, response *dto.BaseResponse) {
+	input.Subject = strings.TrimSpace(input.Subject)
+	if input.Subject == "" {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: "Subject is required",
+		}
+	}
+	if _, ok := common.RolePermissions[input.Role]; !ok {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: fmt.Sprintf("Invalid role, must be one of %s", strings.Join(common.Roles, ", ")),
+		}
+	}
+
+	existing, err := s.roleRepo.GetRoleAssignmentByAttribute(ctx, map[string]interface{}{
+		"subject": input.Subject,
+		"role":    input.Role,
+	})
+	if err != nil {
+		log.Println("[roleService][AssignRole] error get existing role assignment :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if existing != nil {
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Role already assigned",
+		}
+	}
+
+	assignment := &models.RoleAssignment{
+		Subject:    input.Subject,
+		Role:       input.Role,
+		AssignedBy: common.ActorFromContext(ctx),
+	}
+
+	tx := s.roleRepo.StartTransaction(ctx)
+	assignment, err = s.roleRepo.CreateRoleAssignment(ctx, assignment, tx)
+	if err != nil {
+		log.Println("[roleService][AssignRole] error create role assignment :", err)
+		err = s.roleRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[roleService][AssignRole] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, assignment.Id, nil, roleAssignmentAuditFields(assignment))
+	if err != nil {
+		log.Println("[roleService][AssignRole] error record audit :", err)
+		err = s.roleRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[roleService][AssignRole] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.roleRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[roleService][AssignRole] error commit transaction :", err)
+		err = s.roleRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[roleService][AssignRole] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    roleAssignmentOutput(assignment),
+	}
+}
+
+func (s *roleService) RevokeRole(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	assignment, err := s.roleRepo.GetRoleAssignmentByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[roleService][RevokeRole] error get role assignment :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if assignment == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Role assignment not found",
+		}
+	}
+
+	tx := s.roleRepo.StartTransaction(ctx)
+	err = s.roleRepo.DeleteRoleAssignment(ctx, assignment, tx)
+	if err != nil {
+		log.Println("[roleService][RevokeRole] error delete role assignment :", err)
+		err = s.roleRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[roleService][RevokeRole] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionDelete, assignment.Id, roleAssignmentAuditFields(assignment), nil)
+	if err != nil {
+		log.Println("[roleService][RevokeRole] error record audit :", err)
+		err = s.roleRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[roleService][RevokeRole] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.roleRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[roleService][RevokeRole] error commit transaction :", err)
+		err = s.roleRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[roleService][RevokeRole] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// ResolveRoles returns the roles of a subject: its assignments, admin for the configured
+// admin subjects and the default role when it has none
+func (s *roleService) ResolveRoles(ctx context.Context, subject string) ([]string, error) {
+	roles, err := s.roleRepo.GetSubjectRoles(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range s.adminSubjects {
+		if v == subject {
+			roles = append(roles, common.RoleAdmin)
+			break
+		}
+	}
+	if len(roles) == 0 && s.defaultRole != "" {
+		roles = []string{s.defaultRole}
+	}
+	return roles, nil
+}
+
+func (s *roleService) recordAudit(ctx context.Context, tx *gorm.DB, action string, assignmentId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityRoleAssignment, assignmentId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+func roleAssignmentAuditFields(assignment *models.RoleAssignment) map[string]interface{} {
+	return map[string]interface{}{
+		"subject": assignment.Subject,
+		"role":    assignment.Role,
+	}
+}
+
+func roleAssignmentOutput(assignment *models.RoleAssignment) *dto.RoleAssignmentOutputDto {
+	return &dto.RoleAssignmentOutputDto{
+		Id:         assignment.Id,
+		Subject:    assignment.Subject,
+		Role:       assignment.Role,
+		AssignedBy: assignment.AssignedBy,
+		CreatedAt:  assignment.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAssignRole(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *dto.RoleAssignmentInputDto
+		mockSetup     func(mockRoleRepo *repositories.MockRoleRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Success",
+			input: &dto.RoleAssignmentInputDto{Subject: " user-1 ", Role: common.RoleEditor},
+			mockSetup: func(mockRoleRepo *repositories.MockRoleRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockRoleRepo.EXPECT().GetRoleAssignmentByAttribute(gomock.Any(), map[string]interface{}{"subject": "user-1", "role": common.RoleEditor}).Return(nil, nil)
+				mockRoleRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRoleRepo.EXPECT().CreateRoleAssignment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, assignment *models.RoleAssignment, tx *gorm.DB) (*models.RoleAssignment, error) {
+					assert.Equal(t, "admin-1", assignment.AssignedBy)
+					assignment.Id = "assignment-id"
+					return assignment, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityRoleAssignment, auditLog.EntityType)
+					assert.Equal(t, "assignment-id", auditLog.EntityId)
+					return nil
+				})
+				mockRoleRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Unknown role",
+			input: &dto.RoleAssignmentInputDto{Subject: "user-1", Role: "owner"},
+			mockSetup: func(mockRoleRepo *repositories.MockRoleRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid role, must be one of viewer, editor, finance, admin",
+		},
+		{
+			name:  "Blank subject",
+			input: &dto.RoleAssignmentInputDto{Subject: "  ", Role: common.RoleViewer},
+			mockSetup: func(mockRoleRepo *repositories.MockRoleRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Subject is required",
+		},
+		{
+			name:  "Already assigned",
+			input: &dto.RoleAssignmentInputDto{Subject: "user-1", Role: common.RoleViewer},
+			mockSetup: func(mockRoleRepo *repositories.MockRoleRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockRoleRepo.EXPECT().GetRoleAssignmentByAttribute(gomock.Any(), gomock.Any()).Return(&models.RoleAssignment{Id: "assignment-id"}, nil)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "Role already assigned",
+		},
+		{
+			name:  "Error create role assignment",
+			input: &dto.RoleAssignmentInputDto{Subject: "user-1", Role: common.RoleViewer},
+			mockSetup: func(mockRoleRepo *repositories.MockRoleRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockRoleRepo.EXPECT().GetRoleAssignmentByAttribute(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockRoleRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRoleRepo.EXPECT().CreateRoleAssignment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				mockRoleRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoleRepo := repositories.NewMockRoleRepositoryInterface(ctrl)
+			mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+			tt.mockSetup(mockRoleRepo, mockAuditRepo)
+			service := NewRoleService(mockRoleRepo, mockAuditRepo, nil, "")
+
+			code, response := service.AssignRole(common.WithActor(context.Background(), "admin-1"), tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedError, response.ErrorDescription)
+			if tt.expectedCode == http.StatusCreated {
+				assert.Equal(t, &dto.RoleAssignmentOutputDto{
+					Id:         "assignment-id",
+					Subject:    "user-1",
+					Role:       common.RoleEditor,
+					AssignedBy: "admin-1",
+					CreatedAt:  "0001-01-01 00:00:00",
+				}, response.Data)
+			}
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoleRepo := repositories.NewMockRoleRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	service := NewRoleService(mockRoleRepo, mockAuditRepo, nil, "")
+
+	mockRoleRepo.EXPECT().GetRoleAssignmentByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+	code, response := service.RevokeRole(context.Background(), "missing-id")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "Role assignment not found", response.ErrorDescription)
+
+	assignment := &models.RoleAssignment{Id: "assignment-id", Subject: "user-1", Role: common.RoleFinance}
+	mockRoleRepo.EXPECT().GetRoleAssignmentByAttribute(gomock.Any(), map[string]interface{}{"id": "assignment-id"}).Return(assignment, nil)
+	mockRoleRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+	mockRoleRepo.EXPECT().DeleteRoleAssignment(gomock.Any(), assignment, gomock.Any()).Return(nil)
+	mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+		assert.Equal(t, common.AuditActionDelete, auditLog.Action)
+		return nil
+	})
+	mockRoleRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+	code, _ = service.RevokeRole(context.Background(), "assignment-id")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestResolveRoles(t *testing.T) {
+	tests := []struct {
+		name          string
+		subject       string
+		assigned      []string
+		defaultRole   string
+		expectedRoles []string
+	}{
+		{name: "Assigned roles", subject: "user-1", assigned: []string{common.RoleEditor, common.RoleFinance}, defaultRole: common.RoleViewer, expectedRoles: []string{common.RoleEditor, common.RoleFinance}},
+		{name: "Default role without an assignment", subject: "user-1", defaultRole: common.RoleViewer, expectedRoles: []string{common.RoleViewer}},
+		{name: "No default role", subject: "user-1", expectedRoles: nil},
+		{name: "Configured admin", subject: "root", defaultRole: common.RoleViewer, expectedRoles: []string{common.RoleAdmin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRoleRepo := repositories.NewMockRoleRepositoryInterface(ctrl)
+			mockRoleRepo.EXPECT().GetSubjectRoles(gomock.Any(), tt.subject).Return(tt.assigned, nil)
+			service := NewRoleService(mockRoleRepo, repositories.NewMockAuditRepositoryInterface(ctrl), []string{"root"}, tt.defaultRole)
+
+			roles, err := service.ResolveRoles(context.Background(), tt.subject)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRoles, roles)
+		})
+	}
+}
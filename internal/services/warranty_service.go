@@ -0,0 +1,490 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/events"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WarrantyServiceInterface interface {
+	CreateWarranty(ctx context.Context, assetId string, input *dto.WarrantyInputDto) (code int, response *dto.BaseResponse)
+	GetWarranties(ctx context.Context, assetId string) (code int, response *dto.BaseResponse)
+	GetWarrantyById(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	UpdateWarranty(ctx context.Context, id string, input *dto.WarrantyInputDto) (code int, response *dto.BaseResponse)
+	DeleteWarranty(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetExpiringWarranties(ctx context.Context, before string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	NotifyExpiringWarranties(ctx context.Context, noticeDays int) (notified int, err error)
+}
+
+// warrantyDefaultWindowDays is how far ahead the expiring listing looks without a before date
+const warrantyDefaultWindowDays = 90
+
+type warrantyService struct {
+	warrantyRepo repositories.WarrantyRepositoryInterface
+	assetRepo    repositories.AssetRepositoryInterface
+	auditRepo    repositories.AuditRepositoryInterface
+	sink         events.Sink
+}
+
+func NewWarrantyService(warrantyRepo repositories.WarrantyRepositoryInterface, assetRepo repositories.AssetRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, sink events.Sink) WarrantyServiceInterface {
+	return &warrantyService{warrantyRepo: warrantyRepo, assetRepo: assetRepo, auditRepo: auditRepo, sink: sink}
+}
+
+func (s *warrantyService) CreateWarranty(ctx context.Context, assetId string, input *dto.WarrantyInputDto) (code int, response *dto.BaseResponse) {
+	startDate, endDate, err := validateWarrantyInput(input)
+	if err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	asset, err := s.assetRepo.GetAssetByAttribute(ctx, map[string]interface{}{
+		"id": assetId,
+	})
+	if err != nil {
+		log.Println("[warrantyService][CreateWarranty] error get existing asset :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if asset == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Asset not found",
+		}
+	}
+
+	warranty := &models.Warranty{
+		AssetId:        asset.Id,
+		Kind:           input.Kind,
+		Provider:       input.Provider,
+		CoverageType:   input.CoverageType,
+		ContractNumber: input.ContractNumber,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Cost:           input.Cost,
+	}
+
+	tx := s.warrantyRepo.StartTransaction(ctx)
+	warranty, err = s.warrantyRepo.CreateWarranty(ctx, warranty, tx)
+	if err != nil {
+		log.Println("[warrantyService][CreateWarranty] error create warranty :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][CreateWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, warranty.Id, nil, warrantyAuditFields(warranty))
+	if err != nil {
+		log.Println("[warrantyService][CreateWarranty] error record audit :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][CreateWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.warrantyRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[warrantyService][CreateWarranty] error commit transaction :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][CreateWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    warrantyOutput(warranty, ""),
+	}
+}
+
+func (s *warrantyService) GetWarranties(ctx context.Context, assetId string) (code int, response *dto.BaseResponse) {
+	warranties, err := s.warrantyRepo.GetWarranties(ctx, assetId)
+	if err != nil {
+		log.Println("[warrantyService][GetWarranties] error get warranties :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	warrantiesRes := []*dto.WarrantyOutputDto{}
+	for _, v := range warranties {
+		warrantiesRes = append(warrantiesRes, warrantyOutput(v, ""))
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    warrantiesRes,
+	}
+}
+
+func (s *warrantyService) GetWarrantyById(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	warranty, err := s.warrantyRepo.GetWarrantyByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[warrantyService][GetWarrantyById] error get warranty :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if warranty == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Warranty not found",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    warrantyOutput(warranty, ""),
+	}
+}
+
+// UpdateWarranty changes a warranty. A new end date, such as a renewal, re-arms the expiry event.
+func (s *warrantyService) UpdateWarranty(ctx context.Context, id string, input *dto.WarrantyInputDto) (code int, response *dto.BaseResponse) {
+	warranty, err := s.warrantyRepo.GetWarrantyByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[warrantyService][UpdateWarranty] error get warranty :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if warranty == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Warranty not found",
+		}
+	}
+
+	startDate, endDate, err := validateWarrantyInput(input)
+	if err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	before := warrantyAuditFields(warranty)
+	if !endDate.Equal(warranty.EndDate) {
+		warranty.ExpiryNotifiedAt = nil
+	}
+	warranty.Kind = input.Kind
+	warranty.Provider = input.Provider
+	warranty.CoverageType = input.CoverageType
+	warranty.ContractNumber = input.ContractNumber
+	warranty.StartDate = startDate
+	warranty.EndDate = endDate
+	warranty.Cost = input.Cost
+
+	tx := s.warrantyRepo.StartTransaction(ctx)
+	warranty, err = s.warrantyRepo.UpdateWarranty(ctx, warranty, tx)
+	if err != nil {
+		log.Println("[warrantyService][UpdateWarranty] error update warranty :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][UpdateWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionUpdate, warranty.Id, before, warrantyAuditFields(warranty))
+	if err != nil {
+		log.Println("[warrantyService][UpdateWarranty] error record audit :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][UpdateWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.warrantyRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[warrantyService][UpdateWarranty] error commit transaction :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][UpdateWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    warrantyOutput(warranty, ""),
+	}
+}
+
+func (s *warrantyService) DeleteWarranty(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	warranty, err := s.warrantyRepo.GetWarrantyByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Println("[warrantyService][DeleteWarranty] error get warranty :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if warranty == nil {
+		return http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Warranty not found",
+		}
+	}
+
+	tx := s.warrantyRepo.StartTransaction(ctx)
+	err = s.warrantyRepo.DeleteWarranty(ctx, warranty, tx)
+	if err != nil {
+		log.Println("[warrantyService][DeleteWarranty] error delete warranty :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][DeleteWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionDelete, warranty.Id, warrantyAuditFields(warranty), nil)
+	if err != nil {
+		log.Println("[warrantyService][DeleteWarranty] error record audit :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][DeleteWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.warrantyRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[warrantyService][DeleteWarranty] error commit transaction :", err)
+		err = s.warrantyRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[warrantyService][DeleteWarranty] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// GetExpiringWarranties lists the warranties ending from today up to before, by default the
+// next 90 days
+func (s *warrantyService) GetExpiringWarranties(ctx context.Context, before string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	now := today()
+	beforeDate := now.AddDate(0, 0, warrantyDefaultWindowDays)
+	if before != "" {
+		var err error
+		beforeDate, err = time.Parse("2006-01-02", before)
+		if err != nil {
+			log.Println("[warrantyService][GetExpiringWarranties] error parsing date :", err)
+			return http.StatusBadRequest, &dto.MetaPagination{
+				BaseResponse: dto.BaseResponse{
+					Error:            common.BadRequest,
+					ErrorDescription: "Invalid before date format",
+				},
+			}
+		}
+	}
+
+	warranties, count, err := s.warrantyRepo.GetExpiringWarranties(ctx, now, beforeDate, pagination)
+	if err != nil {
+		log.Println("[warrantyService][GetExpiringWarranties] error get expiring warranties :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	warrantiesRes := []*dto.WarrantyOutputDto{}
+	for _, v := range warranties {
+		warrantiesRes = append(warrantiesRes, warrantyOutput(&v.Warranty, v.AssetName))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = warrantiesRes
+	return http.StatusOK, pagination
+}
+
+// NotifyExpiringWarranties emits a warranty.expiring event for every warranty ending within
+// noticeDays that was not notified yet. Warranties whose event could not be delivered are
+// tried again on the next run.
+func (s *warrantyService) NotifyExpiringWarranties(ctx context.Context, noticeDays int) (notified int, err error) {
+	now := today()
+	warranties, err := s.warrantyRepo.GetUnnotifiedExpiringWarranties(ctx, now, now.AddDate(0, 0, noticeDays))
+	if err != nil {
+		log.Println("[warrantyService][NotifyExpiringWarranties] error get expiring warranties :", err)
+		return 0, err
+	}
+
+	for _, v := range warranties {
+		output := warrantyOutput(&v.Warranty, v.AssetName)
+		err = s.sink.Emit(ctx, &events.Event{
+			Type:       common.EventWarrantyExpiring,
+			OccurredAt: time.Now().UTC(),
+			Data: &dto.WarrantyExpiringEventDto{
+				TenantId:       v.TenantId,
+				WarrantyId:     output.Id,
+				AssetId:        output.AssetId,
+				AssetName:      output.AssetName,
+				Kind:           output.Kind,
+				Provider:       output.Provider,
+				CoverageType:   output.CoverageType,
+				ContractNumber: output.ContractNumber,
+				EndDate:        output.EndDate,
+				DaysLeft:       output.DaysLeft,
+			},
+		})
+		if err != nil {
+			log.Println("[warrantyService][NotifyExpiringWarranties] error emit event :", err)
+			continue
+		}
+
+		err = s.warrantyRepo.MarkWarrantyNotified(ctx, v.Id, time.Now().UTC())
+		if err != nil {
+			log.Println("[warrantyService][NotifyExpiringWarranties] error mark warranty notified :", err)
+			continue
+		}
+		notified++
+	}
+
+	return notified, nil
+}
+
+func (s *warrantyService) recordAudit(ctx context.Context, tx *gorm.DB, action string, warrantyId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityWarranty, warrantyId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// validateWarrantyInput normalizes the input and returns its parsed start and end dates
+func validateWarrantyInput(input *dto.WarrantyInputDto) (startDate time.Time, endDate time.Time, err error) {
+	input.Kind = strings.ToLower(strings.TrimSpace(input.Kind))
+	valid := false
+	for _, v := range common.WarrantyKinds {
+		if v == input.Kind {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return startDate, endDate, errors.New("Invalid kind, allowed kinds are " + strings.Join(common.WarrantyKinds, ", "))
+	}
+
+	input.Provider = strings.TrimSpace(input.Provider)
+	if input.Provider == "" {
+		return startDate, endDate, errors.New("Provider is required")
+	}
+	input.CoverageType = strings.TrimSpace(input.CoverageType)
+	input.ContractNumber = strings.TrimSpace(input.ContractNumber)
+
+	if input.Cost < 0 {
+		return startDate, endDate, errors.New("Cost must not be negative")
+	}
+
+	startDate, err = time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return startDate, endDate, errors.New("Invalid start date format")
+	}
+
+	endDate, err = time.Parse("2006-01-02", input.EndDate)
+	if err != nil {
+		return startDate, endDate, errors.New("Invalid end date format")
+	}
+
+	if endDate.Before(startDate) {
+		return startDate, endDate, errors.New("End date must not be before start date")
+	}
+
+	return startDate, endDate, nil
+}
+
+func warrantyAuditFields(warranty *models.Warranty) map[string]interface{} {
+	return map[string]interface{}{
+		"asset_id":        warranty.AssetId,
+		"kind":            warranty.Kind,
+		"provider":        warranty.Provider,
+		"coverage_type":   warranty.CoverageType,
+		"contract_number": warranty.ContractNumber,
+		"start_date":      warranty.StartDate.Format("2006-01-02"),
+		"end_date":        warranty.EndDate.Format("2006-01-02"),
+		"cost":            warranty.Cost,
+	}
+}
+
+func warrantyOutput(warranty *models.Warranty, assetName string) *dto.WarrantyOutputDto {
+	return &dto.WarrantyOutputDto{
+		Id:             warranty.Id,
+		AssetId:        warranty.AssetId,
+		AssetName:      assetName,
+		Kind:           warranty.Kind,
+		Provider:       warranty.Provider,
+		CoverageType:   warranty.CoverageType,
+		ContractNumber: warranty.ContractNumber,
+		StartDate:      warranty.StartDate.Format("2006-01-02"),
+		EndDate:        warranty.EndDate.Format("2006-01-02"),
+		Cost:           warranty.Cost,
+		DaysLeft:       int(warranty.EndDate.Sub(today()).Hours() / 24),
+		CreatedAt:      warranty.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:      warranty.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/events"
+	"assets-api-go/internal/models"
+	mockEvents "assets-api-go/mocks/events"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateWarranty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWarrantyRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockSink := mockEvents.NewMockSink(ctrl)
+	service := NewWarrantyService(mockRepo, mockAssetRepo, mockAuditRepo, mockSink)
+
+	tests := []struct {
+		name                string
+		input               *dto.WarrantyInputDto
+		mockSetup           func()
+		expectedCode        int
+		expectedDescription string
+	}{
+		{
+			name:  "Success - Create warranty",
+			input: &dto.WarrantyInputDto{Kind: " Warranty ", Provider: "Dell", CoverageType: "on-site", ContractNumber: "W-1", StartDate: "2024-01-01", EndDate: "2027-01-01", Cost: 120},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(&models.Asset{Id: "asset-id"}, nil)
+				mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockRepo.EXPECT().CreateWarranty(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error) {
+					assert.Equal(t, common.WarrantyKindWarranty, warranty.Kind)
+					assert.Equal(t, "asset-id", warranty.AssetId)
+					return warranty, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityWarranty, auditLog.EntityType)
+					return nil
+				})
+				mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:                "Error - Unknown kind",
+			input:               &dto.WarrantyInputDto{Kind: "insurance", Provider: "Dell", StartDate: "2024-01-01", EndDate: "2027-01-01"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid kind, allowed kinds are warranty, contract",
+		},
+		{
+			name:                "Error - Missing provider",
+			input:               &dto.WarrantyInputDto{Kind: "contract", StartDate: "2024-01-01", EndDate: "2027-01-01"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Provider is required",
+		},
+		{
+			name:                "Error - Negative cost",
+			input:               &dto.WarrantyInputDto{Kind: "contract", Provider: "Acme", StartDate: "2024-01-01", EndDate: "2027-01-01", Cost: -5},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Cost must not be negative",
+		},
+		{
+			name:                "Error - Invalid end date",
+			input:               &dto.WarrantyInputDto{Kind: "contract", Provider: "Acme", StartDate: "2024-01-01", EndDate: "01-01-2027"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "Invalid end date format",
+		},
+		{
+			name:                "Error - End before start",
+			input:               &dto.WarrantyInputDto{Kind: "contract", Provider: "Acme", StartDate: "2024-01-01", EndDate: "2023-12-31"},
+			mockSetup:           func() {},
+			expectedCode:        http.StatusBadRequest,
+			expectedDescription: "End date must not be before start date",
+		},
+		{
+			name:  "Error - Asset not found",
+			input: &dto.WarrantyInputDto{Kind: "contract", Provider: "Acme", StartDate: "2024-01-01", EndDate: "2027-01-01"},
+			mockSetup: func() {
+				mockAssetRepo.EXPECT().GetAssetByAttribute(gomock.Any(), map[string]interface{}{"id": "asset-id"}).Return(nil, nil)
+			},
+			expectedCode:        http.StatusNotFound,
+			expectedDescription: "Asset not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			code, response := service.CreateWarranty(context.Background(), "asset-id", tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedDescription, response.ErrorDescription)
+		})
+	}
+}
+
+func TestUpdateWarranty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWarrantyRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockSink := mockEvents.NewMockSink(ctrl)
+	service := NewWarrantyService(mockRepo, mockAssetRepo, mockAuditRepo, mockSink)
+
+	notifiedAt := time.Now().UTC()
+	existing := func() *models.Warranty {
+		return &models.Warranty{
+			Id: "warranty-id", AssetId: "asset-id", Kind: common.WarrantyKindWarranty, Provider: "Dell",
+			StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpiryNotifiedAt: &notifiedAt,
+		}
+	}
+
+	t.Run("Success - Renewal re-arms the expiry event", func(t *testing.T) {
+		mockRepo.EXPECT().GetWarrantyByAttribute(gomock.Any(), map[string]interface{}{"id": "warranty-id"}).Return(existing(), nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().UpdateWarranty(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error) {
+			assert.Nil(t, warranty.ExpiryNotifiedAt)
+			assert.Equal(t, "2028-01-01", warranty.EndDate.Format("2006-01-02"))
+			return warranty, nil
+		})
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.UpdateWarranty(context.Background(), "warranty-id", &dto.WarrantyInputDto{Kind: "warranty", Provider: "Dell", StartDate: "2024-01-01", EndDate: "2028-01-01"})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Success - Same end date keeps the notification", func(t *testing.T) {
+		mockRepo.EXPECT().GetWarrantyByAttribute(gomock.Any(), map[string]interface{}{"id": "warranty-id"}).Return(existing(), nil)
+		mockRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+		mockRepo.EXPECT().UpdateWarranty(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, warranty *models.Warranty, tx *gorm.DB) (*models.Warranty, error) {
+			assert.NotNil(t, warranty.ExpiryNotifiedAt)
+			assert.Equal(t, "Dell EMC", warranty.Provider)
+			return warranty, nil
+		})
+		mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+		code, _ := service.UpdateWarranty(context.Background(), "warranty-id", &dto.WarrantyInputDto{Kind: "warranty", Provider: "Dell EMC", StartDate: "2024-01-01", EndDate: "2027-01-01"})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Warranty not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetWarrantyByAttribute(gomock.Any(), map[string]interface{}{"id": "missing-id"}).Return(nil, nil)
+
+		code, response := service.UpdateWarranty(context.Background(), "missing-id", &dto.WarrantyInputDto{Kind: "warranty", Provider: "Dell", StartDate: "2024-01-01", EndDate: "2028-01-01"})
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "Warranty not found", response.ErrorDescription)
+	})
+}
+
+func TestGetExpiringWarranties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWarrantyRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockSink := mockEvents.NewMockSink(ctrl)
+	service := NewWarrantyService(mockRepo, mockAssetRepo, mockAuditRepo, mockSink)
+
+	t.Run("Success - Until before date", func(t *testing.T) {
+		before := time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC)
+		mockRepo.EXPECT().GetExpiringWarranties(gomock.Any(), today(), before, gomock.Any()).Return([]*models.WarrantyExpiry{
+			{Warranty: models.Warranty{Id: "warranty-id", AssetId: "asset-id", EndDate: today().AddDate(0, 0, 12)}, AssetName: "Laptop"},
+		}, int64(1), nil)
+
+		code, response := service.GetExpiringWarranties(context.Background(), "2027-03-31", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusOK, code)
+		data := response.Data.([]*dto.WarrantyOutputDto)
+		assert.Equal(t, "Laptop", data[0].AssetName)
+		assert.Equal(t, 12, data[0].DaysLeft)
+	})
+
+	t.Run("Success - Defaults to 90 days", func(t *testing.T) {
+		mockRepo.EXPECT().GetExpiringWarranties(gomock.Any(), today(), today().AddDate(0, 0, 90), gomock.Any()).Return([]*models.WarrantyExpiry{}, int64(0), nil)
+
+		code, _ := service.GetExpiringWarranties(context.Background(), "", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Error - Invalid before date", func(t *testing.T) {
+		code, response := service.GetExpiringWarranties(context.Background(), "next-quarter", &dto.MetaPagination{Page: 1, Limit: 10})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Invalid before date format", response.ErrorDescription)
+	})
+}
+
+func TestNotifyExpiringWarranties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repositories.NewMockWarrantyRepositoryInterface(ctrl)
+	mockAssetRepo := repositories.NewMockAssetRepositoryInterface(ctrl)
+	mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+	mockSink := mockEvents.NewMockSink(ctrl)
+	service := NewWarrantyService(mockRepo, mockAssetRepo, mockAuditRepo, mockSink)
+
+	t.Run("Success - Failed deliveries are not marked", func(t *testing.T) {
+		mockRepo.EXPECT().GetUnnotifiedExpiringWarranties(gomock.Any(), today(), today().AddDate(0, 0, 30)).Return([]*models.WarrantyExpiry{
+			{Warranty: models.Warranty{Id: "delivered-id", AssetId: "asset-id", Provider: "Dell", EndDate: today().AddDate(0, 0, 5)}, AssetName: "Laptop"},
+			{Warranty: models.Warranty{Id: "failed-id", AssetId: "asset-id", Provider: "Acme", EndDate: today().AddDate(0, 0, 20)}, AssetName: "Laptop"},
+		}, nil)
+		gomock.InOrder(
+			mockSink.EXPECT().Emit(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, event *events.Event) error {
+				assert.Equal(t, common.EventWarrantyExpiring, event.Type)
+				data := event.Data.(*dto.WarrantyExpiringEventDto)
+				assert.Equal(t, "delivered-id", data.WarrantyId)
+				assert.Equal(t, 5, data.DaysLeft)
+				return nil
+			}),
+			mockSink.EXPECT().Emit(gomock.Any(), gomock.Any()).Return(errors.New("webhook responded with status 503")),
+		)
+		mockRepo.EXPECT().MarkWarrantyNotified(gomock.Any(), "delivered-id", gomock.Any()).Return(nil)
+
+		notified, err := service.NotifyExpiringWarranties(context.Background(), 30)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, notified)
+	})
+
+	t.Run("Error - Repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetUnnotifiedExpiringWarranties(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+		notified, err := service.NotifyExpiringWarranties(context.Background(), 30)
+		assert.Error(t, err)
+		assert.Equal(t, 0, notified)
+	})
+}
@@ -0,0 +1,732 @@
+package services
+
+import (
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/events"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookServiceInterface interface {
+	CreateWebhook(ctx context.Context, input *dto.WebhookInputDto) (code int, response *dto.BaseResponse)
+	GetWebhooks(ctx context.Context, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	GetWebhookById(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	UpdateWebhook(ctx context.Context, id string, input *dto.WebhookInputDto) (code int, response *dto.BaseResponse)
+	DeleteWebhook(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	GetWebhookDeliveries(ctx context.Context, webhookId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination)
+	GetWebhookDeliveryById(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	RedeliverWebhookDelivery(ctx context.Context, id string) (code int, response *dto.BaseResponse)
+	DispatchOutboxEvents(ctx context.Context) (dispatched int, err error)
+	DeliverDueWebhooks(ctx context.Context) (attempted int, err error)
+}
+
+const (
+	// webhookSecretPrefix starts every generated secret
+	webhookSecretPrefix = "whsec_"
+	// webhookSecretMinLength is the shortest secret a webhook can be given
+	webhookSecretMinLength = 16
+	// webhookBatchSize is the number of events or deliveries handled per run
+	webhookBatchSize = 100
+	// webhookDeliveryLease is how long a claimed delivery is kept from other workers, longer
+	// than a delivery request can take
+	webhookDeliveryLease = time.Minute
+	// webhookMaxRetryDelay caps the exponential backoff between attempts
+	webhookMaxRetryDelay = time.Hour
+)
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepositoryInterface
+	outboxRepo  repositories.OutboxRepositoryInterface
+	auditRepo   repositories.AuditRepositoryInterface
+	deliverer   events.Deliverer
+	// maxAttempts is the number of attempts before a delivery is given up
+	maxAttempts int
+	// retryBase is the delay after the first failed attempt, doubled after each further one
+	retryBase time.Duration
+	now       func() time.Time
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepositoryInterface, outboxRepo repositories.OutboxRepositoryInterface, auditRepo repositories.AuditRepositoryInterface, deliverer events.Deliverer, maxAttempts int, retryBase time.Duration) WebhookServiceInterface {
+	return &webhookService{webhookRepo: webhookRepo, outboxRepo: outboxRepo, auditRepo: auditRepo, deliverer: deliverer, maxAttempts: maxAttempts, retryBase: retryBase, now: time.Now}
+}
+
+// CreateWebhook subscribes a URL to asset events and returns its secret, which cannot be read
+// again afterwards
+func (s *webhookService) CreateWebhook(ctx context.Context, input *dto.WebhookInputDto) (code int, response *dto.BaseResponse) {
+	webhook := &models.Webhook{
+		Active:    true,
+		CreatedBy: common.ActorFromContext(ctx),
+	}
+	if err := applyWebhookInput(ctx, webhook, input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			log.Println("[webhookService][CreateWebhook] error generate secret :", err)
+			return http.StatusInternalServerError, &dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			}
+		}
+		webhook.Secret = secret
+	}
+
+	tx := s.webhookRepo.StartTransaction(ctx)
+	webhook, err := s.webhookRepo.CreateWebhook(ctx, webhook, tx)
+	if err != nil {
+		log.Println("[webhookService][CreateWebhook] error create webhook :", err)
+		err = s.webhookRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[webhookService][CreateWebhook] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.recordAudit(ctx, tx, common.AuditActionCreate, webhook.Id, nil, webhookAuditFields(webhook))
+	if err != nil {
+		log.Println("[webhookService][CreateWebhook] error record audit :", err)
+		err = s.webhookRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[webhookService][CreateWebhook] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	err = s.webhookRepo.CommitTransaction(tx)
+	if err != nil {
+		log.Println("[webhookService][CreateWebhook] error commit transaction :", err)
+		err = s.webhookRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[webhookService][CreateWebhook] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusCreated, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    &dto.WebhookSecretOutputDto{WebhookOutputDto: *webhookOutput(webhook), Secret: webhook.Secret},
+	}
+}
+
+func (s *webhookService) GetWebhooks(ctx context.Context, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	webhooks, count, err := s.webhookRepo.GetWebhooks(ctx, pagination)
+	if err != nil {
+		log.Println("[webhookService][GetWebhooks] error get webhooks :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	webhooksRes := []*dto.WebhookOutputDto{}
+	for _, v := range webhooks {
+		webhooksRes = append(webhooksRes, webhookOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = webhooksRes
+	return http.StatusOK, pagination
+}
+
+func (s *webhookService) GetWebhookById(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	webhook, code, response := s.getWebhook(ctx, id, "GetWebhookById")
+	if response != nil {
+		return code, response
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    webhookOutput(webhook),
+	}
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, id string, input *dto.WebhookInputDto) (code int, response *dto.BaseResponse) {
+	webhook, code, response := s.getWebhook(ctx, id, "UpdateWebhook")
+	if response != nil {
+		return code, response
+	}
+
+	before := webhookAuditFields(webhook)
+	if err := applyWebhookInput(ctx, webhook, input); err != nil {
+		return http.StatusBadRequest, &dto.BaseResponse{
+			Error:            common.BadRequest,
+			ErrorDescription: err.Error(),
+		}
+	}
+
+	tx := s.webhookRepo.StartTransaction(ctx)
+	webhook, err := s.webhookRepo.UpdateWebhook(ctx, webhook, tx)
+	if err == nil {
+		err = s.recordAudit(ctx, tx, common.AuditActionUpdate, webhook.Id, before, webhookAuditFields(webhook))
+	}
+	if err == nil {
+		err = s.webhookRepo.CommitTransaction(tx)
+	}
+	if err != nil {
+		log.Println("[webhookService][UpdateWebhook] error update webhook :", err)
+		err = s.webhookRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[webhookService][UpdateWebhook] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    webhookOutput(webhook),
+	}
+}
+
+// DeleteWebhook removes a subscription, its pending deliveries are not attempted anymore
+func (s *webhookService) DeleteWebhook(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	webhook, code, response := s.getWebhook(ctx, id, "DeleteWebhook")
+	if response != nil {
+		return code, response
+	}
+
+	tx := s.webhookRepo.StartTransaction(ctx)
+	err := s.webhookRepo.DeleteWebhook(ctx, webhook, tx)
+	if err == nil {
+		err = s.recordAudit(ctx, tx, common.AuditActionDelete, webhook.Id, webhookAuditFields(webhook), nil)
+	}
+	if err == nil {
+		err = s.webhookRepo.CommitTransaction(tx)
+	}
+	if err != nil {
+		log.Println("[webhookService][DeleteWebhook] error delete webhook :", err)
+		err = s.webhookRepo.RollbackTransaction(tx)
+		if err != nil {
+			log.Println("[webhookService][DeleteWebhook] error rollback transaction :", err)
+		}
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+	}
+}
+
+// GetWebhookDeliveries returns the deliveries of a webhook, newest first
+func (s *webhookService) GetWebhookDeliveries(ctx context.Context, webhookId string, pagination *dto.MetaPagination) (code int, response *dto.MetaPagination) {
+	deliveries, count, err := s.webhookRepo.GetWebhookDeliveries(ctx, webhookId, pagination)
+	if err != nil {
+		log.Println("[webhookService][GetWebhookDeliveries] error get deliveries :", err)
+		return http.StatusInternalServerError, &dto.MetaPagination{
+			BaseResponse: dto.BaseResponse{
+				Error:            common.InternalServerError,
+				ErrorDescription: "Something went wrong",
+			},
+		}
+	}
+
+	deliveriesRes := []*dto.WebhookDeliveryOutputDto{}
+	for _, v := range deliveries {
+		deliveriesRes = append(deliveriesRes, webhookDeliveryOutput(v))
+	}
+	pagination.Total = count
+	pagination.TotalPage = count / int64(pagination.Limit)
+	if count%int64(pagination.Limit) > 0 {
+		pagination.TotalPage++
+	}
+	pagination.Data = deliveriesRes
+	return http.StatusOK, pagination
+}
+
+// GetWebhookDeliveryById returns a delivery with the history of its attempts
+func (s *webhookService) GetWebhookDeliveryById(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	delivery, code, response := s.getWebhookDelivery(ctx, id, "GetWebhookDeliveryById")
+	if response != nil {
+		return code, response
+	}
+
+	attempts, err := s.webhookRepo.GetWebhookAttempts(ctx, delivery.Id)
+	if err != nil {
+		log.Println("[webhookService][GetWebhookDeliveryById] error get attempts :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	resData := webhookDeliveryOutput(delivery)
+	resData.AttemptHistory = []*dto.WebhookAttemptOutputDto{}
+	for _, v := range attempts {
+		resData.AttemptHistory = append(resData.AttemptHistory, webhookAttemptOutput(v))
+	}
+
+	return http.StatusOK, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    resData,
+	}
+}
+
+// RedeliverWebhookDelivery schedules a finished delivery to be sent again right away, with a
+// fresh number of attempts
+func (s *webhookService) RedeliverWebhookDelivery(ctx context.Context, id string) (code int, response *dto.BaseResponse) {
+	delivery, code, response := s.getWebhookDelivery(ctx, id, "RedeliverWebhookDelivery")
+	if response != nil {
+		return code, response
+	}
+
+	if delivery.Status == common.WebhookDeliveryPending {
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Delivery is already pending",
+		}
+	}
+
+	webhook, code, response := s.getWebhook(ctx, delivery.WebhookId, "RedeliverWebhookDelivery")
+	if response != nil {
+		return code, response
+	}
+	if !webhook.Active {
+		return http.StatusConflict, &dto.BaseResponse{
+			Error:            common.Conflict,
+			ErrorDescription: "Webhook is not active",
+		}
+	}
+
+	now := s.now().UTC()
+	delivery.Status = common.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	delivery.LastError = ""
+	delivery.DeliveredAt = nil
+	delivery, err := s.webhookRepo.UpdateWebhookDelivery(ctx, delivery, nil)
+	if err != nil {
+		log.Println("[webhookService][RedeliverWebhookDelivery] error update delivery :", err)
+		return http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	return http.StatusAccepted, &dto.BaseResponse{
+		Message: common.Success,
+		Data:    webhookDeliveryOutput(delivery),
+	}
+}
+
+// DispatchOutboxEvents creates a delivery for every active webhook subscribed to a committed
+// event and marks the event dispatched. It runs without a tenant and handles every event in
+// the tenant it was written in.
+func (s *webhookService) DispatchOutboxEvents(ctx context.Context) (dispatched int, err error) {
+	outboxEvents, err := s.outboxRepo.GetPendingOutboxEvents(ctx, webhookBatchSize)
+	if err != nil {
+		log.Println("[webhookService][DispatchOutboxEvents] error get pending events :", err)
+		return 0, err
+	}
+
+	for _, v := range outboxEvents {
+		if err = s.dispatchOutboxEvent(common.WithTenant(ctx, v.TenantId), v); err != nil {
+			log.Println("[webhookService][DispatchOutboxEvents] error dispatch event :", err)
+			return dispatched, err
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+// DeliverDueWebhooks attempts the pending deliveries whose next attempt is due. A failed
+// attempt is retried with an exponential backoff until maxAttempts is reached.
+func (s *webhookService) DeliverDueWebhooks(ctx context.Context) (attempted int, err error) {
+	now := s.now().UTC()
+	deliveries, err := s.webhookRepo.GetDueWebhookDeliveries(ctx, now, webhookBatchSize)
+	if err != nil {
+		log.Println("[webhookService][DeliverDueWebhooks] error get due deliveries :", err)
+		return 0, err
+	}
+
+	for _, v := range deliveries {
+		claimed, err := s.webhookRepo.ClaimWebhookDelivery(ctx, v.Id, now, now.Add(webhookDeliveryLease))
+		if err != nil {
+			log.Println("[webhookService][DeliverDueWebhooks] error claim delivery :", err)
+			return attempted, err
+		}
+		// another worker took it
+		if !claimed {
+			continue
+		}
+
+		if err = s.deliver(common.WithTenant(ctx, v.TenantId), v); err != nil {
+			log.Println("[webhookService][DeliverDueWebhooks] error deliver :", err)
+			return attempted, err
+		}
+		attempted++
+	}
+	return attempted, nil
+}
+
+func (s *webhookService) dispatchOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	webhooks, err := s.webhookRepo.GetActiveWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	tx := s.webhookRepo.StartTransaction(ctx)
+	claimed, err := s.outboxRepo.MarkOutboxEventDispatched(ctx, event.Id, now, tx)
+	if err == nil && claimed {
+		for _, v := range webhooks {
+			if !webhookSubscribed(v, event.Type) {
+				continue
+			}
+			_, err = s.webhookRepo.CreateWebhookDelivery(ctx, &models.WebhookDelivery{
+				WebhookId:     v.Id,
+				EventId:       event.Id,
+				EventType:     event.Type,
+				Status:        common.WebhookDeliveryPending,
+				NextAttemptAt: &now,
+			}, tx)
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = s.webhookRepo.CommitTransaction(tx)
+	}
+	if err != nil {
+		if rollbackErr := s.webhookRepo.RollbackTransaction(tx); rollbackErr != nil {
+			log.Println("[webhookService][dispatchOutboxEvent] error rollback transaction :", rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// deliver makes one attempt of a claimed delivery and records it
+func (s *webhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	webhook, err := s.webhookRepo.GetWebhookByAttribute(ctx, map[string]interface{}{
+		"id": delivery.WebhookId,
+	})
+	if err != nil {
+		return err
+	}
+	event, err := s.outboxRepo.GetOutboxEventByAttribute(ctx, map[string]interface{}{
+		"id": delivery.EventId,
+	})
+	if err != nil {
+		return err
+	}
+
+	// nothing to send anymore, the delivery is given up without an attempt
+	if webhook == nil || event == nil || !webhook.Active {
+		delivery.Status = common.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		switch {
+		case webhook == nil:
+			delivery.LastError = "Webhook was deleted"
+		case event == nil:
+			delivery.LastError = "Event not found"
+		default:
+			delivery.LastError = "Webhook is not active"
+		}
+		_, err = s.webhookRepo.UpdateWebhookDelivery(ctx, delivery, nil)
+		return err
+	}
+
+	result, deliverErr := s.deliverer.Deliver(ctx, &events.Delivery{
+		Id:        delivery.Id,
+		EventType: delivery.EventType,
+		Url:       webhook.Url,
+		Secret:    webhook.Secret,
+		Body:      []byte(event.Payload),
+	})
+
+	attemptedAt := s.now().UTC()
+	attempt := &models.WebhookAttempt{
+		DeliveryId:   delivery.Id,
+		StatusCode:   result.StatusCode,
+		ResponseBody: result.ResponseBody,
+		DurationMs:   result.Duration.Milliseconds(),
+		AttemptedAt:  attemptedAt,
+	}
+	delivery.Attempts++
+	if deliverErr == nil {
+		delivery.Status = common.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &attemptedAt
+	} else {
+		attempt.Error = deliverErr.Error()
+		delivery.LastError = deliverErr.Error()
+		if delivery.Attempts >= s.maxAttempts {
+			delivery.Status = common.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			nextAttemptAt := attemptedAt.Add(s.retryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+	}
+
+	tx := s.webhookRepo.StartTransaction(ctx)
+	_, err = s.webhookRepo.CreateWebhookAttempt(ctx, attempt, tx)
+	if err == nil {
+		_, err = s.webhookRepo.UpdateWebhookDelivery(ctx, delivery, tx)
+	}
+	if err == nil {
+		err = s.webhookRepo.CommitTransaction(tx)
+	}
+	if err != nil {
+		if rollbackErr := s.webhookRepo.RollbackTransaction(tx); rollbackErr != nil {
+			log.Println("[webhookService][deliver] error rollback transaction :", rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// retryDelay is the wait after the given number of failed attempts, retryBase doubled for each
+// attempt after the first and capped at webhookMaxRetryDelay
+func (s *webhookService) retryDelay(attempts int) time.Duration {
+	delay := s.retryBase
+	for i := 1; i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxRetryDelay {
+		delay = webhookMaxRetryDelay
+	}
+	return delay
+}
+
+func (s *webhookService) getWebhook(ctx context.Context, id string, method string) (webhook *models.Webhook, code int, response *dto.BaseResponse) {
+	webhook, err := s.webhookRepo.GetWebhookByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Printf("[webhookService][%s] error get webhook : %v\n", method, err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if webhook == nil {
+		return nil, http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Webhook not found",
+		}
+	}
+	return webhook, 0, nil
+}
+
+func (s *webhookService) getWebhookDelivery(ctx context.Context, id string, method string) (delivery *models.WebhookDelivery, code int, response *dto.BaseResponse) {
+	delivery, err := s.webhookRepo.GetWebhookDeliveryByAttribute(ctx, map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		log.Printf("[webhookService][%s] error get delivery : %v\n", method, err)
+		return nil, http.StatusInternalServerError, &dto.BaseResponse{
+			Error:            common.InternalServerError,
+			ErrorDescription: "Something went wrong",
+		}
+	}
+
+	if delivery == nil {
+		return nil, http.StatusNotFound, &dto.BaseResponse{
+			Error:            common.NotFound,
+			ErrorDescription: "Delivery not found",
+		}
+	}
+	return delivery, 0, nil
+}
+
+func (s *webhookService) recordAudit(ctx context.Context, tx *gorm.DB, action string, webhookId string, before, after map[string]interface{}) error {
+	auditLog, err := newAuditLog(ctx, common.AuditEntityWebhook, webhookId, action, diffFields(before, after))
+	if err != nil {
+		return err
+	}
+	return s.auditRepo.CreateAuditLog(ctx, auditLog, tx)
+}
+
+// newOutboxEvent builds the outbox record of an event of the tenant found in ctx, its payload
+// is the body the webhooks receive
+func newOutboxEvent(ctx context.Context, eventType string, data interface{}) (*models.OutboxEvent, error) {
+	event := &events.Event{
+		Id:         uuid.New().String(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OutboxEvent{
+		Id:         event.Id,
+		TenantId:   common.TenantFromContext(ctx),
+		Type:       event.Type,
+		Payload:    string(encoded),
+		OccurredAt: event.OccurredAt,
+	}, nil
+}
+
+// applyWebhookInput validates the input and sets it on the webhook. An empty secret keeps the
+// current one and a missing active flag the current state.
+func applyWebhookInput(ctx context.Context, webhook *models.Webhook, input *dto.WebhookInputDto) error {
+	target, err := url.Parse(strings.TrimSpace(input.Url))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.New("Url must be an absolute http or https URL")
+	}
+	if err = events.CheckTarget(ctx, target.Hostname()); err != nil {
+		return errors.New("Url must not point to a loopback, link-local or private address")
+	}
+
+	eventTypes, err := validateWebhookEvents(input.Events)
+	if err != nil {
+		return err
+	}
+
+	secret := strings.TrimSpace(input.Secret)
+	if secret != "" && len(secret) < webhookSecretMinLength {
+		return fmt.Errorf("Secret must be at least %d characters", webhookSecretMinLength)
+	}
+
+	webhook.Url = target.String()
+	webhook.Events = strings.Join(eventTypes, ",")
+	webhook.Description = strings.TrimSpace(input.Description)
+	if secret != "" {
+		webhook.Secret = secret
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+	return nil
+}
+
+func validateWebhookEvents(eventTypes []string) ([]string, error) {
+	known := map[string]bool{common.EventAll: true}
+	for _, v := range common.WebhookEvents {
+		known[v] = true
+	}
+
+	valid := []string{}
+	seen := map[string]bool{}
+	for _, v := range eventTypes {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if !known[v] {
+			return nil, fmt.Errorf("Invalid event %s, allowed events are %s", v, strings.Join(append([]string{common.EventAll}, common.WebhookEvents...), ", "))
+		}
+		if !seen[v] {
+			seen[v] = true
+			valid = append(valid, v)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, errors.New("At least one event is required")
+	}
+	if seen[common.EventAll] {
+		return []string{common.EventAll}, nil
+	}
+	return valid, nil
+}
+
+func webhookSubscribed(webhook *models.Webhook, eventType string) bool {
+	for _, v := range strings.Split(webhook.Events, ",") {
+		if v == common.EventAll || v == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// newWebhookSecret returns a random secret of the form whsec_<secret>
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// webhookAuditFields leaves out the secret, it must not be readable from the audit log
+func webhookAuditFields(webhook *models.Webhook) map[string]interface{} {
+	return map[string]interface{}{
+		"url":         webhook.Url,
+		"events":      webhook.Events,
+		"description": webhook.Description,
+		"active":      webhook.Active,
+	}
+}
+
+func webhookOutput(webhook *models.Webhook) *dto.WebhookOutputDto {
+	return &dto.WebhookOutputDto{
+		Id:          webhook.Id,
+		Url:         webhook.Url,
+		Events:      strings.Split(webhook.Events, ","),
+		Description: webhook.Description,
+		Active:      webhook.Active,
+		CreatedBy:   webhook.CreatedBy,
+		CreatedAt:   webhook.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   webhook.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func webhookDeliveryOutput(delivery *models.WebhookDelivery) *dto.WebhookDeliveryOutputDto {
+	return &dto.WebhookDeliveryOutputDto{
+		Id:            delivery.Id,
+		WebhookId:     delivery.WebhookId,
+		EventId:       delivery.EventId,
+		EventType:     delivery.EventType,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: formatOptionalTime(delivery.NextAttemptAt),
+		LastError:     delivery.LastError,
+		DeliveredAt:   formatOptionalTime(delivery.DeliveredAt),
+		CreatedAt:     delivery.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     delivery.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func webhookAttemptOutput(attempt *models.WebhookAttempt) *dto.WebhookAttemptOutputDto {
+	return &dto.WebhookAttemptOutputDto{
+		Id:           attempt.Id,
+		StatusCode:   attempt.StatusCode,
+		ResponseBody: attempt.ResponseBody,
+		Error:        attempt.Error,
+		DurationMs:   attempt.DurationMs,
+		AttemptedAt:  attempt.AttemptedAt.Format("2006-01-02 15:04:05"),
+	}
+}
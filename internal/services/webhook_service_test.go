@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/events"
+	"assets-api-go/internal/models"
+	mockEvents "assets-api-go/mocks/events"
+	"assets-api-go/mocks/repositories"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *dto.WebhookInputDto
+		mockSetup     func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Success",
+			input: &dto.WebhookInputDto{Url: " https://hooks.example.com/assets ", Events: []string{common.EventAssetCreated, "Asset.Deleted", common.EventAssetCreated}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockWebhookRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockWebhookRepo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, webhook *models.Webhook, tx *gorm.DB) (*models.Webhook, error) {
+					assert.Equal(t, "https://hooks.example.com/assets", webhook.Url)
+					assert.Equal(t, "asset.created,asset.deleted", webhook.Events)
+					assert.True(t, webhook.Active)
+					assert.Equal(t, "admin-1", webhook.CreatedBy)
+					webhook.Id = "webhook-id"
+					return webhook, nil
+				})
+				mockAuditRepo.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, auditLog *models.AuditLog, tx *gorm.DB) error {
+					assert.Equal(t, common.AuditEntityWebhook, auditLog.EntityType)
+					assert.NotContains(t, auditLog.Changes, "whsec_")
+					return nil
+				})
+				mockWebhookRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:  "Not an http URL",
+			input: &dto.WebhookInputDto{Url: "ftp://hooks.example.com", Events: []string{common.EventAll}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Url must be an absolute http or https URL",
+		},
+		{
+			name:  "Loopback URL",
+			input: &dto.WebhookInputDto{Url: "http://localhost:8080/hook", Events: []string{common.EventAll}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Url must not point to a loopback, link-local or private address",
+		},
+		{
+			name:  "Metadata URL",
+			input: &dto.WebhookInputDto{Url: "http://169.254.169.254/latest/meta-data", Events: []string{common.EventAll}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Url must not point to a loopback, link-local or private address",
+		},
+		{
+			name:  "Private URL",
+			input: &dto.WebhookInputDto{Url: "https://10.0.0.5/hook", Events: []string{common.EventAll}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Url must not point to a loopback, link-local or private address",
+		},
+		{
+			name:  "Unknown event",
+			input: &dto.WebhookInputDto{Url: "https://hooks.example.com", Events: []string{"asset.sold"}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Invalid event asset.sold, allowed events are *, asset.created, asset.updated, asset.deleted, asset.restored, asset.purged, asset.status_changed",
+		},
+		{
+			name:  "No event",
+			input: &dto.WebhookInputDto{Url: "https://hooks.example.com"},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "At least one event is required",
+		},
+		{
+			name:  "Short secret",
+			input: &dto.WebhookInputDto{Url: "https://hooks.example.com", Events: []string{common.EventAll}, Secret: "short"},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "Secret must be at least 16 characters",
+		},
+		{
+			name:  "Error create webhook",
+			input: &dto.WebhookInputDto{Url: "https://hooks.example.com", Events: []string{common.EventAll}},
+			mockSetup: func(mockWebhookRepo *repositories.MockWebhookRepositoryInterface, mockAuditRepo *repositories.MockAuditRepositoryInterface) {
+				mockWebhookRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+				mockWebhookRepo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				mockWebhookRepo.EXPECT().RollbackTransaction(gomock.Any()).Return(nil)
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedError: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWebhookRepo := repositories.NewMockWebhookRepositoryInterface(ctrl)
+			mockAuditRepo := repositories.NewMockAuditRepositoryInterface(ctrl)
+			tt.mockSetup(mockWebhookRepo, mockAuditRepo)
+			service := NewWebhookService(mockWebhookRepo, repositories.NewMockOutboxRepositoryInterface(ctrl), mockAuditRepo, mockEvents.NewMockDeliverer(ctrl), 3, time.Minute)
+
+			code, response := service.CreateWebhook(common.WithActor(context.Background(), "admin-1"), tt.input)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedError, response.ErrorDescription)
+			if tt.expectedCode == http.StatusCreated {
+				output := response.Data.(*dto.WebhookSecretOutputDto)
+				assert.Equal(t, "webhook-id", output.Id)
+				assert.Equal(t, []string{common.EventAssetCreated, common.EventAssetDeleted}, output.Events)
+				assert.True(t, strings.HasPrefix(output.Secret, "whsec_"))
+			}
+		})
+	}
+}
+
+func TestDispatchOutboxEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := repositories.NewMockWebhookRepositoryInterface(ctrl)
+	mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+	service := NewWebhookService(mockWebhookRepo, mockOutboxRepo, repositories.NewMockAuditRepositoryInterface(ctrl), mockEvents.NewMockDeliverer(ctrl), 3, time.Minute)
+
+	mockOutboxRepo.EXPECT().GetPendingOutboxEvents(gomock.Any(), webhookBatchSize).Return([]*models.OutboxEvent{
+		{Id: "event-1", TenantId: "acme", Type: common.EventAssetCreated},
+		{Id: "event-2", TenantId: "acme", Type: common.EventAssetCreated},
+	}, nil)
+	mockWebhookRepo.EXPECT().GetActiveWebhooks(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]*models.Webhook, error) {
+		assert.Equal(t, "acme", common.TenantFromContext(ctx))
+		return []*models.Webhook{
+			{Id: "all", Events: common.EventAll},
+			{Id: "created", Events: common.EventAssetUpdated + "," + common.EventAssetCreated},
+			{Id: "deleted", Events: common.EventAssetDeleted},
+		}, nil
+	}).Times(2)
+	mockWebhookRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{}).Times(2)
+	mockOutboxRepo.EXPECT().MarkOutboxEventDispatched(gomock.Any(), "event-1", gomock.Any(), gomock.Any()).Return(true, nil)
+	// already fanned out by another dispatcher
+	mockOutboxRepo.EXPECT().MarkOutboxEventDispatched(gomock.Any(), "event-2", gomock.Any(), gomock.Any()).Return(false, nil)
+	webhookIds := []string{}
+	mockWebhookRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, delivery *models.WebhookDelivery, tx *gorm.DB) (*models.WebhookDelivery, error) {
+		assert.Equal(t, "event-1", delivery.EventId)
+		assert.Equal(t, common.WebhookDeliveryPending, delivery.Status)
+		assert.NotNil(t, delivery.NextAttemptAt)
+		webhookIds = append(webhookIds, delivery.WebhookId)
+		return delivery, nil
+	}).Times(2)
+	mockWebhookRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil).Times(2)
+
+	dispatched, err := service.DispatchOutboxEvents(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dispatched)
+	assert.Equal(t, []string{"all", "created"}, webhookIds)
+}
+
+func TestDeliverDueWebhooks(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	webhook := &models.Webhook{Id: "webhook-id", Url: "https://hooks.example.com", Secret: "whsec_test", Active: true}
+	event := &models.OutboxEvent{Id: "event-id", Type: common.EventAssetCreated, Payload: `{"type":"asset.created"}`}
+
+	tests := []struct {
+		name          string
+		attempts      int
+		deliverErr    error
+		statusCode    int
+		expectedState *models.WebhookDelivery
+	}{
+		{
+			name:          "Delivered",
+			statusCode:    http.StatusOK,
+			expectedState: &models.WebhookDelivery{Status: common.WebhookDeliverySucceeded, Attempts: 1, DeliveredAt: &now},
+		},
+		{
+			name:          "Retried with backoff",
+			attempts:      1,
+			deliverErr:    errors.New("webhook responded with status 500"),
+			statusCode:    http.StatusInternalServerError,
+			expectedState: &models.WebhookDelivery{Status: common.WebhookDeliveryPending, Attempts: 2, LastError: "webhook responded with status 500", NextAttemptAt: timePtr(now.Add(2 * time.Minute))},
+		},
+		{
+			name:          "Given up after the last attempt",
+			attempts:      2,
+			deliverErr:    errors.New("connection refused"),
+			expectedState: &models.WebhookDelivery{Status: common.WebhookDeliveryFailed, Attempts: 3, LastError: "connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWebhookRepo := repositories.NewMockWebhookRepositoryInterface(ctrl)
+			mockOutboxRepo := repositories.NewMockOutboxRepositoryInterface(ctrl)
+			mockDeliverer := mockEvents.NewMockDeliverer(ctrl)
+			service := NewWebhookService(mockWebhookRepo, mockOutboxRepo, repositories.NewMockAuditRepositoryInterface(ctrl), mockDeliverer, 3, time.Minute).(*webhookService)
+			service.now = func() time.Time { return now }
+
+			delivery := &models.WebhookDelivery{Id: "delivery-id", TenantId: "acme", WebhookId: webhook.Id, EventId: event.Id, EventType: event.Type, Status: common.WebhookDeliveryPending, Attempts: tt.attempts, NextAttemptAt: &now}
+			mockWebhookRepo.EXPECT().GetDueWebhookDeliveries(gomock.Any(), now, webhookBatchSize).Return([]*models.WebhookDelivery{delivery}, nil)
+			mockWebhookRepo.EXPECT().ClaimWebhookDelivery(gomock.Any(), "delivery-id", now, now.Add(webhookDeliveryLease)).Return(true, nil)
+			mockWebhookRepo.EXPECT().GetWebhookByAttribute(gomock.Any(), map[string]interface{}{"id": webhook.Id}).Return(webhook, nil)
+			mockOutboxRepo.EXPECT().GetOutboxEventByAttribute(gomock.Any(), map[string]interface{}{"id": event.Id}).Return(event, nil)
+			mockDeliverer.EXPECT().Deliver(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, request *events.Delivery) (*events.DeliveryResult, error) {
+				assert.Equal(t, "acme", common.TenantFromContext(ctx))
+				assert.Equal(t, &events.Delivery{Id: "delivery-id", EventType: common.EventAssetCreated, Url: webhook.Url, Secret: webhook.Secret, Body: []byte(event.Payload)}, request)
+				return &events.DeliveryResult{StatusCode: tt.statusCode, Duration: 20 * time.Millisecond}, tt.deliverErr
+			})
+			mockWebhookRepo.EXPECT().StartTransaction(gomock.Any()).Return(&gorm.DB{})
+			mockWebhookRepo.EXPECT().CreateWebhookAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, attempt *models.WebhookAttempt, tx *gorm.DB) (*models.WebhookAttempt, error) {
+				assert.Equal(t, tt.statusCode, attempt.StatusCode)
+				assert.Equal(t, int64(20), attempt.DurationMs)
+				return attempt, nil
+			})
+			mockWebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), delivery, gomock.Any()).Return(delivery, nil)
+			mockWebhookRepo.EXPECT().CommitTransaction(gomock.Any()).Return(nil)
+
+			attempted, err := service.DeliverDueWebhooks(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 1, attempted)
+			assert.Equal(t, tt.expectedState.Status, delivery.Status)
+			assert.Equal(t, tt.expectedState.Attempts, delivery.Attempts)
+			assert.Equal(t, tt.expectedState.LastError, delivery.LastError)
+			assert.Equal(t, tt.expectedState.NextAttemptAt, delivery.NextAttemptAt)
+			assert.Equal(t, tt.expectedState.DeliveredAt, delivery.DeliveredAt)
+		})
+	}
+}
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := repositories.NewMockWebhookRepositoryInterface(ctrl)
+	service := NewWebhookService(mockWebhookRepo, repositories.NewMockOutboxRepositoryInterface(ctrl), repositories.NewMockAuditRepositoryInterface(ctrl), mockEvents.NewMockDeliverer(ctrl), 3, time.Minute)
+
+	mockWebhookRepo.EXPECT().GetWebhookDeliveryByAttribute(gomock.Any(), map[string]interface{}{"id": "pending-id"}).Return(&models.WebhookDelivery{Id: "pending-id", Status: common.WebhookDeliveryPending}, nil)
+	code, response := service.RedeliverWebhookDelivery(context.Background(), "pending-id")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "Delivery is already pending", response.ErrorDescription)
+
+	delivery := &models.WebhookDelivery{Id: "delivery-id", WebhookId: "webhook-id", Status: common.WebhookDeliveryFailed, Attempts: 3, LastError: "connection refused"}
+	mockWebhookRepo.EXPECT().GetWebhookDeliveryByAttribute(gomock.Any(), map[string]interface{}{"id": "delivery-id"}).Return(delivery, nil)
+	mockWebhookRepo.EXPECT().GetWebhookByAttribute(gomock.Any(), map[string]interface{}{"id": "webhook-id"}).Return(&models.Webhook{Id: "webhook-id", Active: true}, nil)
+	mockWebhookRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), delivery, nil).Return(delivery, nil)
+	code, _ = service.RedeliverWebhookDelivery(context.Background(), "delivery-id")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, common.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 0, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+	assert.NotNil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	service := &webhookService{retryBase: 30 * time.Second}
+	assert.Equal(t, 30*time.Second, service.retryDelay(1))
+	assert.Equal(t, 2*time.Minute, service.retryDelay(3))
+	assert.Equal(t, webhookMaxRetryDelay, service.retryDelay(20))
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
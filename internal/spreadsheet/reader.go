@@ -0,0 +1,200 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var ErrNoSheet = errors.New("workbook has no worksheet")
+
+// IsXLSX reports whether data looks like an XLSX workbook, which is a zip archive
+func IsXLSX(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// ReadCSV returns the records of a comma separated file. A leading UTF-8 byte order mark,
+// as written by Excel, is dropped and rows may have different numbers of fields.
+func ReadCSV(data []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Id string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is a string made of a plain text or rich text runs
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, v := range t.Runs {
+		b.WriteString(v.Text)
+	}
+	return b.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX returns the rows of the first worksheet of a workbook as text. Numbers are kept as
+// written in the file, so dates typed as dates come back as Excel serial numbers. Rows after
+// the first are cut to its width, cells without a header are dropped.
+func ReadXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	sheetPath, err := firstSheetPath(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if err = readXml(archive, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, errMissingPart) {
+		return nil, err
+	}
+
+	var sheet xlsxWorksheet
+	if err = readXml(archive, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	width := maxColumns
+	for _, row := range sheet.Rows {
+		values := []string{}
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				if column, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if column >= width {
+				continue
+			}
+			for len(values) <= column {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(shared.Items) {
+					return nil, fmt.Errorf("cell %s references an unknown shared string", cell.Ref)
+				}
+				values[column] = shared.Items[index].String()
+			case "inlineStr":
+				values[column] = cell.Inline.String()
+			default:
+				values[column] = cell.Value
+			}
+		}
+		if len(rows) == 0 {
+			width = len(values)
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+var errMissingPart = errors.New("missing part")
+
+func firstSheetPath(archive *zip.Reader) (string, error) {
+	var workbook xlsxWorkbook
+	if err := readXml(archive, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", ErrNoSheet
+	}
+
+	var relationships xlsxRelationships
+	if err := readXml(archive, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return "", err
+	}
+	for _, v := range relationships.Relationships {
+		if v.Id == workbook.Sheets[0].Id {
+			if strings.HasPrefix(v.Target, "/") {
+				return strings.TrimPrefix(v.Target, "/"), nil
+			}
+			return path.Join("xl", v.Target), nil
+		}
+	}
+	return "", ErrNoSheet
+}
+
+func readXml(archive *zip.Reader, name string, target interface{}) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w %s", errMissingPart, name)
+	}
+	defer file.Close()
+
+	return xml.NewDecoder(io.LimitReader(file, maxPartSize)).Decode(target)
+}
+
+// maxPartSize bounds how much of a single decompressed workbook part is read
+const maxPartSize = 100 << 20
+
+// maxColumns is the number of columns of a worksheet, the last one is XFD
+const maxColumns = 16384
+
+// columnIndex returns the zero based column of a cell reference such as "C12"
+func columnIndex(ref string) (int, error) {
+	column := 0
+	for i, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			column = column*26 + int(r-'A') + 1
+			if column > maxColumns {
+				return 0, fmt.Errorf("cell reference %s is beyond the last column", ref)
+			}
+			continue
+		}
+		if i == 0 {
+			break
+		}
+		return column - 1, nil
+	}
+	return 0, fmt.Errorf("invalid cell reference %s", ref)
+}
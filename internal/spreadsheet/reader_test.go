@@ -0,0 +1,112 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		file, err := archive.Create(name)
+		assert.NoError(t, err)
+		file.Write([]byte(content))
+	}
+	assert.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func TestReadCSV(t *testing.T) {
+	rows, err := ReadCSV([]byte("\xef\xbb\xbfname,value\n\"Laptop, 14\"\"\",1200\nMonitor\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"name", "value"}, {"Laptop, 14\"", "1200"}, {"Monitor"}}, rows)
+}
+
+func TestReadXLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Assets" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId4"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId4" Target="worksheets/sheet2.xml"/><Relationship Id="rId3" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<si><t>name</t></si><si><t>value</t></si><si><r><t>Lap</t></r><r><t>top</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>1200.5</v></c></row>
+			<row r="3"><c r="B3" t="inlineStr"><is><t>Monitor</t></is></c></row>
+			</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+	})
+
+	rows, err := ReadXLSX(data)
+	assert.NoError(t, err)
+	// C2 has no header and is dropped
+	assert.Equal(t, [][]string{{"name", "value"}, {"Laptop"}, {"", "Monitor"}}, rows)
+	assert.True(t, IsXLSX(data))
+	assert.False(t, IsXLSX([]byte("name,value")))
+}
+
+func TestReadXLSXInvalid(t *testing.T) {
+	_, err := ReadXLSX([]byte("not a zip"))
+	assert.Error(t, err)
+
+	_, err = ReadXLSX(buildXLSX(t, map[string]string{"docProps/app.xml": "<Properties/>"}))
+	assert.Error(t, err)
+}
+
+func TestReadXLSXColumnLimit(t *testing.T) {
+	workbook := func(cells string) []byte {
+		return buildXLSX(t, map[string]string{
+			"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+				<sheets><sheet name="Assets" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+				<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+			"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+				<row r="1"><c r="A1" t="inlineStr"><is><t>name</t></is></c></row>
+				<row r="2">` + cells + `</row></sheetData></worksheet>`,
+		})
+	}
+
+	t.Run("Cells past the header are dropped", func(t *testing.T) {
+		rows, err := ReadXLSX(workbook(`<c r="A2"><v>1</v></c><c r="XFD2"><v>2</v></c>`))
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"name"}, {"1"}}, rows)
+	})
+
+	t.Run("Error - Column past XFD", func(t *testing.T) {
+		_, err := ReadXLSX(workbook(`<c r="ZZZZZZZ2"><v>1</v></c>`))
+		assert.EqualError(t, err, "cell reference ZZZZZZZ2 is beyond the last column")
+	})
+
+	t.Run("Error - Overflowing column", func(t *testing.T) {
+		_, err := ReadXLSX(workbook(`<c r="ZZZZZZZZZZZZZZZZ2"><v>1</v></c>`))
+		assert.EqualError(t, err, "cell reference ZZZZZZZZZZZZZZZZ2 is beyond the last column")
+	})
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected int
+		hasError bool
+	}{
+		{ref: "A1", expected: 0},
+		{ref: "AA7", expected: 26},
+		{ref: "XFD1", expected: 16383},
+		{ref: "XFE1", hasError: true},
+		{ref: "ZZZZZZZZZZZZZZZZ1", hasError: true},
+		{ref: "12", hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			column, err := columnIndex(tt.ref)
+			assert.Equal(t, tt.hasError, err != nil)
+			assert.Equal(t, tt.expected, column)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RowWriter writes a table row by row. A cell is a string, a float64, an int or nil for an
+// empty cell. Close must be called to complete the output.
+type RowWriter interface {
+	WriteRow(cells []interface{}) error
+	Close() error
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+// NewCSVWriter writes comma separated rows to w. Text starting with =, +, - or @ is prefixed
+// with a quote so spreadsheet applications do not run it as a formula.
+func NewCSVWriter(w io.Writer) RowWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) WriteRow(cells []interface{}) error {
+	record := make([]string, len(cells))
+	for i, v := range cells {
+		switch value := v.(type) {
+		case nil:
+		case string:
+			if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+				value = "'" + value
+			}
+			record[i] = value
+		default:
+			record[i] = formatNumber(value)
+		}
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+}
+
+// NewXLSXWriter writes a workbook with a single worksheet to w. Rows are streamed into the
+// archive as they are written, nothing but the current row is kept in memory.
+func NewXLSXWriter(w io.Writer, sheetName string) (RowWriter, error) {
+	archive := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + escapeXml(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+	}
+	for _, v := range parts {
+		part, err := archive.Create(v.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(part, v.content); err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(part)
+	if _, err = sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{archive: archive, sheet: sheet}, nil
+}
+
+func (w *xlsxWriter) WriteRow(cells []interface{}) error {
+	w.row++
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.row)
+	for i, v := range cells {
+		ref := columnName(i) + strconv.Itoa(w.row)
+		switch value := v.(type) {
+		case nil:
+		case string:
+			fmt.Fprintf(w.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXml(value))
+		default:
+			fmt.Fprintf(w.sheet, `<c r="%s"><v>%s</v></c>`, ref, formatNumber(value))
+		}
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	if _, err := w.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+// columnName returns the letters of a zero based column, A for 0 and AA for 26
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func formatNumber(value interface{}) string {
+	switch number := value.(type) {
+	case float64:
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(number)
+	case int64:
+		return strconv.FormatInt(number, 10)
+	}
+	return fmt.Sprint(value)
+}
+
+func escapeXml(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
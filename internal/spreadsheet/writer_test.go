@@ -0,0 +1,41 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewCSVWriter(&buf)
+	assert.NoError(t, writer.WriteRow([]interface{}{"name", "value", "note"}))
+	assert.NoError(t, writer.WriteRow([]interface{}{"Laptop, 14\"", 1200.5, nil}))
+	assert.NoError(t, writer.WriteRow([]interface{}{"=HYPERLINK(\"x\")", -3.0, "-"}))
+	assert.NoError(t, writer.Close())
+
+	assert.Equal(t, "name,value,note\n\"Laptop, 14\"\"\",1200.5,\n\"'=HYPERLINK(\"\"x\"\")\",-3,'-\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewXLSXWriter(&buf, "Assets")
+	assert.NoError(t, err)
+	assert.NoError(t, writer.WriteRow([]interface{}{"name", "value"}))
+	assert.NoError(t, writer.WriteRow([]interface{}{"Desk & <Chair>", 350.25}))
+	assert.NoError(t, writer.WriteRow([]interface{}{nil, 7}))
+	assert.NoError(t, writer.Close())
+
+	rows, err := ReadXLSX(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"name", "value"}, {"Desk & <Chair>", "350.25"}, {"", "7"}}, rows)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound is returned by Get when no object is stored under the key
+var ErrNotFound = errors.New("object not found")
+
+// Storage keeps the content of uploaded files. Keys are slash separated paths chosen by the caller.
+type Storage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects the storage backend, the S3 fields are only used by the s3 backend
+type Config struct {
+	Backend           string
+	LocalPath         string
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKeyId     string
+	S3SecretAccessKey string
+}
+
+// NewStorage returns the backend configured by cfg, an S3-compatible bucket or a local directory
+func NewStorage(cfg Config) Storage {
+	if cfg.Backend == BackendS3 {
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Bucket, cfg.S3Region, cfg.S3AccessKeyId, cfg.S3SecretAccessKey)
+	}
+	return NewLocalStorage(cfg.LocalPath)
+}
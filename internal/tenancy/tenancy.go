@@ -0,0 +1,121 @@
+package tenancy
+
+import (
+	"assets-api-go/internal/common"
+	"context"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Column is the tenant column of every tenant-owned table
+const Column = "tenant_id"
+
+// ErrMissingTenant fails a statement on a tenant-owned model whose context has no tenant and
+// was not marked with WithoutTenant
+var ErrMissingTenant = errors.New("tenancy: statement context has no tenant")
+
+type contextKey struct{}
+
+// WithoutTenant lets the statements run with the context see and change the rows of every
+// tenant. It is meant for migrations, background jobs and the lookup of the credentials that
+// name the tenant, each of which opts in where it runs.
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, true)
+}
+
+// Plugin scopes the statements on models with a tenant column to the tenant of the
+// statement context (see common.WithTenant). Queries, updates and deletes only match rows
+// of that tenant and created rows are given it, so repositories need no tenant conditions
+// of their own. A statement without a tenant fails with ErrMissingTenant unless its context
+// comes from WithoutTenant, a tenant set on top of it still scopes.
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "tenancy"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenancy:create", assignTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenancy:query", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenancy:row", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenancy:update", func(db *gorm.DB) {
+		// a saved struct keeps its tenant, a foreign row is not matched by the scope
+		assignTenant(db)
+		scopeTenant(db)
+	}); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenancy:delete", scopeTenant)
+}
+
+// statementTenant returns the tenant of the statement when its model is tenant-owned. A
+// statement on a tenant-owned model without a tenant gets ErrMissingTenant unless it opted out.
+func statementTenant(db *gorm.DB) (string, bool) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Schema.LookUpField(Column) == nil {
+		return "", false
+	}
+	ctx := db.Statement.Context
+	if tenantId := common.TenantFromContext(ctx); tenantId != "" {
+		return tenantId, true
+	}
+	if unscoped, _ := ctx.Value(contextKey{}).(bool); !unscoped {
+		db.AddError(ErrMissingTenant)
+	}
+	return "", false
+}
+
+func tenantCondition(tenantId string) clause.Expression {
+	return clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: tenantId}
+}
+
+func scopeTenant(db *gorm.DB) {
+	tenantId, ok := statementTenant(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{tenantCondition(tenantId)}})
+}
+
+func assignTenant(db *gorm.DB) {
+	tenantId, ok := statementTenant(db)
+	if !ok {
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField(Column)
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(db.Statement.Context, reflect.Indirect(value.Index(i)), tenantId); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(db.Statement.Context, value, tenantId); err != nil {
+			db.AddError(err)
+			return
+		}
+	}
+
+	// Save turns into an upsert when the update matched no row, it must not take over the
+	// row of another tenant with the same key
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs, tenantCondition(tenantId))
+			c.Expression = onConflict
+			db.Statement.Clauses["ON CONFLICT"] = c
+		}
+	}
+}
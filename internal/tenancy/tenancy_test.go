@@ -0,0 +1,198 @@
+package tenancy_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/common"
+	"assets-api-go/internal/dto"
+	"assets-api-go/internal/models"
+	"assets-api-go/internal/repositories"
+	"assets-api-go/internal/services"
+	"assets-api-go/internal/tenancy"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	acme   = common.WithTenant(context.Background(), "acme")
+	globex = common.WithTenant(context.Background(), "globex")
+)
+
+func newTestDb(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "assets.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(tenancy.Plugin{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&models.Asset{}, &models.AuditLog{}, &models.Category{}, &models.Location{}, &models.Assignment{}, &models.AssetTransition{}, &models.MaintenanceRecord{}, &models.MaintenancePlan{}, &models.Warranty{}, &models.Attachment{}, &models.RoleAssignment{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newAsset(name string) *models.Asset {
+	return &models.Asset{Name: name, Type: "Computer", Status: common.AssetStatusInStock, Value: 1000, AcquisitionDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestAssetRepositoryIsolation(t *testing.T) {
+	repo := repositories.NewAssetRepository(newTestDb(t))
+
+	acmeAsset, err := repo.CreateAsset(acme, newAsset("Laptop"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", acmeAsset.TenantId)
+
+	// the tenant of the context wins over one set on the model
+	forged := newAsset("Monitor")
+	forged.TenantId = "acme"
+	globexAsset, err := repo.CreateAsset(globex, forged, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", globexAsset.TenantId)
+	_, err = repo.CreateAsset(globex, newAsset("Laptop"), nil)
+	assert.NoError(t, err)
+
+	t.Run("Get by ID", func(t *testing.T) {
+		asset, err := repo.GetAssetByAttribute(acme, map[string]interface{}{"id": globexAsset.Id})
+		assert.NoError(t, err)
+		assert.Nil(t, asset)
+
+		asset, err = repo.GetAssetByAttribute(globex, map[string]interface{}{"id": globexAsset.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, "Monitor", asset.Name)
+	})
+
+	t.Run("Name and type duplicate check", func(t *testing.T) {
+		asset, err := repo.GetAssetByAttribute(acme, map[string]interface{}{"name": "Monitor", "type": "Computer"})
+		assert.NoError(t, err)
+		assert.Nil(t, asset)
+
+		asset, err = repo.GetAssetByAttribute(acme, map[string]interface{}{"name": "Laptop", "type": "Computer"})
+		assert.NoError(t, err)
+		assert.Equal(t, acmeAsset.Id, asset.Id)
+	})
+
+	t.Run("Listing, count and sum", func(t *testing.T) {
+		assets, total, err := repo.GetAssets(acme, &dto.AssetFilterDto{}, &dto.MetaPagination{Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Len(t, assets, 1)
+		assert.Equal(t, acmeAsset.Id, assets[0].Id)
+
+		assets, _, err = repo.GetAssetsByCursor(globex, &dto.AssetFilterDto{}, &dto.MetaPagination{Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, assets, 2)
+
+		streamed := 0
+		err = repo.StreamAssets(acme, &dto.AssetFilterDto{}, &dto.MetaPagination{}, func(asset *models.Asset) error {
+			assert.Equal(t, "acme", asset.TenantId)
+			streamed++
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, streamed)
+
+		count, err := repo.CountAssets(globex, &dto.AssetFilterDto{Status: common.AssignmentStatusAvailable})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		sum, err := repo.SumAssetValue(acme, &dto.AssetFilterDto{})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1000), sum)
+	})
+
+	t.Run("Writes to another tenant's asset", func(t *testing.T) {
+		foreign := *globexAsset
+		foreign.Name = "Taken"
+		updated, err := repo.UpdateAsset(acme, &foreign, nil)
+		assert.NoError(t, err)
+		assert.False(t, updated)
+
+		foreign.Status = common.AssetStatusDeployed
+		moved, err := repo.TransitionAsset(acme, &foreign, common.AssetStatusInStock, nil)
+		assert.NoError(t, err)
+		assert.False(t, moved)
+
+		deleted, err := repo.DeleteAsset(acme, globexAsset, nil)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, repo.PurgeAsset(acme, globexAsset, nil))
+
+		asset, err := repo.GetAssetByAttribute(globex, map[string]interface{}{"id": globexAsset.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, "Monitor", asset.Name)
+		assert.Equal(t, common.AssetStatusInStock, asset.Status)
+		assert.Equal(t, "globex", asset.TenantId)
+	})
+
+	t.Run("Statements without a tenant fail", func(t *testing.T) {
+		_, err := repo.CountAssets(context.Background(), &dto.AssetFilterDto{})
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+		_, err = repo.CreateAsset(context.Background(), newAsset("Laptop"), nil)
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+		asset, err := repo.GetAssetByAttribute(context.Background(), map[string]interface{}{"id": globexAsset.Id})
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+		assert.Nil(t, asset)
+	})
+
+	t.Run("Background jobs opt in to every tenant", func(t *testing.T) {
+		count, err := repo.CountAssets(tenancy.WithoutTenant(context.Background()), &dto.AssetFilterDto{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		// a tenant set on top of it still scopes
+		count, err = repo.CountAssets(common.WithTenant(tenancy.WithoutTenant(context.Background()), "acme"), &dto.AssetFilterDto{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestRoleAssignmentsPerTenant(t *testing.T) {
+	repo := repositories.NewRoleRepository(newTestDb(t))
+
+	_, err := repo.CreateRoleAssignment(acme, &models.RoleAssignment{Subject: "user-1", Role: common.RoleAdmin}, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateRoleAssignment(globex, &models.RoleAssignment{Subject: "user-1", Role: common.RoleAdmin}, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateRoleAssignment(globex, &models.RoleAssignment{Subject: "user-1", Role: common.RoleAdmin}, nil)
+	assert.Error(t, err)
+	_, err = repo.CreateRoleAssignment(globex, &models.RoleAssignment{Subject: "user-2", Role: common.RoleViewer}, nil)
+	assert.NoError(t, err)
+
+	roles, err := repo.GetSubjectRoles(acme, "user-2")
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+
+	roles, err = repo.GetSubjectRoles(globex, "user-2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{common.RoleViewer}, roles)
+}
+
+func TestAssetServiceIsolation(t *testing.T) {
+	db := newTestDb(t)
+	assetRepo := repositories.NewAssetRepository(db)
+	service := services.NewAssetService(assetRepo, repositories.NewAuditRepository(db), repositories.NewCategoryRepository(db), repositories.NewLocationRepository(db), repositories.NewOutboxRepository(db), repositories.NewAttachmentRepository(db), nil, nil)
+
+	asset, err := assetRepo.CreateAsset(acme, newAsset("Laptop"), nil)
+	assert.NoError(t, err)
+
+	code, _ := service.GetAssetById(acme, asset.Id)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, response := service.GetAssetById(globex, asset.Id)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Nil(t, response.Data)
+
+	code, _ = service.DeleteAsset(globex, asset.Id, "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = service.GetAssetById(acme, asset.Id)
+	assert.Equal(t, http.StatusOK, code)
+}
@@ -0,0 +1,84 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// MaxPixels is the largest image, in pixels, a thumbnail is made of. Larger images are
+// rejected before decoding so a small compressed file cannot exhaust memory.
+const MaxPixels = 50_000_000
+
+const jpegQuality = 80
+
+var ErrTooLarge = errors.New("image is too large for a thumbnail")
+
+// Generate decodes a JPEG, PNG or GIF image and returns a JPEG scaled down to fit in a
+// maxSide x maxSide box, keeping the aspect ratio. Images already fitting are only re-encoded.
+func Generate(content []byte, maxSide int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, scale(src, maxSide), &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scale resizes src to fit maxSide with a box filter, every target pixel is the average of
+// the source pixels it covers
+func scale(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	targetWidth, targetHeight := width, height
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			targetWidth, targetHeight = maxSide, max(1, height*maxSide/width)
+		} else {
+			targetWidth, targetHeight = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := 0; y < targetHeight; y++ {
+		y0, y1 := bounds.Min.Y+y*height/targetHeight, bounds.Min.Y+max((y+1)*height/targetHeight, y*height/targetHeight+1)
+		for x := 0; x < targetWidth; x++ {
+			x0, x1 := bounds.Min.X+x*width/targetWidth, bounds.Min.X+max((x+1)*width/targetWidth, x*width/targetWidth+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			// JPEG has no alpha, transparent areas are drawn on white
+			alpha := a / n
+			white := uint64(0xffff) - alpha
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r/n + white),
+				G: uint16(g/n + white),
+				B: uint16(b/n + white),
+				A: 0xffff,
+			})
+		}
+	}
+	return dst
+}
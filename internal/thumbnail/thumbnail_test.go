@@ -0,0 +1,67 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePng(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name           string
+		width, height  int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{name: "Landscape", width: 800, height: 400, expectedWidth: 256, expectedHeight: 128},
+		{name: "Portrait", width: 300, height: 600, expectedWidth: 128, expectedHeight: 256},
+		{name: "Already small", width: 100, height: 50, expectedWidth: 100, expectedHeight: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			for y := 0; y < tt.height; y++ {
+				for x := 0; x < tt.width; x++ {
+					src.Set(x, y, color.RGBA{R: 200, G: 20, B: 20, A: 255})
+				}
+			}
+
+			thumb, err := Generate(encodePng(t, src), 256)
+			assert.NoError(t, err)
+
+			decoded, err := jpeg.Decode(bytes.NewReader(thumb))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedWidth, decoded.Bounds().Dx())
+			assert.Equal(t, tt.expectedHeight, decoded.Bounds().Dy())
+			r, _, _, _ := decoded.At(tt.expectedWidth/2, tt.expectedHeight/2).RGBA()
+			assert.InDelta(t, 200, r>>8, 8)
+		})
+	}
+
+	t.Run("Transparent areas become white", func(t *testing.T) {
+		thumb, err := Generate(encodePng(t, image.NewNRGBA(image.Rect(0, 0, 10, 10))), 256)
+		assert.NoError(t, err)
+		decoded, _ := jpeg.Decode(bytes.NewReader(thumb))
+		r, g, b, _ := decoded.At(5, 5).RGBA()
+		assert.Greater(t, r>>8, uint32(245))
+		assert.Greater(t, g>>8, uint32(245))
+		assert.Greater(t, b>>8, uint32(245))
+	})
+
+	t.Run("Not an image", func(t *testing.T) {
+		_, err := Generate([]byte("%PDF-1.4"), 256)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+// Package validation checks request DTOs against their validate tags and reports every
+// invalid field, named as in the JSON body
+package validation
+
+import (
+	"assets-api-go/internal/dto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// codes of a dto.FieldErrorDto
+const (
+	CodeRequired    = "required"
+	CodeMaxLength   = "max_length"
+	CodeMin         = "min"
+	CodeMax         = "max"
+	CodeDateFormat  = "date_format"
+	CodeFutureDate  = "future_date"
+	CodeInvalidType = "invalid_type"
+	CodeInvalid     = "invalid"
+)
+
+// dateLayout is the layout of the date fields of the API
+const dateLayout = "2006-01-02"
+
+// now is the clock of the notfuture rule
+var now = time.Now
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	// notfuture accepts a YYYY-MM-DD date up to today in UTC, other values are left to the
+	// datetime rule
+	v.RegisterValidation("notfuture", func(fl validator.FieldLevel) bool {
+		date, err := time.Parse(dateLayout, fl.Field().String())
+		if err != nil {
+			return true
+		}
+		return !date.After(now().UTC())
+	})
+	return v
+}
+
+// Struct validates a DTO, it returns nil when it is valid
+func Struct(s interface{}) []dto.FieldErrorDto {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return []dto.FieldErrorDto{{Code: CodeInvalid, Message: err.Error()}}
+	}
+	errs := make([]dto.FieldErrorDto, 0, len(fieldErrs))
+	for _, v := range fieldErrs {
+		errs = append(errs, fieldError(v))
+	}
+	return errs
+}
+
+func fieldError(err validator.FieldError) dto.FieldErrorDto {
+	field := err.Field()
+	switch err.Tag() {
+	case "required":
+		return dto.FieldErrorDto{Field: field, Code: CodeRequired, Message: field + " is required"}
+	case "max":
+		return dto.FieldErrorDto{Field: field, Code: CodeMaxLength, Message: fmt.Sprintf("%s must be at most %s characters", field, err.Param())}
+	case "gte", "min":
+		return dto.FieldErrorDto{Field: field, Code: CodeMin, Message: fmt.Sprintf("%s must be %s or more", field, err.Param())}
+	case "lte":
+		return dto.FieldErrorDto{Field: field, Code: CodeMax, Message: fmt.Sprintf("%s must be %s or less", field, err.Param())}
+	case "datetime":
+		layout := err.Param()
+		if layout == dateLayout {
+			layout = "YYYY-MM-DD"
+		}
+		return dto.FieldErrorDto{Field: field, Code: CodeDateFormat, Message: fmt.Sprintf("%s must be a date in the format %s", field, layout)}
+	case "notfuture":
+		return dto.FieldErrorDto{Field: field, Code: CodeFutureDate, Message: field + " must not be in the future"}
+	}
+	return dto.FieldErrorDto{Field: field, Code: CodeInvalid, Message: field + " is invalid"}
+}
+
+// BindingErrors reports the field of a JSON body that does not decode into its DTO field, it
+// returns nil for a body that is not JSON
+func BindingErrors(err error) []dto.FieldErrorDto {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field == "" {
+		return nil
+	}
+	return []dto.FieldErrorDto{{
+		Field:   typeErr.Field,
+		Code:    CodeInvalidType,
+		Message: fmt.Sprintf("%s must be %s", typeErr.Field, jsonType(typeErr.Type)),
+	}}
+}
+
+// Message joins the messages of the field errors, for the error_description of a response
+func Message(errs []dto.FieldErrorDto) string {
+	messages := make([]string, len(errs))
+	for i, v := range errs {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"assets-api-go/internal/dto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStruct(t *testing.T) {
+	now = func() time.Time { return time.Date(2025, 6, 15, 23, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name     string
+		input    *dto.AssetInputDto
+		expected []dto.FieldErrorDto
+	}{
+		{
+			name:  "Valid",
+			input: &dto.AssetInputDto{Name: "Laptop", Type: "Computer", Value: 0, AcquisitionDate: "2025-06-15"},
+		},
+		{
+			name:  "Missing fields",
+			input: &dto.AssetInputDto{},
+			expected: []dto.FieldErrorDto{
+				{Field: "name", Code: CodeRequired, Message: "name is required"},
+				{Field: "acquisition_date", Code: CodeRequired, Message: "acquisition_date is required"},
+			},
+		},
+		{
+			name:  "Invalid fields",
+			input: &dto.AssetInputDto{Name: strings.Repeat("n", 256), Type: strings.Repeat("t", 256), Value: -1, AcquisitionDate: "15/06/2025"},
+			expected: []dto.FieldErrorDto{
+				{Field: "name", Code: CodeMaxLength, Message: "name must be at most 255 characters"},
+				{Field: "type", Code: CodeMaxLength, Message: "type must be at most 255 characters"},
+				{Field: "value", Code: CodeMin, Message: "value must be 0 or more"},
+				{Field: "acquisition_date", Code: CodeDateFormat, Message: "acquisition_date must be a date in the format YYYY-MM-DD"},
+			},
+		},
+		{
+			name:  "Useful life above maximum",
+			input: &dto.AssetInputDto{Name: "Laptop", Value: 1, AcquisitionDate: "2025-06-15", UsefulLifeYears: 2000000000},
+			expected: []dto.FieldErrorDto{
+				{Field: "useful_life_years", Code: CodeMax, Message: "useful_life_years must be 100 or less"},
+			},
+		},
+		{
+			name:  "Future date",
+			input: &dto.AssetInputDto{Name: "Laptop", Value: 1, AcquisitionDate: "2025-06-16"},
+			expected: []dto.FieldErrorDto{
+				{Field: "acquisition_date", Code: CodeFutureDate, Message: "acquisition_date must not be in the future"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Struct(tt.input))
+		})
+	}
+}
+
+func TestBindingErrors(t *testing.T) {
+	err := json.Unmarshal([]byte(`{"name":"Laptop","value":"abc"}`), &dto.AssetInputDto{})
+	assert.Equal(t, []dto.FieldErrorDto{{Field: "value", Code: CodeInvalidType, Message: "value must be a number"}}, BindingErrors(err))
+
+	err = json.Unmarshal([]byte(`{"name":`), &dto.AssetInputDto{})
+	assert.Nil(t, BindingErrors(err))
+	assert.Nil(t, BindingErrors(errors.New("EOF")))
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, "name is required; value must be 0 or more", Message([]dto.FieldErrorDto{
+		{Field: "name", Code: CodeRequired, Message: "name is required"},
+		{Field: "value", Code: CodeMin, Message: "value must be 0 or more"},
+	}))
+}
@@ -7,7 +7,9 @@ package repositories
 import (
 	dto "assets-api-go/internal/dto"
 	models "assets-api-go/internal/models"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	gorm "gorm.io/gorm"
@@ -50,54 +52,101 @@ func (mr *MockAssetRepositoryInterfaceMockRecorder) CommitTransaction(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTransaction", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).CommitTransaction), arg0)
 }
 
+// CountAssets mocks base method.
+func (m *MockAssetRepositoryInterface) CountAssets(ctx context.Context, filter *dto.AssetFilterDto) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAssets", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAssets indicates an expected call of CountAssets.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) CountAssets(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAssets", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).CountAssets), ctx, filter)
+}
+
 // CreateAsset mocks base method.
-func (m *MockAssetRepositoryInterface) CreateAsset(asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+func (m *MockAssetRepositoryInterface) CreateAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAsset", asset, tx)
+	ret := m.ctrl.Call(m, "CreateAsset", ctx, asset, tx)
 	ret0, _ := ret[0].(*models.Asset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateAsset indicates an expected call of CreateAsset.
-func (mr *MockAssetRepositoryInterfaceMockRecorder) CreateAsset(asset, tx interface{}) *gomock.Call {
+func (mr *MockAssetRepositoryInterfaceMockRecorder) CreateAsset(ctx, asset, tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).CreateAsset), asset, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).CreateAsset), ctx, asset, tx)
+}
+
+// CreateAssetTransition mocks base method.
+func (m *MockAssetRepositoryInterface) CreateAssetTransition(ctx context.Context, transition *models.AssetTransition, tx *gorm.DB) (*models.AssetTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssetTransition", ctx, transition, tx)
+	ret0, _ := ret[0].(*models.AssetTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssetTransition indicates an expected call of CreateAssetTransition.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) CreateAssetTransition(ctx, transition, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssetTransition", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).CreateAssetTransition), ctx, transition, tx)
 }
 
 // DeleteAsset mocks base method.
-func (m *MockAssetRepositoryInterface) DeleteAsset(asset *models.Asset, tx *gorm.DB) error {
+func (m *MockAssetRepositoryInterface) DeleteAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAsset", asset, tx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteAsset", ctx, asset, tx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteAsset indicates an expected call of DeleteAsset.
-func (mr *MockAssetRepositoryInterfaceMockRecorder) DeleteAsset(asset, tx interface{}) *gomock.Call {
+func (mr *MockAssetRepositoryInterfaceMockRecorder) DeleteAsset(ctx, asset, tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).DeleteAsset), asset, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).DeleteAsset), ctx, asset, tx)
 }
 
 // GetAssetByAttribute mocks base method.
-func (m *MockAssetRepositoryInterface) GetAssetByAttribute(whereClause interface{}) (*models.Asset, error) {
+func (m *MockAssetRepositoryInterface) GetAssetByAttribute(ctx context.Context, whereClause interface{}) (*models.Asset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAssetByAttribute", whereClause)
+	ret := m.ctrl.Call(m, "GetAssetByAttribute", ctx, whereClause)
 	ret0, _ := ret[0].(*models.Asset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAssetByAttribute indicates an expected call of GetAssetByAttribute.
-func (mr *MockAssetRepositoryInterfaceMockRecorder) GetAssetByAttribute(whereClause interface{}) *gomock.Call {
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetAssetByAttribute(ctx, whereClause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetByAttribute", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetAssetByAttribute), ctx, whereClause)
+}
+
+// GetAssetTransitions mocks base method.
+func (m *MockAssetRepositoryInterface) GetAssetTransitions(ctx context.Context, assetId string, pagination *dto.MetaPagination) ([]*models.AssetTransition, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetTransitions", ctx, assetId, pagination)
+	ret0, _ := ret[0].([]*models.AssetTransition)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssetTransitions indicates an expected call of GetAssetTransitions.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetAssetTransitions(ctx, assetId, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetByAttribute", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetAssetByAttribute), whereClause)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetTransitions", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetAssetTransitions), ctx, assetId, pagination)
 }
 
 // GetAssets mocks base method.
-func (m *MockAssetRepositoryInterface) GetAssets(pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+func (m *MockAssetRepositoryInterface) GetAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAssets", pagination)
+	ret := m.ctrl.Call(m, "GetAssets", ctx, filter, pagination)
 	ret0, _ := ret[0].([]*models.Asset)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -105,9 +154,114 @@ func (m *MockAssetRepositoryInterface) GetAssets(pagination *dto.MetaPagination)
 }
 
 // GetAssets indicates an expected call of GetAssets.
-func (mr *MockAssetRepositoryInterfaceMockRecorder) GetAssets(pagination interface{}) *gomock.Call {
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetAssets(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssets", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetAssets), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssets", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetAssets), ctx, filter, pagination)
+}
+
+// GetAssetsByCursor mocks base method.
+func (m *MockAssetRepositoryInterface) GetAssetsByCursor(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination) ([]*models.Asset, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetsByCursor", ctx, filter, pagination)
+	ret0, _ := ret[0].([]*models.Asset)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssetsByCursor indicates an expected call of GetAssetsByCursor.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetAssetsByCursor(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetsByCursor", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetAssetsByCursor), ctx, filter, pagination)
+}
+
+// GetDeletedAssetByAttribute mocks base method.
+func (m *MockAssetRepositoryInterface) GetDeletedAssetByAttribute(ctx context.Context, whereClause interface{}) (*models.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedAssetByAttribute", ctx, whereClause)
+	ret0, _ := ret[0].(*models.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedAssetByAttribute indicates an expected call of GetDeletedAssetByAttribute.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetDeletedAssetByAttribute(ctx, whereClause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedAssetByAttribute", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetDeletedAssetByAttribute), ctx, whereClause)
+}
+
+// GetDeletedAssets mocks base method.
+func (m *MockAssetRepositoryInterface) GetDeletedAssets(ctx context.Context, pagination *dto.MetaPagination) ([]*models.Asset, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedAssets", ctx, pagination)
+	ret0, _ := ret[0].([]*models.Asset)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeletedAssets indicates an expected call of GetDeletedAssets.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetDeletedAssets(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedAssets", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetDeletedAssets), ctx, pagination)
+}
+
+// GetDeletedAssetsBefore mocks base method.
+func (m *MockAssetRepositoryInterface) GetDeletedAssetsBefore(ctx context.Context, before time.Time) ([]*models.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedAssetsBefore", ctx, before)
+	ret0, _ := ret[0].([]*models.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedAssetsBefore indicates an expected call of GetDeletedAssetsBefore.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) GetDeletedAssetsBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedAssetsBefore", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).GetDeletedAssetsBefore), ctx, before)
+}
+
+// PatchAsset mocks base method.
+func (m *MockAssetRepositoryInterface) PatchAsset(ctx context.Context, asset *models.Asset, columns []string, tx *gorm.DB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchAsset", ctx, asset, columns, tx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchAsset indicates an expected call of PatchAsset.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) PatchAsset(ctx, asset, columns, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).PatchAsset), ctx, asset, columns, tx)
+}
+
+// PurgeAsset mocks base method.
+func (m *MockAssetRepositoryInterface) PurgeAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAsset", ctx, asset, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeAsset indicates an expected call of PurgeAsset.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) PurgeAsset(ctx, asset, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).PurgeAsset), ctx, asset, tx)
+}
+
+// RestoreAsset mocks base method.
+func (m *MockAssetRepositoryInterface) RestoreAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAsset", ctx, asset, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAsset indicates an expected call of RestoreAsset.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) RestoreAsset(ctx, asset, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).RestoreAsset), ctx, asset, tx)
 }
 
 // RollbackTransaction mocks base method.
@@ -125,30 +279,74 @@ func (mr *MockAssetRepositoryInterfaceMockRecorder) RollbackTransaction(arg0 int
 }
 
 // StartTransaction mocks base method.
-func (m *MockAssetRepositoryInterface) StartTransaction() *gorm.DB {
+func (m *MockAssetRepositoryInterface) StartTransaction(ctx context.Context) *gorm.DB {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartTransaction")
+	ret := m.ctrl.Call(m, "StartTransaction", ctx)
 	ret0, _ := ret[0].(*gorm.DB)
 	return ret0
 }
 
 // StartTransaction indicates an expected call of StartTransaction.
-func (mr *MockAssetRepositoryInterfaceMockRecorder) StartTransaction() *gomock.Call {
+func (mr *MockAssetRepositoryInterfaceMockRecorder) StartTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTransaction", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).StartTransaction), ctx)
+}
+
+// StreamAssets mocks base method.
+func (m *MockAssetRepositoryInterface) StreamAssets(ctx context.Context, filter *dto.AssetFilterDto, pagination *dto.MetaPagination, fn func(*models.Asset) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamAssets", ctx, filter, pagination, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamAssets indicates an expected call of StreamAssets.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) StreamAssets(ctx, filter, pagination, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamAssets", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).StreamAssets), ctx, filter, pagination, fn)
+}
+
+// SumAssetValue mocks base method.
+func (m *MockAssetRepositoryInterface) SumAssetValue(ctx context.Context, filter *dto.AssetFilterDto) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAssetValue", ctx, filter)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAssetValue indicates an expected call of SumAssetValue.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) SumAssetValue(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAssetValue", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).SumAssetValue), ctx, filter)
+}
+
+// TransitionAsset mocks base method.
+func (m *MockAssetRepositoryInterface) TransitionAsset(ctx context.Context, asset *models.Asset, fromStatus string, tx *gorm.DB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionAsset", ctx, asset, fromStatus, tx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionAsset indicates an expected call of TransitionAsset.
+func (mr *MockAssetRepositoryInterfaceMockRecorder) TransitionAsset(ctx, asset, fromStatus, tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTransaction", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).StartTransaction))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).TransitionAsset), ctx, asset, fromStatus, tx)
 }
 
 // UpdateAsset mocks base method.
-func (m *MockAssetRepositoryInterface) UpdateAsset(asset *models.Asset, tx *gorm.DB) (*models.Asset, error) {
+func (m *MockAssetRepositoryInterface) UpdateAsset(ctx context.Context, asset *models.Asset, tx *gorm.DB) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAsset", asset, tx)
-	ret0, _ := ret[0].(*models.Asset)
+	ret := m.ctrl.Call(m, "UpdateAsset", ctx, asset, tx)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateAsset indicates an expected call of UpdateAsset.
-func (mr *MockAssetRepositoryInterfaceMockRecorder) UpdateAsset(asset, tx interface{}) *gomock.Call {
+func (mr *MockAssetRepositoryInterfaceMockRecorder) UpdateAsset(ctx, asset, tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).UpdateAsset), asset, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAsset", reflect.TypeOf((*MockAssetRepositoryInterface)(nil).UpdateAsset), ctx, asset, tx)
 }